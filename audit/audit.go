@@ -0,0 +1,151 @@
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Redacted replaces the value of sensitive request parameters
+const Redacted = "***"
+
+// sensitiveKeys are substrings of parameter names whose values are never logged
+var sensitiveKeys = []string{"secret", "password", "passwd", "token", "credential", "private"}
+
+// Entry represents a single change made from the TUI
+type Entry struct {
+	Time      time.Time              `json:"time"`
+	Profile   string                 `json:"profile"`
+	Account   string                 `json:"account,omitempty"`
+	Region    string                 `json:"region"`
+	Action    string                 `json:"action"`
+	Resources []string               `json:"resources"`
+	Params    map[string]interface{} `json:"params,omitempty"`
+	Result    string                 `json:"result"`
+	Error     string                 `json:"error,omitempty"`
+}
+
+// Logger appends entries to a JSON lines file
+type Logger struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewLogger creates a new logger writing to the given file
+func NewLogger(path string) *Logger {
+	return &Logger{path: path}
+}
+
+// Path returns the location of the log file
+func (l *Logger) Path() string {
+	return l.path
+}
+
+// Record redacts and appends an entry to the log
+func (l *Logger) Record(entry Entry) error {
+	entry.Params = Redact(entry.Params)
+
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to encode audit entry: %w", err)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(l.path), 0o700); err != nil {
+		return fmt.Errorf("failed to create audit log directory: %w", err)
+	}
+	file, err := os.OpenFile(l.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to open audit log: %w", err)
+	}
+	defer file.Close()
+
+	if _, err := file.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("failed to write audit log: %w", err)
+	}
+	return nil
+}
+
+// Read returns every entry in the log, oldest first, and how many lines could not be parsed. A line
+// cut short by a crash while appending is skipped rather than hiding the rest of the log.
+func (l *Logger) Read() ([]Entry, int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	file, err := os.Open(l.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, 0, nil
+	}
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to open audit log: %w", err)
+	}
+	defer file.Close()
+
+	var entries []Entry
+	skipped := 0
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		if len(strings.TrimSpace(scanner.Text())) == 0 {
+			continue
+		}
+		var entry Entry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			skipped++
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, 0, fmt.Errorf("failed to read audit log: %w", err)
+	}
+	return entries, skipped, nil
+}
+
+// Redact returns a copy of the parameters with sensitive values replaced
+func Redact(params map[string]interface{}) map[string]interface{} {
+	if params == nil {
+		return nil
+	}
+	redacted := make(map[string]interface{}, len(params))
+	for key, value := range params {
+		if isSensitive(key) {
+			redacted[key] = Redacted
+			continue
+		}
+		redacted[key] = redactValue(value)
+	}
+	return redacted
+}
+
+func redactValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		return Redact(v)
+	case []interface{}:
+		values := make([]interface{}, len(v))
+		for i, item := range v {
+			values[i] = redactValue(item)
+		}
+		return values
+	}
+	return value
+}
+
+func isSensitive(key string) bool {
+	key = strings.ToLower(key)
+	for _, s := range sensitiveKeys {
+		if strings.Contains(key, s) {
+			return true
+		}
+	}
+	return false
+}
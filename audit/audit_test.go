@@ -0,0 +1,97 @@
+package audit
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedact(t *testing.T) {
+	params := map[string]interface{}{
+		"name":         "/app/db",
+		"SecretString": "hunter2",
+		"nested": map[string]interface{}{
+			"Password": "hunter2",
+			"type":     "SecureString",
+		},
+		"items": []interface{}{
+			map[string]interface{}{"SessionToken": "abc", "key": "env"},
+		},
+	}
+
+	assert.Equal(t, map[string]interface{}{
+		"name":         "/app/db",
+		"SecretString": Redacted,
+		"nested": map[string]interface{}{
+			"Password": Redacted,
+			"type":     "SecureString",
+		},
+		"items": []interface{}{
+			map[string]interface{}{"SessionToken": Redacted, "key": "env"},
+		},
+	}, Redact(params))
+
+	// The original parameters are left untouched
+	assert.Equal(t, "hunter2", params["SecretString"])
+}
+
+func TestLogger(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state", "audit.jsonl")
+	logger := NewLogger(path)
+
+	t.Run("Missing log", func(t *testing.T) {
+		entries, skipped, err := logger.Read()
+		assert.NoError(t, err)
+		assert.Empty(t, entries)
+		assert.Zero(t, skipped)
+	})
+
+	first := Entry{
+		Time:      time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC),
+		Profile:   "dev",
+		Account:   "123456789012",
+		Region:    "eu-west-1",
+		Action:    "secretsmanager:TagResource",
+		Resources: []string{"arn:aws:secretsmanager:eu-west-1:123456789012:secret:db"},
+		Params:    map[string]interface{}{"SecretString": "hunter2"},
+		Result:    "success",
+	}
+	second := first
+	second.Action = "ecr:StartImageScan"
+	second.Params = nil
+	second.Result = "error"
+	second.Error = "access denied"
+
+	assert.NoError(t, logger.Record(first))
+	assert.NoError(t, logger.Record(second))
+
+	entries, skipped, err := logger.Read()
+	assert.NoError(t, err)
+	assert.Len(t, entries, 2)
+	assert.Zero(t, skipped)
+	assert.Equal(t, Redacted, entries[0].Params["SecretString"])
+	assert.Equal(t, second, entries[1])
+
+	info, err := os.Stat(path)
+	assert.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	t.Run("Corrupt lines", func(t *testing.T) {
+		// A crash mid-append leaves a truncated line, later entries still follow it
+		file, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0o600)
+		assert.NoError(t, err)
+		_, err = file.WriteString(`{"time":"2024-01-15T10:31:00Z","profile":"dev","act` + "\nnot json\n")
+		assert.NoError(t, err)
+		assert.NoError(t, file.Close())
+		assert.NoError(t, logger.Record(first))
+
+		entries, skipped, err := logger.Read()
+		assert.NoError(t, err)
+		assert.Len(t, entries, 3)
+		assert.Equal(t, 2, skipped)
+		assert.Equal(t, first.Action, entries[2].Action)
+	})
+}
@@ -0,0 +1,108 @@
+package aws
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+)
+
+// Shell represents the syntax used to export environment variables
+type Shell string
+
+const (
+	ShellPOSIX      Shell = "bash"
+	ShellFish       Shell = "fish"
+	ShellPowerShell Shell = "powershell"
+)
+
+// Shells lists the supported export syntaxes
+var Shells = []Shell{ShellPOSIX, ShellFish, ShellPowerShell}
+
+// credentialVars are cleared from a subshell so stale keys or profiles cannot win over the new ones
+var credentialVars = []string{
+	"AWS_PROFILE",
+	"AWS_DEFAULT_PROFILE",
+	"AWS_ACCESS_KEY_ID",
+	"AWS_SECRET_ACCESS_KEY",
+	"AWS_SESSION_TOKEN",
+	"AWS_SECURITY_TOKEN",
+	"AWS_CREDENTIAL_EXPIRATION",
+}
+
+// EnvVar represents an environment variable to export
+type EnvVar struct {
+	Name  string
+	Value string
+}
+
+// CredentialEnv returns the environment variables that make the CLI and SDKs use resolved credentials
+func CredentialEnv(creds aws.Credentials, region string) []EnvVar {
+	vars := []EnvVar{
+		{"AWS_ACCESS_KEY_ID", creds.AccessKeyID},
+		{"AWS_SECRET_ACCESS_KEY", creds.SecretAccessKey},
+	}
+	if creds.SessionToken != "" {
+		vars = append(vars, EnvVar{"AWS_SESSION_TOKEN", creds.SessionToken})
+	}
+	if creds.CanExpire {
+		vars = append(vars, EnvVar{"AWS_CREDENTIAL_EXPIRATION", creds.Expires.UTC().Format(time.RFC3339)})
+	}
+	if region != "" {
+		vars = append(vars, EnvVar{"AWS_REGION", region}, EnvVar{"AWS_DEFAULT_REGION", region})
+	}
+	return vars
+}
+
+// ExportLines renders environment variables as commands for a shell
+func ExportLines(vars []EnvVar, shell Shell) string {
+	var b strings.Builder
+	for _, v := range vars {
+		switch shell {
+		case ShellFish:
+			fmt.Fprintf(&b, "set -gx %s %s\n", v.Name, fishQuote(v.Value))
+		case ShellPowerShell:
+			fmt.Fprintf(&b, "$Env:%s = %s\n", v.Name, powerShellQuote(v.Value))
+		default:
+			fmt.Fprintf(&b, "export %s=%s\n", v.Name, posixQuote(v.Value))
+		}
+	}
+	return b.String()
+}
+
+// ShellEnv returns a base environment with any previous credentials replaced by vars
+func ShellEnv(base []string, vars []EnvVar) []string {
+	replaced := make(map[string]bool)
+	for _, name := range credentialVars {
+		replaced[name] = true
+	}
+	for _, v := range vars {
+		replaced[v.Name] = true
+	}
+
+	var env []string
+	for _, entry := range base {
+		name, _, _ := strings.Cut(entry, "=")
+		if !replaced[name] {
+			env = append(env, entry)
+		}
+	}
+	for _, v := range vars {
+		env = append(env, v.Name+"="+v.Value)
+	}
+	return env
+}
+
+func posixQuote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
+
+func fishQuote(value string) string {
+	value = strings.ReplaceAll(value, `\`, `\\`)
+	return "'" + strings.ReplaceAll(value, "'", `\'`) + "'"
+}
+
+func powerShellQuote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", "''") + "'"
+}
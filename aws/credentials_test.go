@@ -0,0 +1,60 @@
+package aws
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCredentialExports(t *testing.T) {
+	creds := aws.Credentials{
+		AccessKeyID:     "AKIAEXAMPLE",
+		SecretAccessKey: "it's/secret",
+		SessionToken:    "token",
+		CanExpire:       true,
+		Expires:         time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+	}
+	vars := CredentialEnv(creds, "eu-west-1")
+
+	t.Run("Variables", func(t *testing.T) {
+		assert.Equal(t, []EnvVar{
+			{"AWS_ACCESS_KEY_ID", "AKIAEXAMPLE"},
+			{"AWS_SECRET_ACCESS_KEY", "it's/secret"},
+			{"AWS_SESSION_TOKEN", "token"},
+			{"AWS_CREDENTIAL_EXPIRATION", "2024-01-02T03:04:05Z"},
+			{"AWS_REGION", "eu-west-1"},
+			{"AWS_DEFAULT_REGION", "eu-west-1"},
+		}, vars)
+
+		static := CredentialEnv(aws.Credentials{AccessKeyID: "a", SecretAccessKey: "b"}, "")
+		assert.Len(t, static, 2)
+	})
+
+	t.Run("Bash", func(t *testing.T) {
+		lines := ExportLines(vars[:2], ShellPOSIX)
+		assert.Equal(t, "export AWS_ACCESS_KEY_ID='AKIAEXAMPLE'\nexport AWS_SECRET_ACCESS_KEY='it'\\''s/secret'\n", lines)
+	})
+
+	t.Run("Fish", func(t *testing.T) {
+		lines := ExportLines(vars[:2], ShellFish)
+		assert.Equal(t, "set -gx AWS_ACCESS_KEY_ID 'AKIAEXAMPLE'\nset -gx AWS_SECRET_ACCESS_KEY 'it\\'s/secret'\n", lines)
+	})
+
+	t.Run("PowerShell", func(t *testing.T) {
+		lines := ExportLines(vars[:2], ShellPowerShell)
+		assert.Equal(t, "$Env:AWS_ACCESS_KEY_ID = 'AKIAEXAMPLE'\n$Env:AWS_SECRET_ACCESS_KEY = 'it''s/secret'\n", lines)
+	})
+
+	t.Run("Shell environment", func(t *testing.T) {
+		base := []string{"HOME=/home/alice", "AWS_PROFILE=dev", "AWS_SESSION_TOKEN=old", "AWS_REGION=us-east-1"}
+		env := ShellEnv(base, vars[:2])
+		assert.Equal(t, []string{
+			"HOME=/home/alice",
+			"AWS_REGION=us-east-1",
+			"AWS_ACCESS_KEY_ID=AKIAEXAMPLE",
+			"AWS_SECRET_ACCESS_KEY=it's/secret",
+		}, env)
+	})
+}
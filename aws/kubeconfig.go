@@ -0,0 +1,195 @@
+package aws
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// KubeconfigEntry describes the cluster, user and context written for an EKS cluster. All three
+// are named after the cluster ARN, like "aws eks update-kubeconfig" does.
+type KubeconfigEntry struct {
+	Name                 string // Cluster ARN
+	ClusterName          string
+	Region               string
+	Server               string
+	CertificateAuthority string // Base64 encoded
+	Profile              string // Set as AWS_PROFILE for the token command, empty to leave it out
+}
+
+type kubeconfigCluster struct {
+	Cluster struct {
+		CertificateAuthorityData string `yaml:"certificate-authority-data"`
+		Server                   string `yaml:"server"`
+	} `yaml:"cluster"`
+	Name string `yaml:"name"`
+}
+
+type kubeconfigContext struct {
+	Context struct {
+		Cluster string `yaml:"cluster"`
+		User    string `yaml:"user"`
+	} `yaml:"context"`
+	Name string `yaml:"name"`
+}
+
+type kubeconfigUser struct {
+	Name string `yaml:"name"`
+	User struct {
+		Exec kubeconfigExec `yaml:"exec"`
+	} `yaml:"user"`
+}
+
+type kubeconfigExec struct {
+	APIVersion string          `yaml:"apiVersion"`
+	Args       []string        `yaml:"args"`
+	Command    string          `yaml:"command"`
+	Env        []kubeconfigEnv `yaml:"env,omitempty"`
+}
+
+type kubeconfigEnv struct {
+	Name  string `yaml:"name"`
+	Value string `yaml:"value"`
+}
+
+// KubeconfigPath returns the kubeconfig file kubectl reads first, honoring KUBECONFIG
+func KubeconfigPath() (string, error) {
+	if paths := filepath.SplitList(os.Getenv("KUBECONFIG")); len(paths) > 0 && paths[0] != "" {
+		return paths[0], nil
+	}
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", errors.New("failed to get user home directory")
+	}
+	return filepath.Join(homeDir, ".kube", "config"), nil
+}
+
+// WriteKubeconfig adds or replaces the entry of a cluster in a kubeconfig file and makes it the
+// current context
+func WriteKubeconfig(path string, entry KubeconfigEntry) error {
+	data, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	merged, err := MergeKubeconfig(data, entry)
+	if err != nil {
+		return fmt.Errorf("could not parse %s: %w", path, err)
+	}
+	return replaceFile(path, merged)
+}
+
+// MergeKubeconfig adds or replaces the cluster, user and context of an entry in a kubeconfig,
+// keeping every other entry and comment as it was
+func MergeKubeconfig(data []byte, entry KubeconfigEntry) ([]byte, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	if doc.Kind == 0 {
+		doc = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode}}}
+	}
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return nil, errors.New("kubeconfig is not a mapping")
+	}
+
+	// Start new files with the fields kubectl writes
+	if mappingValue(root, "apiVersion") == nil {
+		setMappingValue(root, "apiVersion", scalar("v1"))
+		setMappingValue(root, "kind", scalar("Config"))
+		setMappingValue(root, "preferences", &yaml.Node{Kind: yaml.MappingNode, Style: yaml.FlowStyle})
+	}
+
+	var cluster kubeconfigCluster
+	cluster.Name = entry.Name
+	cluster.Cluster.Server = entry.Server
+	cluster.Cluster.CertificateAuthorityData = entry.CertificateAuthority
+
+	var context kubeconfigContext
+	context.Name = entry.Name
+	context.Context.Cluster = entry.Name
+	context.Context.User = entry.Name
+
+	var user kubeconfigUser
+	user.Name = entry.Name
+	user.User.Exec = kubeconfigExec{
+		APIVersion: "client.authentication.k8s.io/v1beta1",
+		Command:    "aws",
+		Args:       []string{"--region", entry.Region, "eks", "get-token", "--cluster-name", entry.ClusterName, "--output", "json"},
+	}
+	if entry.Profile != "" {
+		user.User.Exec.Env = []kubeconfigEnv{{Name: "AWS_PROFILE", Value: entry.Profile}}
+	}
+
+	lists := []struct {
+		key  string
+		item interface{}
+	}{{"clusters", cluster}, {"contexts", context}, {"users", user}}
+	for _, list := range lists {
+		var node yaml.Node
+		if err := node.Encode(list.item); err != nil {
+			return nil, err
+		}
+		upsertNamed(root, list.key, entry.Name, &node)
+	}
+	setMappingValue(root, "current-context", scalar(entry.Name))
+
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&doc); err != nil {
+		return nil, err
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// upsertNamed replaces the item with the given name in a list of the mapping, or appends it
+func upsertNamed(mapping *yaml.Node, key, name string, item *yaml.Node) {
+	list := mappingValue(mapping, key)
+	if list == nil || list.Kind != yaml.SequenceNode {
+		list = &yaml.Node{Kind: yaml.SequenceNode}
+		setMappingValue(mapping, key, list)
+	}
+	for i, existing := range list.Content {
+		if n := mappingValue(existing, "name"); n != nil && n.Value == name {
+			list.Content[i] = item
+			return
+		}
+	}
+	list.Content = append(list.Content, item)
+}
+
+// mappingValue returns the value of a key in a mapping node, or nil if it has none
+func mappingValue(mapping *yaml.Node, key string) *yaml.Node {
+	if mapping.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return mapping.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// setMappingValue sets the value of a key in a mapping node, adding the key if needed
+func setMappingValue(mapping *yaml.Node, key string, value *yaml.Node) {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			mapping.Content[i+1] = value
+			return
+		}
+	}
+	mapping.Content = append(mapping.Content, scalar(key), value)
+}
+
+func scalar(value string) *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value}
+}
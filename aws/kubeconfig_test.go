@@ -0,0 +1,133 @@
+package aws
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+var testEntry = KubeconfigEntry{
+	Name:                 "arn:aws:eks:eu-west-1:123456789012:cluster/prod",
+	ClusterName:          "prod",
+	Region:               "eu-west-1",
+	Server:               "https://ABC.gr7.eu-west-1.eks.amazonaws.com",
+	CertificateAuthority: "LS0tLS1CRUdJTg==",
+	Profile:              "prod-admin",
+}
+
+func TestMergeKubeconfigNewFile(t *testing.T) {
+	data, err := MergeKubeconfig(nil, testEntry)
+	assert.NoError(t, err)
+	assert.Equal(t, `apiVersion: v1
+kind: Config
+preferences: {}
+clusters:
+  - cluster:
+      certificate-authority-data: LS0tLS1CRUdJTg==
+      server: https://ABC.gr7.eu-west-1.eks.amazonaws.com
+    name: arn:aws:eks:eu-west-1:123456789012:cluster/prod
+contexts:
+  - context:
+      cluster: arn:aws:eks:eu-west-1:123456789012:cluster/prod
+      user: arn:aws:eks:eu-west-1:123456789012:cluster/prod
+    name: arn:aws:eks:eu-west-1:123456789012:cluster/prod
+users:
+  - name: arn:aws:eks:eu-west-1:123456789012:cluster/prod
+    user:
+      exec:
+        apiVersion: client.authentication.k8s.io/v1beta1
+        args:
+          - --region
+          - eu-west-1
+          - eks
+          - get-token
+          - --cluster-name
+          - prod
+          - --output
+          - json
+        command: aws
+        env:
+          - name: AWS_PROFILE
+            value: prod-admin
+current-context: arn:aws:eks:eu-west-1:123456789012:cluster/prod
+`, string(data))
+}
+
+func TestMergeKubeconfigExisting(t *testing.T) {
+	existing := `apiVersion: v1
+kind: Config
+# kind cluster for local testing
+clusters:
+- cluster:
+    server: https://127.0.0.1:6443
+  name: kind
+- cluster:
+    server: https://old.example.com
+  name: arn:aws:eks:eu-west-1:123456789012:cluster/prod
+contexts:
+- context:
+    cluster: kind
+    user: kind
+  name: kind
+users:
+- name: kind
+  user:
+    token: secret
+current-context: kind
+`
+	entry := testEntry
+	entry.Profile = ""
+	data, err := MergeKubeconfig([]byte(existing), entry)
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), "# kind cluster for local testing")
+
+	var config struct {
+		Clusters []struct {
+			Name    string
+			Cluster map[string]string
+		}
+		Contexts []struct{ Name string }
+		Users    []struct {
+			Name string
+			User map[string]interface{}
+		}
+		CurrentContext string `yaml:"current-context"`
+	}
+	assert.NoError(t, yaml.Unmarshal(data, &config))
+
+	assert.Len(t, config.Clusters, 2)
+	assert.Equal(t, "kind", config.Clusters[0].Name)
+	assert.Equal(t, testEntry.Server, config.Clusters[1].Cluster["server"])
+	assert.Len(t, config.Contexts, 2)
+	assert.Len(t, config.Users, 2)
+	assert.Equal(t, "secret", config.Users[0].User["token"])
+	assert.NotContains(t, string(data), "AWS_PROFILE")
+	assert.Equal(t, testEntry.Name, config.CurrentContext)
+}
+
+func TestWriteKubeconfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".kube", "config")
+
+	assert.NoError(t, WriteKubeconfig(path, testEntry))
+	assert.NoError(t, WriteKubeconfig(path, testEntry))
+
+	info, err := os.Stat(path)
+	assert.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	data, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	first, err := MergeKubeconfig(nil, testEntry)
+	assert.NoError(t, err)
+	assert.Equal(t, string(first), string(data))
+}
+
+func TestKubeconfigPath(t *testing.T) {
+	t.Setenv("KUBECONFIG", "/tmp/a"+string(os.PathListSeparator)+"/tmp/b")
+	path, err := KubeconfigPath()
+	assert.NoError(t, err)
+	assert.Equal(t, "/tmp/a", path)
+}
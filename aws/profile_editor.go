@@ -0,0 +1,490 @@
+package aws
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// backupSuffix is appended to a shared file's name for the copy kept before each write
+const backupSuffix = ".bak"
+
+// Outputs lists the output formats the AWS CLI accepts
+var Outputs = []string{"json", "yaml", "yaml-stream", "text", "table"}
+
+// ProfileSpec represents a new profile to write to the shared files
+type ProfileSpec struct {
+	Name   string
+	Source CredentialSource // SourceStatic, SourceSSO or SourceAssumeRole
+	Region string
+	Output string
+
+	// Static keys
+	AccessKeyID     string
+	SecretAccessKey string
+
+	// SSO, where the start URL and region are only used to create a missing sso-session
+	SSOSession   string
+	SSOStartURL  string
+	SSORegion    string
+	SSOAccountID string
+	SSORoleName  string
+
+	// Assume role
+	RoleARN       string
+	SourceProfile string
+	MFASerial     string
+}
+
+// sharedFile represents a shared config or credentials file loaded for editing. It is edited line by
+// line, so only the changed keys are rewritten and comments, blank lines and nested settings such as
+// an indented s3 block are written back exactly as they were.
+type sharedFile struct {
+	path  string
+	lines []string // Without their line breaks
+}
+
+// CreateProfile validates a profile and writes it to the shared files
+func (pm *ProfileManager) CreateProfile(spec ProfileSpec) error {
+	if err := pm.validateSpec(spec); err != nil {
+		return err
+	}
+
+	configFile, err := loadSharedFile(ConfigFilePath)
+	if err != nil {
+		return err
+	}
+	section := configSectionName(spec.Name)
+	configFile.addSection(section)
+	configFile.setKeys(section,
+		"region", spec.Region,
+		"output", spec.Output,
+	)
+
+	var credentialsFile *sharedFile
+	switch spec.Source {
+	case SourceStatic:
+		// Keys go to the credentials file like `aws configure` does
+		credentialsFile, err = loadSharedFile(CredentialsFilePath)
+		if err != nil {
+			return err
+		}
+		credentialsFile.addSection(spec.Name)
+		credentialsFile.setKeys(spec.Name,
+			"aws_access_key_id", spec.AccessKeyID,
+			"aws_secret_access_key", spec.SecretAccessKey,
+		)
+	case SourceSSO:
+		configFile.setKeys(section,
+			"sso_session", spec.SSOSession,
+			"sso_account_id", spec.SSOAccountID,
+			"sso_role_name", spec.SSORoleName,
+		)
+		if session := "sso-session " + spec.SSOSession; !configFile.hasSection(session) {
+			configFile.addSection(session)
+			configFile.setKeys(session,
+				"sso_start_url", spec.SSOStartURL,
+				"sso_region", spec.SSORegion,
+				"sso_registration_scopes", "sso:account:access",
+			)
+		}
+	case SourceAssumeRole:
+		configFile.setKeys(section,
+			"role_arn", spec.RoleARN,
+			"source_profile", spec.SourceProfile,
+			"mfa_serial", spec.MFASerial,
+		)
+	}
+
+	// Write the keys before the profile that refers to them
+	if credentialsFile != nil {
+		if err := credentialsFile.save(); err != nil {
+			return err
+		}
+	}
+	if err := configFile.save(); err != nil {
+		return err
+	}
+	return pm.LoadProfiles()
+}
+
+// UpdateProfile changes the region and output of a profile, removing them when empty
+func (pm *ProfileManager) UpdateProfile(name, region, output string) error {
+	if _, ok := pm.findProfile(name); !ok {
+		return fmt.Errorf("profile %s not found", name)
+	}
+	if output != "" && !slices.Contains(Outputs, output) {
+		return fmt.Errorf("unknown output format %q", output)
+	}
+
+	configFile, err := loadSharedFile(ConfigFilePath)
+	if err != nil {
+		return err
+	}
+	section, ok := configFile.profileSection(name)
+	if !ok {
+		// Profiles that only exist in the credentials file get a config section
+		section = configSectionName(name)
+		configFile.addSection(section)
+	}
+	configFile.updateKeys(section, "region", region, "output", output)
+
+	// The credentials file wins when both set a value, so keep it in step
+	credentialsFile, err := loadSharedFile(CredentialsFilePath)
+	if err != nil {
+		return err
+	}
+	if credentialsFile.hasSection(name) {
+		var changed bool
+		for _, kv := range [][2]string{{"region", region}, {"output", output}} {
+			if credentialsFile.hasKey(name, kv[0]) {
+				credentialsFile.updateKeys(name, kv[0], kv[1])
+				changed = true
+			}
+		}
+		if changed {
+			if err := credentialsFile.save(); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := configFile.save(); err != nil {
+		return err
+	}
+	return pm.LoadProfiles()
+}
+
+// DeleteProfile removes a profile from both shared files
+func (pm *ProfileManager) DeleteProfile(name string) error {
+	if _, ok := pm.findProfile(name); !ok {
+		return fmt.Errorf("profile %s not found", name)
+	}
+
+	// Deleting a source profile would break the roles assumed from it
+	var dependents []string
+	for _, p := range pm.GetAllProfiles() {
+		if p.SourceProfile == name {
+			dependents = append(dependents, p.Name)
+		}
+	}
+	if len(dependents) > 0 {
+		return fmt.Errorf("profile %s is the source_profile of %s", name, strings.Join(dependents, ", "))
+	}
+
+	// The config file may spell the default profile either way
+	files := []struct {
+		pathFn   func() (string, error)
+		sections []string
+	}{
+		{ConfigFilePath, []string{"profile " + name, configSectionName(name)}},
+		{CredentialsFilePath, []string{name}},
+	}
+	for _, f := range files {
+		shared, err := loadSharedFile(f.pathFn)
+		if err != nil {
+			return err
+		}
+
+		var removed bool
+		for _, sectionName := range f.sections {
+			for shared.deleteSection(sectionName) {
+				removed = true
+			}
+		}
+		if removed {
+			if err := shared.save(); err != nil {
+				return err
+			}
+		}
+	}
+	return pm.LoadProfiles()
+}
+
+// validateSpec checks a new profile has a unique name and every setting its source needs
+func (pm *ProfileManager) validateSpec(spec ProfileSpec) error {
+	switch {
+	case spec.Name == "":
+		return errors.New("profile name is required")
+	case strings.ContainsAny(spec.Name, "[] \t"):
+		return errors.New("profile name cannot contain spaces or brackets")
+	case spec.Output != "" && !slices.Contains(Outputs, spec.Output):
+		return fmt.Errorf("unknown output format %q", spec.Output)
+	}
+	if _, ok := pm.findProfile(spec.Name); ok {
+		return fmt.Errorf("profile %s already exists", spec.Name)
+	}
+
+	switch spec.Source {
+	case SourceStatic:
+		if spec.AccessKeyID == "" || spec.SecretAccessKey == "" {
+			return errors.New("access key ID and secret access key are required")
+		}
+	case SourceSSO:
+		if spec.SSOSession == "" || spec.SSOAccountID == "" || spec.SSORoleName == "" {
+			return errors.New("SSO session, account ID and role name are required")
+		}
+		var known bool
+		for _, s := range pm.GetSSOSessions() {
+			known = known || s.Name == spec.SSOSession
+		}
+		if !known && (spec.SSOStartURL == "" || spec.SSORegion == "") {
+			return fmt.Errorf("sso-session %s does not exist, so a start URL and SSO region are required", spec.SSOSession)
+		}
+	case SourceAssumeRole:
+		if spec.RoleARN == "" || spec.SourceProfile == "" {
+			return errors.New("role ARN and source profile are required")
+		}
+		if _, ok := pm.findProfile(spec.SourceProfile); !ok {
+			return fmt.Errorf("source profile %s not found", spec.SourceProfile)
+		}
+	default:
+		return fmt.Errorf("cannot create %q profiles", spec.Source)
+	}
+	return nil
+}
+
+func (pm *ProfileManager) findProfile(name string) (Profile, bool) {
+	for _, p := range pm.GetAllProfiles() {
+		if p.Name == name {
+			return p, true
+		}
+	}
+	return Profile{}, false
+}
+
+// loadSharedFile loads a shared file for editing, starting empty when it does not exist
+func loadSharedFile(pathFn func() (string, error)) (*sharedFile, error) {
+	path, err := pathFn()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	var lines []string
+	if len(data) > 0 {
+		lines = strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+	}
+	return &sharedFile{path: path, lines: lines}, nil
+}
+
+// save backs up the current file, then replaces it without changing its permissions
+func (f *sharedFile) save() error {
+	var data string
+	if len(f.lines) > 0 {
+		data = strings.Join(f.lines, "\n") + "\n"
+	}
+	return replaceFile(f.path, []byte(data))
+}
+
+// replaceFile backs up a file, then replaces it with data without changing its permissions. Files
+// that do not exist yet are created readable by the owner only.
+func replaceFile(path string, data []byte) error {
+	mode := os.FileMode(0o600)
+	if info, err := os.Stat(path); err == nil {
+		mode = info.Mode().Perm()
+		if err := copyFile(path, path+backupSuffix, mode); err != nil {
+			return fmt.Errorf("failed to back up %s: %w", path, err)
+		}
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+
+	// Write next to the file and rename so a failed write never leaves it truncated
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), mode); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
+
+// configSectionName returns the config file section of a profile
+func configSectionName(name string) string {
+	if name == "default" {
+		return "default"
+	}
+	return "profile " + name
+}
+
+// profileSection returns the config file section of a profile, accepting both spellings of default
+func (f *sharedFile) profileSection(name string) (string, bool) {
+	for _, section := range []string{"profile " + name, configSectionName(name)} {
+		if f.hasSection(section) {
+			return section, true
+		}
+	}
+	return "", false
+}
+
+// hasSection reports whether the file has a section
+func (f *sharedFile) hasSection(section string) bool {
+	_, ok := f.findSection(section)
+	return ok
+}
+
+// hasKey reports whether a section sets a key
+func (f *sharedFile) hasKey(section, key string) bool {
+	_, _, ok := f.findKey(section, key)
+	return ok
+}
+
+// addSection appends an empty section, separated from the previous one by a blank line
+func (f *sharedFile) addSection(section string) {
+	if n := len(f.lines); n > 0 && strings.TrimSpace(f.lines[n-1]) != "" {
+		f.lines = append(f.lines, "")
+	}
+	f.lines = append(f.lines, "["+section+"]")
+}
+
+// deleteSection removes the first section of a name with its keys and the blank lines after it,
+// reporting whether there was one. Comments above the next section stay.
+func (f *sharedFile) deleteSection(section string) bool {
+	start, ok := f.findSection(section)
+	if !ok {
+		return false
+	}
+	end := f.sectionEnd(start)
+	for end < len(f.lines) && strings.TrimSpace(f.lines[end]) == "" {
+		end++
+	}
+	f.lines = slices.Delete(f.lines, start, end)
+	return true
+}
+
+// setKeys sets key/value pairs on a section, skipping empty values
+func (f *sharedFile) setKeys(section string, pairs ...string) {
+	for i := 0; i+1 < len(pairs); i += 2 {
+		if pairs[i+1] != "" {
+			f.setKey(section, pairs[i], pairs[i+1])
+		}
+	}
+}
+
+// updateKeys sets key/value pairs on a section, deleting keys whose value is empty
+func (f *sharedFile) updateKeys(section string, pairs ...string) {
+	for i := 0; i+1 < len(pairs); i += 2 {
+		if pairs[i+1] == "" {
+			if start, end, ok := f.findKey(section, pairs[i]); ok {
+				f.lines = slices.Delete(f.lines, start, end)
+			}
+		} else {
+			f.setKey(section, pairs[i], pairs[i+1])
+		}
+	}
+}
+
+// setKey replaces the line of a key, or adds it after the last key of the section
+func (f *sharedFile) setKey(section, key, value string) {
+	line := key + " = " + value
+	if start, end, ok := f.findKey(section, key); ok {
+		f.lines = slices.Replace(f.lines, start, end, line)
+		return
+	}
+	if start, ok := f.findSection(section); ok {
+		f.lines = slices.Insert(f.lines, f.sectionEnd(start), line)
+	}
+}
+
+// findSection returns the line of a section header
+func (f *sharedFile) findSection(section string) (int, bool) {
+	for i, line := range f.lines {
+		if name, ok := sectionHeader(line); ok && name == section {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// sectionEnd returns the line after the last key of the section starting at start, leaving the
+// blank lines and comments in front of the next section out
+func (f *sharedFile) sectionEnd(start int) int {
+	end := start + 1
+	for i := start + 1; i < len(f.lines); i++ {
+		line := f.lines[i]
+		if _, ok := sectionHeader(line); ok {
+			break
+		}
+		if _, ok := keyName(line); ok || isNested(line) {
+			end = i + 1
+		}
+	}
+	return end
+}
+
+// findKey returns the lines of a key in a section, including the indented settings nested below it
+func (f *sharedFile) findKey(section, key string) (int, int, bool) {
+	start, ok := f.findSection(section)
+	if !ok {
+		return 0, 0, false
+	}
+	for i := start + 1; i < len(f.lines); i++ {
+		if _, ok := sectionHeader(f.lines[i]); ok {
+			break
+		}
+		if name, ok := keyName(f.lines[i]); ok && name == key {
+			end := i + 1
+			for end < len(f.lines) && isNested(f.lines[end]) {
+				end++
+			}
+			return i, end, true
+		}
+	}
+	return 0, 0, false
+}
+
+// sectionHeader returns the name of a [section] line
+func sectionHeader(line string) (string, bool) {
+	line = strings.TrimSpace(line)
+	if !strings.HasPrefix(line, "[") || !strings.HasSuffix(line, "]") {
+		return "", false
+	}
+	return strings.TrimSpace(line[1 : len(line)-1]), true
+}
+
+// keyName returns the key of a top-level "key = value" line. Indented lines are nested settings.
+func keyName(line string) (string, bool) {
+	if line == "" || isNested(line) {
+		return "", false
+	}
+	line = strings.TrimSpace(line)
+	if strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+		return "", false
+	}
+	key, _, ok := strings.Cut(line, "=")
+	return strings.TrimSpace(key), ok
+}
+
+// isNested reports whether a line is an indented setting, such as max_concurrent_requests below s3
+func isNested(line string) bool {
+	return strings.TrimSpace(line) != "" && (line[0] == ' ' || line[0] == '\t')
+}
+
+func copyFile(src, dst string, mode os.FileMode) error {
+	data, err := os.ReadFile(src)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(dst, data, mode)
+}
@@ -0,0 +1,253 @@
+package aws
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProfileEditor(t *testing.T) {
+	tmpDir := t.TempDir()
+	awsDir := filepath.Join(tmpDir, ".aws")
+	os.MkdirAll(awsDir, 0755)
+
+	configContent := `# Managed by hand
+[default]
+region = us-east-1
+
+; Development account
+[profile dev]
+region=us-west-2
+credential_process = /usr/bin/vault "aws" # not a comment
+
+[sso-session corp]
+sso_start_url = https://corp.awsapps.com/start
+sso_region = us-east-1
+# end of file
+`
+	configPath := filepath.Join(awsDir, "config")
+	err := os.WriteFile(configPath, []byte(configContent), 0644)
+	assert.NoError(t, err)
+
+	credentialsContent := `[default]
+aws_access_key_id = default_key
+aws_secret_access_key = default_secret
+`
+	credentialsPath := filepath.Join(awsDir, "credentials")
+	err = os.WriteFile(credentialsPath, []byte(credentialsContent), 0600)
+	assert.NoError(t, err)
+
+	originalHome := os.Getenv("HOME")
+	originalConfig := os.Getenv("AWS_CONFIG_FILE")
+	originalCredentials := os.Getenv("AWS_SHARED_CREDENTIALS_FILE")
+	os.Setenv("HOME", tmpDir)
+	os.Setenv("AWS_CONFIG_FILE", "")
+	os.Setenv("AWS_SHARED_CREDENTIALS_FILE", "")
+	defer func() {
+		os.Setenv("HOME", originalHome)
+		os.Setenv("AWS_CONFIG_FILE", originalConfig)
+		os.Setenv("AWS_SHARED_CREDENTIALS_FILE", originalCredentials)
+	}()
+
+	pm := NewProfileManager()
+	err = pm.LoadProfiles()
+	assert.NoError(t, err)
+
+	profiles := func() map[string]Profile {
+		result := make(map[string]Profile)
+		for _, p := range pm.GetAllProfiles() {
+			result[p.Name] = p
+		}
+		return result
+	}
+
+	t.Run("Update keeps comments and order", func(t *testing.T) {
+		err := pm.UpdateProfile("dev", "eu-west-1", "json")
+		assert.NoError(t, err)
+
+		data, err := os.ReadFile(configPath)
+		assert.NoError(t, err)
+		assert.Equal(t, `# Managed by hand
+[default]
+region = us-east-1
+
+; Development account
+[profile dev]
+region = eu-west-1
+credential_process = /usr/bin/vault "aws" # not a comment
+output = json
+
+[sso-session corp]
+sso_start_url = https://corp.awsapps.com/start
+sso_region = us-east-1
+# end of file
+`, string(data))
+
+		backup, err := os.ReadFile(configPath + backupSuffix)
+		assert.NoError(t, err)
+		assert.Equal(t, configContent, string(backup))
+
+		assert.Equal(t, "eu-west-1", profiles()["dev"].Region)
+		assert.Equal(t, "json", profiles()["dev"].Output)
+	})
+
+	t.Run("Update removes empty values", func(t *testing.T) {
+		err := pm.UpdateProfile("dev", "eu-west-1", "")
+		assert.NoError(t, err)
+		assert.Empty(t, profiles()["dev"].Output)
+	})
+
+	t.Run("Create static profile", func(t *testing.T) {
+		err := pm.CreateProfile(ProfileSpec{
+			Name:            "ci",
+			Source:          SourceStatic,
+			Region:          "ap-south-1",
+			AccessKeyID:     "ci_key",
+			SecretAccessKey: "ci_secret",
+		})
+		assert.NoError(t, err)
+
+		p := profiles()["ci"]
+		assert.Equal(t, SourceStatic, p.Source)
+		assert.Equal(t, "ap-south-1", p.Region)
+
+		info, err := os.Stat(credentialsPath)
+		assert.NoError(t, err)
+		assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+	})
+
+	t.Run("Create SSO profile with a new session", func(t *testing.T) {
+		err := pm.CreateProfile(ProfileSpec{
+			Name:         "sandbox",
+			Source:       SourceSSO,
+			SSOSession:   "sandbox",
+			SSOStartURL:  "https://sandbox.awsapps.com/start",
+			SSORegion:    "eu-west-1",
+			SSOAccountID: "210987654321",
+			SSORoleName:  "Developer",
+		})
+		assert.NoError(t, err)
+
+		assert.Equal(t, SourceSSO, profiles()["sandbox"].Source)
+		assert.Len(t, pm.GetSSOSessions(), 2)
+	})
+
+	t.Run("Create assume-role profile", func(t *testing.T) {
+		err := pm.CreateProfile(ProfileSpec{
+			Name:          "admin",
+			Source:        SourceAssumeRole,
+			RoleARN:       "arn:aws:iam::123456789012:role/admin",
+			SourceProfile: "ci",
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, SourceAssumeRole, profiles()["admin"].Source)
+	})
+
+	t.Run("Invalid profiles", func(t *testing.T) {
+		specs := []ProfileSpec{
+			{Name: "", Source: SourceStatic, AccessKeyID: "a", SecretAccessKey: "b"},
+			{Name: "dev", Source: SourceStatic, AccessKeyID: "a", SecretAccessKey: "b"},
+			{Name: "has space", Source: SourceStatic, AccessKeyID: "a", SecretAccessKey: "b"},
+			{Name: "nokeys", Source: SourceStatic},
+			{Name: "nosession", Source: SourceSSO, SSOSession: "missing", SSOAccountID: "1", SSORoleName: "r"},
+			{Name: "nosource", Source: SourceAssumeRole, RoleARN: "arn", SourceProfile: "missing"},
+			{Name: "badoutput", Source: SourceStatic, AccessKeyID: "a", SecretAccessKey: "b", Output: "xml"},
+		}
+		for _, spec := range specs {
+			assert.Error(t, pm.CreateProfile(spec), spec.Name)
+		}
+	})
+
+	t.Run("Delete profile", func(t *testing.T) {
+		// ci is the source of admin
+		err := pm.DeleteProfile("ci")
+		assert.Error(t, err)
+
+		err = pm.DeleteProfile("admin")
+		assert.NoError(t, err)
+		err = pm.DeleteProfile("ci")
+		assert.NoError(t, err)
+		assert.NotContains(t, profiles(), "ci")
+
+		data, err := os.ReadFile(credentialsPath)
+		assert.NoError(t, err)
+		assert.NotContains(t, string(data), "ci_key")
+		assert.Contains(t, string(data), "default_key")
+	})
+}
+
+func TestProfileEditorKeepsNestedSettings(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config")
+	credentialsPath := filepath.Join(tmpDir, "credentials")
+	t.Setenv("AWS_CONFIG_FILE", configPath)
+	t.Setenv("AWS_SHARED_CREDENTIALS_FILE", credentialsPath)
+
+	configContent := `[default]
+region = us-east-1
+s3 =
+    max_concurrent_requests = 20
+    multipart_threshold = 64MB
+
+
+[profile dev]
+region = us-west-2
+s3 =
+  addressing_style = path
+output = text
+
+[profile old]
+region = eu-central-1
+
+# Staging
+[profile staging]
+region = eu-west-1
+`
+	assert.NoError(t, os.WriteFile(configPath, []byte(configContent), 0o600))
+
+	pm := NewProfileManager()
+	assert.NoError(t, pm.LoadProfiles())
+
+	// Only the edited keys change, nested blocks and blank lines stay byte for byte
+	assert.NoError(t, pm.UpdateProfile("dev", "eu-west-1", ""))
+	assert.NoError(t, pm.DeleteProfile("old"))
+
+	data, err := os.ReadFile(configPath)
+	assert.NoError(t, err)
+	assert.Equal(t, `[default]
+region = us-east-1
+s3 =
+    max_concurrent_requests = 20
+    multipart_threshold = 64MB
+
+
+[profile dev]
+region = eu-west-1
+s3 =
+  addressing_style = path
+
+# Staging
+[profile staging]
+region = eu-west-1
+`, string(data))
+
+	// New profiles are appended without touching the existing lines
+	assert.NoError(t, pm.CreateProfile(ProfileSpec{
+		Name:          "ops",
+		Source:        SourceAssumeRole,
+		RoleARN:       "arn:aws:iam::123456789012:role/ops",
+		SourceProfile: "dev",
+	}))
+	data, err = os.ReadFile(configPath)
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(data), "[default]\nregion = us-east-1\ns3 =\n    max_concurrent_requests = 20\n"))
+	assert.True(t, strings.HasSuffix(string(data), `region = eu-west-1
+
+[profile ops]
+role_arn = arn:aws:iam::123456789012:role/ops
+source_profile = dev
+`))
+}
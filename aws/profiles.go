@@ -6,23 +6,75 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/go-ini/ini"
 )
 
+// EnvironmentProfileName names the synthetic profile that uses the SDK's default credential chain
+const EnvironmentProfileName = "environment / default chain"
+
+// CredentialSource represents where a profile gets its credentials from
+type CredentialSource string
+
+const (
+	SourceUnknown     CredentialSource = ""
+	SourceStatic      CredentialSource = "static"
+	SourceSSO         CredentialSource = "sso"
+	SourceAssumeRole  CredentialSource = "assume-role"
+	SourceProcess     CredentialSource = "credential_process"
+	SourceWebIdentity CredentialSource = "web-identity"
+	SourceDefault     CredentialSource = "default chain"
+)
+
 // Profile represents an AWS profile configuration
 type Profile struct {
-	Name      string
-	Region    string
-	IsDefault bool
-	IsFromEnv bool
+	Name                 string
+	Region               string
+	IsDefault            bool
+	IsFromEnv            bool
+	Source               CredentialSource
+	MFASerial            string // Set for assume-role profiles that require an MFA token
+	RoleARN              string
+	SourceProfile        string
+	SSOSession           string
+	SSOStartURL          string // Legacy SSO profiles set the start URL without an sso-session
+	SSOAccountID         string
+	CredentialProcess    string
+	WebIdentityTokenFile string
+	Output               string
+	EndpointURL          string
+	hasStaticKeys        bool
+}
+
+// AccountID returns the account a profile's settings name, from its SSO account or role ARN
+func (p Profile) AccountID() string {
+	if p.SSOAccountID != "" {
+		return p.SSOAccountID
+	}
+	if parts := strings.Split(p.RoleARN, ":"); len(parts) > 4 {
+		return parts[4]
+	}
+	return ""
+}
+
+// SSOSession represents an [sso-session name] section of the config file
+type SSOSession struct {
+	Name     string
+	StartURL string
+	Region   string
+	Scopes   []string
 }
 
-// ProfileManager handles AWS profile operations
+// ProfileManager handles AWS profile operations. Its profiles are read by background identity
+// lookups while the UI reloads them, so they are only replaced under the lock, never changed in place.
 type ProfileManager struct {
+	mu       sync.RWMutex
 	profiles []Profile
+	sessions []SSOSession
+	hasFiles bool
 }
 
 // NewProfileManager creates a new profile manager
@@ -32,64 +84,120 @@ func NewProfileManager() *ProfileManager {
 
 // LoadProfiles loads all available AWS profiles
 func (pm *ProfileManager) LoadProfiles() error {
-	profiles, err := loadProfiles()
+	profiles, sessions, err := loadProfiles()
 	if err != nil {
 		return err
 	}
+	hasFiles := profileFilesExist()
+
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
 	pm.profiles = profiles
+	pm.sessions = sessions
+	pm.hasFiles = hasFiles
 	return nil
 }
 
+// HasProfileFiles reports whether a shared config or credentials file exists
+func (pm *ProfileManager) HasProfileFiles() bool {
+	pm.mu.RLock()
+	defer pm.mu.RUnlock()
+	return pm.hasFiles
+}
+
+// EnvironmentProfile returns the synthetic profile for environment variables,
+// container credentials and instance roles
+func (pm *ProfileManager) EnvironmentProfile() Profile {
+	region := os.Getenv("AWS_REGION")
+	if region == "" {
+		region = os.Getenv("AWS_DEFAULT_REGION")
+	}
+	return Profile{
+		Name:   EnvironmentProfileName,
+		Region: region,
+		Source: SourceDefault,
+	}
+}
+
+// GetSSOSessions returns the sso-session sections of the config file
+func (pm *ProfileManager) GetSSOSessions() []SSOSession {
+	pm.mu.RLock()
+	defer pm.mu.RUnlock()
+	return pm.sessions
+}
+
 // GetAllProfiles returns all loaded profiles
 func (pm *ProfileManager) GetAllProfiles() []Profile {
+	pm.mu.RLock()
+	defer pm.mu.RUnlock()
 	return pm.profiles
 }
 
-// LoadConfig loads AWS config for a specific profile
-func (pm *ProfileManager) LoadConfig(ctx context.Context, profileName string) (config.Config, error) {
-	// Validate profile exists
-	var found bool
-	for _, p := range pm.profiles {
-		if p.Name == profileName {
-			found = true
-			break
-		}
+// LoadConfig loads AWS config for a specific profile, applying any extra load options
+func (pm *ProfileManager) LoadConfig(ctx context.Context, profileName string, optFns ...func(*config.LoadOptions) error) (config.Config, error) {
+	// The synthetic profile uses the default chain, looking the region up on EC2 if unset
+	if profileName == EnvironmentProfileName {
+		optFns = append([]func(*config.LoadOptions) error{config.WithEC2IMDSRegion()}, optFns...)
+		return config.LoadDefaultConfig(ctx, optFns...)
 	}
-	if !found {
+
+	// Validate profile exists
+	if _, found := pm.findProfile(profileName); !found {
 		return aws.Config{}, errors.New("profile not found")
 	}
 
 	// Load AWS config
-	return config.LoadDefaultConfig(ctx, config.WithSharedConfigProfile(profileName))
+	optFns = append([]func(*config.LoadOptions) error{config.WithSharedConfigProfile(profileName)}, optFns...)
+	return config.LoadDefaultConfig(ctx, optFns...)
 }
 
 // loadProfiles returns a list of available AWS profiles from both config and credentials files
-func loadProfiles() ([]Profile, error) {
+func loadProfiles() ([]Profile, []SSOSession, error) {
 	var profiles []Profile
-	homeDir, err := os.UserHomeDir()
+
+	configPath, err := ConfigFilePath()
 	if err != nil {
-		return nil, errors.New("failed to get user home directory")
+		return nil, nil, err
+	}
+	credentialsPath, err := CredentialsFilePath()
+	if err != nil {
+		return nil, nil, err
 	}
 
 	// Get profiles from config file
-	configProfiles, err := parseProfilesFromFile(filepath.Join(homeDir, ".aws", "config"), true)
+	configProfiles, err := parseProfilesFromFile(configPath, true)
 	if err != nil && !os.IsNotExist(err) {
-		return nil, err
+		return nil, nil, err
 	}
 	profiles = append(profiles, configProfiles...)
 
+	// Get SSO sessions from config file
+	sessions, err := parseSSOSessionsFromFile(configPath)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, nil, err
+	}
+
 	// Get profiles from credentials file
-	credProfiles, err := parseProfilesFromFile(filepath.Join(homeDir, ".aws", "credentials"), false)
+	credProfiles, err := parseProfilesFromFile(credentialsPath, false)
 	if err != nil && !os.IsNotExist(err) {
-		return nil, err
+		return nil, nil, err
 	}
 	profiles = append(profiles, credProfiles...)
 
 	// Deduplicate profiles and mark default
 	profiles = deduplicateProfiles(profiles)
 
-	// Check AWS_PROFILE environment variable
-	if envProfile := os.Getenv("AWS_PROFILE"); envProfile != "" {
+	// Work out where each profile gets its credentials once both files are merged
+	for i := range profiles {
+		profiles[i].Source = credentialSource(profiles[i])
+	}
+
+	// Check AWS_PROFILE, falling back to AWS_DEFAULT_PROFILE like the AWS CLI
+	envProfile := os.Getenv("AWS_PROFILE")
+	if envProfile == "" {
+		envProfile = os.Getenv("AWS_DEFAULT_PROFILE")
+	}
+	if envProfile != "" {
 		for i := range profiles {
 			if profiles[i].Name == envProfile {
 				profiles[i].IsFromEnv = true
@@ -98,7 +206,46 @@ func loadProfiles() ([]Profile, error) {
 		}
 	}
 
-	return profiles, nil
+	return profiles, sessions, nil
+}
+
+// profileFilesExist reports whether the shared config or credentials file exists
+func profileFilesExist() bool {
+	for _, pathFn := range []func() (string, error){ConfigFilePath, CredentialsFilePath} {
+		if path, err := pathFn(); err == nil {
+			if _, err := os.Stat(path); err == nil {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ConfigFilePath returns the shared config file, honoring AWS_CONFIG_FILE
+func ConfigFilePath() (string, error) {
+	return sharedFilePath("AWS_CONFIG_FILE", "config")
+}
+
+// CredentialsFilePath returns the shared credentials file, honoring AWS_SHARED_CREDENTIALS_FILE
+func CredentialsFilePath() (string, error) {
+	return sharedFilePath("AWS_SHARED_CREDENTIALS_FILE", "credentials")
+}
+
+func sharedFilePath(envVar, name string) (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", errors.New("failed to get user home directory")
+	}
+
+	if path := os.Getenv(envVar); path != "" {
+		// Expand ~ the same way the AWS CLI does
+		if path == "~" || strings.HasPrefix(path, "~/") {
+			path = filepath.Join(homeDir, path[1:])
+		}
+		return path, nil
+	}
+
+	return filepath.Join(homeDir, ".aws", name), nil
 }
 
 func parseProfilesFromFile(filePath string, isConfig bool) ([]Profile, error) {
@@ -122,43 +269,98 @@ func parseProfilesFromFile(filePath string, isConfig bool) ([]Profile, error) {
 		}
 
 		if isConfig {
-			// In config file, profiles are prefixed with "profile " except for 'default'
+			// In config file, profiles are prefixed with "profile " except for 'default',
+			// which may be written either way
 			if name == "default" {
-				profiles = append(profiles, Profile{
-					Name:      "default",
-					Region:    section.Key("region").String(),
-					IsDefault: true,
-				})
+				profiles = append(profiles, profileFromSection("default", section))
 			} else if strings.HasPrefix(name, "profile ") {
-				profiles = append(profiles, Profile{
-					Name:   strings.TrimPrefix(name, "profile "),
-					Region: section.Key("region").String(),
-				})
+				profiles = append(profiles, profileFromSection(strings.TrimSpace(strings.TrimPrefix(name, "profile ")), section))
 			}
 		} else {
 			// In credentials file, profile names are used directly
-			profiles = append(profiles, Profile{
-				Name:      name,
-				IsDefault: name == "default",
-			})
+			profiles = append(profiles, profileFromSection(name, section))
 		}
 	}
 
 	return profiles, nil
 }
 
+// profileFromSection reads the settings of a profile section
+func profileFromSection(name string, section *ini.Section) Profile {
+	return Profile{
+		Name:                 name,
+		Region:               section.Key("region").String(),
+		IsDefault:            name == "default",
+		MFASerial:            section.Key("mfa_serial").String(),
+		RoleARN:              section.Key("role_arn").String(),
+		SourceProfile:        section.Key("source_profile").String(),
+		SSOSession:           section.Key("sso_session").String(),
+		SSOStartURL:          section.Key("sso_start_url").String(),
+		SSOAccountID:         section.Key("sso_account_id").String(),
+		CredentialProcess:    section.Key("credential_process").String(),
+		WebIdentityTokenFile: section.Key("web_identity_token_file").String(),
+		Output:               section.Key("output").String(),
+		EndpointURL:          section.Key("endpoint_url").String(),
+		hasStaticKeys:        section.Key("aws_access_key_id").String() != "",
+	}
+}
+
+// parseSSOSessionsFromFile returns the [sso-session name] sections of a config file
+func parseSSOSessionsFromFile(filePath string) ([]SSOSession, error) {
+	cfg, err := ini.Load(filePath)
+	if err != nil {
+		if _, statErr := os.Stat(filePath); statErr != nil {
+			return nil, statErr
+		}
+		return nil, errors.New("could not load file: " + filePath)
+	}
+
+	var sessions []SSOSession
+	for _, section := range cfg.Sections() {
+		name, ok := strings.CutPrefix(section.Name(), "sso-session ")
+		if !ok {
+			continue
+		}
+		session := SSOSession{
+			Name:     strings.TrimSpace(name),
+			StartURL: section.Key("sso_start_url").String(),
+			Region:   section.Key("sso_region").String(),
+		}
+		for _, scope := range section.Key("sso_registration_scopes").Strings(",") {
+			if scope != "" {
+				session.Scopes = append(session.Scopes, scope)
+			}
+		}
+		sessions = append(sessions, session)
+	}
+
+	return sessions, nil
+}
+
+// credentialSource returns where a profile gets its credentials, in the SDK's order of precedence
+func credentialSource(p Profile) CredentialSource {
+	switch {
+	case p.RoleARN != "" && p.WebIdentityTokenFile != "":
+		return SourceWebIdentity
+	case p.RoleARN != "":
+		return SourceAssumeRole
+	case p.SSOSession != "" || p.SSOStartURL != "":
+		return SourceSSO
+	case p.CredentialProcess != "":
+		return SourceProcess
+	case p.hasStaticKeys:
+		return SourceStatic
+	}
+	return SourceUnknown
+}
+
 func deduplicateProfiles(profiles []Profile) []Profile {
 	seen := make(map[string]int)
 	result := make([]Profile, 0)
 
 	for _, p := range profiles {
 		if idx, exists := seen[p.Name]; exists {
-			// If profile exists and has a region, update the existing one
-			if p.Region != "" {
-				result[idx].Region = p.Region
-			}
-			// Preserve IsDefault flag
-			result[idx].IsDefault = result[idx].IsDefault || p.IsDefault
+			mergeProfile(&result[idx], p)
 		} else {
 			seen[p.Name] = len(result)
 			result = append(result, p)
@@ -167,3 +369,32 @@ func deduplicateProfiles(profiles []Profile) []Profile {
 
 	return result
 }
+
+// mergeProfile updates a profile with the settings another section of the same name sets
+func mergeProfile(dst *Profile, src Profile) {
+	fields := []struct {
+		dst *string
+		src string
+	}{
+		{&dst.Region, src.Region},
+		{&dst.MFASerial, src.MFASerial},
+		{&dst.RoleARN, src.RoleARN},
+		{&dst.SourceProfile, src.SourceProfile},
+		{&dst.SSOSession, src.SSOSession},
+		{&dst.SSOStartURL, src.SSOStartURL},
+		{&dst.SSOAccountID, src.SSOAccountID},
+		{&dst.CredentialProcess, src.CredentialProcess},
+		{&dst.WebIdentityTokenFile, src.WebIdentityTokenFile},
+		{&dst.Output, src.Output},
+		{&dst.EndpointURL, src.EndpointURL},
+	}
+	for _, f := range fields {
+		if f.src != "" {
+			*f.dst = f.src
+		}
+	}
+
+	// Preserve IsDefault flag
+	dst.IsDefault = dst.IsDefault || src.IsDefault
+	dst.hasStaticKeys = dst.hasStaticKeys || src.hasStaticKeys
+}
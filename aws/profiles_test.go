@@ -131,3 +131,200 @@ func TestParseProfilesFromFile(t *testing.T) {
 		assert.Empty(t, profiles)
 	})
 }
+
+func TestSharedConfigParity(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	// Files outside ~/.aws, selected through the environment
+	configContent := `[profile default]
+region = us-east-1
+output = json
+
+[profile static]
+region = eu-west-1
+endpoint_url = http://localhost:4566
+
+[profile sso]
+sso_session = corp
+sso_account_id = 123456789012
+sso_role_name = Developer
+
+[profile legacy-sso]
+sso_start_url = https://legacy.awsapps.com/start
+sso_region = us-east-1
+
+[profile admin]
+role_arn = arn:aws:iam::123456789012:role/admin
+source_profile = static
+mfa_serial = arn:aws:iam::123456789012:mfa/alice
+
+[profile ci]
+role_arn = arn:aws:iam::123456789012:role/ci
+web_identity_token_file = /var/run/token
+
+[profile vault]
+credential_process = vault-aws-creds
+
+[sso-session corp]
+sso_start_url = https://corp.awsapps.com/start
+sso_region = eu-central-1
+sso_registration_scopes = sso:account:access
+`
+	configPath := filepath.Join(tmpDir, "custom-config")
+	err := os.WriteFile(configPath, []byte(configContent), 0644)
+	assert.NoError(t, err)
+
+	credentialsContent := `[default]
+aws_access_key_id = default_key
+aws_secret_access_key = default_secret
+
+[static]
+aws_access_key_id = static_key
+aws_secret_access_key = static_secret
+`
+	credentialsPath := filepath.Join(tmpDir, "custom-credentials")
+	err = os.WriteFile(credentialsPath, []byte(credentialsContent), 0644)
+	assert.NoError(t, err)
+
+	originalHome := os.Getenv("HOME")
+	originalConfig := os.Getenv("AWS_CONFIG_FILE")
+	originalCredentials := os.Getenv("AWS_SHARED_CREDENTIALS_FILE")
+	originalProfile := os.Getenv("AWS_PROFILE")
+	originalDefaultProfile := os.Getenv("AWS_DEFAULT_PROFILE")
+	os.Setenv("HOME", filepath.Join(tmpDir, "empty-home"))
+	os.Setenv("AWS_CONFIG_FILE", configPath)
+	os.Setenv("AWS_SHARED_CREDENTIALS_FILE", credentialsPath)
+	os.Setenv("AWS_PROFILE", "")
+	os.Setenv("AWS_DEFAULT_PROFILE", "vault")
+	defer func() {
+		os.Setenv("HOME", originalHome)
+		os.Setenv("AWS_CONFIG_FILE", originalConfig)
+		os.Setenv("AWS_SHARED_CREDENTIALS_FILE", originalCredentials)
+		os.Setenv("AWS_PROFILE", originalProfile)
+		os.Setenv("AWS_DEFAULT_PROFILE", originalDefaultProfile)
+	}()
+
+	pm := NewProfileManager()
+	err = pm.LoadProfiles()
+	assert.NoError(t, err)
+
+	profiles := make(map[string]Profile)
+	for _, p := range pm.GetAllProfiles() {
+		profiles[p.Name] = p
+	}
+	assert.Len(t, profiles, 7)
+
+	t.Run("Profile default", func(t *testing.T) {
+		// [profile default] and [default] are the same profile
+		p := profiles["default"]
+		assert.True(t, p.IsDefault)
+		assert.Equal(t, "us-east-1", p.Region)
+		assert.Equal(t, "json", p.Output)
+		assert.Equal(t, SourceStatic, p.Source)
+	})
+
+	t.Run("Static credentials", func(t *testing.T) {
+		p := profiles["static"]
+		assert.Equal(t, SourceStatic, p.Source)
+		assert.Equal(t, "eu-west-1", p.Region)
+		assert.Equal(t, "http://localhost:4566", p.EndpointURL)
+	})
+
+	t.Run("SSO", func(t *testing.T) {
+		assert.Equal(t, SourceSSO, profiles["sso"].Source)
+		assert.Equal(t, "corp", profiles["sso"].SSOSession)
+		assert.Equal(t, SourceSSO, profiles["legacy-sso"].Source)
+		assert.Equal(t, "https://legacy.awsapps.com/start", profiles["legacy-sso"].SSOStartURL)
+		assert.Equal(t, "123456789012", profiles["sso"].AccountID())
+
+		assert.Equal(t, []SSOSession{{
+			Name:     "corp",
+			StartURL: "https://corp.awsapps.com/start",
+			Region:   "eu-central-1",
+			Scopes:   []string{"sso:account:access"},
+		}}, pm.GetSSOSessions())
+	})
+
+	t.Run("Assume role chain", func(t *testing.T) {
+		p := profiles["admin"]
+		assert.Equal(t, SourceAssumeRole, p.Source)
+		assert.Equal(t, "arn:aws:iam::123456789012:role/admin", p.RoleARN)
+		assert.Equal(t, "static", p.SourceProfile)
+		assert.Equal(t, "arn:aws:iam::123456789012:mfa/alice", p.MFASerial)
+		assert.Equal(t, "123456789012", p.AccountID())
+		assert.Empty(t, profiles["static"].AccountID())
+	})
+
+	t.Run("Web identity", func(t *testing.T) {
+		assert.Equal(t, SourceWebIdentity, profiles["ci"].Source)
+	})
+
+	t.Run("Credential process", func(t *testing.T) {
+		p := profiles["vault"]
+		assert.Equal(t, SourceProcess, p.Source)
+		assert.Equal(t, "vault-aws-creds", p.CredentialProcess)
+	})
+
+	t.Run("AWS_DEFAULT_PROFILE", func(t *testing.T) {
+		assert.True(t, profiles["vault"].IsFromEnv)
+		assert.False(t, profiles["default"].IsFromEnv)
+	})
+
+	t.Run("AWS_PROFILE takes precedence", func(t *testing.T) {
+		os.Setenv("AWS_PROFILE", "sso")
+		defer os.Setenv("AWS_PROFILE", "")
+
+		pm := NewProfileManager()
+		err := pm.LoadProfiles()
+		assert.NoError(t, err)
+		for _, p := range pm.GetAllProfiles() {
+			assert.Equal(t, p.Name == "sso", p.IsFromEnv, p.Name)
+		}
+	})
+}
+
+func TestEnvironmentProfile(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	originalHome := os.Getenv("HOME")
+	originalConfig := os.Getenv("AWS_CONFIG_FILE")
+	originalCredentials := os.Getenv("AWS_SHARED_CREDENTIALS_FILE")
+	originalRegion := os.Getenv("AWS_REGION")
+	os.Setenv("HOME", tmpDir)
+	os.Setenv("AWS_CONFIG_FILE", "")
+	os.Setenv("AWS_SHARED_CREDENTIALS_FILE", "")
+	os.Setenv("AWS_REGION", "ap-south-1")
+	defer func() {
+		os.Setenv("HOME", originalHome)
+		os.Setenv("AWS_CONFIG_FILE", originalConfig)
+		os.Setenv("AWS_SHARED_CREDENTIALS_FILE", originalCredentials)
+		os.Setenv("AWS_REGION", originalRegion)
+	}()
+
+	t.Run("No profile files", func(t *testing.T) {
+		pm := NewProfileManager()
+		err := pm.LoadProfiles()
+		assert.NoError(t, err)
+		assert.Empty(t, pm.GetAllProfiles())
+		assert.False(t, pm.HasProfileFiles())
+
+		p := pm.EnvironmentProfile()
+		assert.Equal(t, EnvironmentProfileName, p.Name)
+		assert.Equal(t, "ap-south-1", p.Region)
+		assert.Equal(t, SourceDefault, p.Source)
+	})
+
+	t.Run("Credentials file only", func(t *testing.T) {
+		awsDir := filepath.Join(tmpDir, ".aws")
+		err := os.MkdirAll(awsDir, 0755)
+		assert.NoError(t, err)
+		err = os.WriteFile(filepath.Join(awsDir, "credentials"), []byte("[default]\naws_access_key_id = key\n"), 0644)
+		assert.NoError(t, err)
+
+		pm := NewProfileManager()
+		err = pm.LoadProfiles()
+		assert.NoError(t, err)
+		assert.True(t, pm.HasProfileFiles())
+		assert.Len(t, pm.GetAllProfiles(), 1)
+	})
+}
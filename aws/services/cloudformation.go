@@ -0,0 +1,271 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/cloudformation"
+	"github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
+)
+
+// Stack status categories returned by StackStatusCategory
+const (
+	StackComplete   = "complete"
+	StackInProgress = "in-progress"
+	StackFailed     = "failed"
+	StackRollback   = "rollback"
+)
+
+// ListStacks returns the stacks of the region that have not been deleted
+func ListStacks(ctx context.Context, cfg config.Config) ([]Stack, error) {
+	awsCfg := GetAWSConfig(cfg).(aws.Config)
+	client := cloudformation.NewFromConfig(awsCfg)
+
+	var stacks []Stack
+	paginator := cloudformation.NewDescribeStacksPaginator(client, &cloudformation.DescribeStacksInput{})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list stacks: %w", err)
+		}
+		for _, s := range page.Stacks {
+			stacks = append(stacks, stack(s))
+		}
+	}
+
+	return stacks, nil
+}
+
+// GetStack returns a single stack by name or ARN
+func GetStack(ctx context.Context, cfg config.Config, name string) (*Stack, error) {
+	awsCfg := GetAWSConfig(cfg).(aws.Config)
+	client := cloudformation.NewFromConfig(awsCfg)
+
+	resp, err := client.DescribeStacks(ctx, &cloudformation.DescribeStacksInput{StackName: aws.String(name)})
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe stack %s: %w", name, err)
+	}
+	if len(resp.Stacks) == 0 {
+		return nil, fmt.Errorf("stack %s not found", name)
+	}
+	s := stack(resp.Stacks[0])
+	return &s, nil
+}
+
+// ListStackEvents returns the latest events of a stack, newest first
+func ListStackEvents(ctx context.Context, cfg config.Config, stack string) ([]StackEvent, error) {
+	awsCfg := GetAWSConfig(cfg).(aws.Config)
+	client := cloudformation.NewFromConfig(awsCfg)
+
+	resp, err := client.DescribeStackEvents(ctx, &cloudformation.DescribeStackEventsInput{StackName: aws.String(stack)})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list stack events: %w", err)
+	}
+
+	events := make([]StackEvent, len(resp.StackEvents))
+	for i, e := range resp.StackEvents {
+		events[i] = StackEvent{
+			ID:         aws.ToString(e.EventId),
+			Timestamp:  aws.ToTime(e.Timestamp),
+			LogicalID:  aws.ToString(e.LogicalResourceId),
+			PhysicalID: aws.ToString(e.PhysicalResourceId),
+			Type:       aws.ToString(e.ResourceType),
+			Status:     string(e.ResourceStatus),
+			Reason:     aws.ToString(e.ResourceStatusReason),
+		}
+	}
+
+	return events, nil
+}
+
+// NewStackEvents returns the events, newest first, that happened after the event with the
+// given ID. All events are new when the ID is not among them.
+func NewStackEvents(events []StackEvent, lastID string) []StackEvent {
+	for i, event := range events {
+		if event.ID == lastID {
+			return events[:i]
+		}
+	}
+	return events
+}
+
+// ListStackResources returns the resources of a stack
+func ListStackResources(ctx context.Context, cfg config.Config, stack string) ([]StackResource, error) {
+	awsCfg := GetAWSConfig(cfg).(aws.Config)
+	client := cloudformation.NewFromConfig(awsCfg)
+
+	var resources []StackResource
+	paginator := cloudformation.NewListStackResourcesPaginator(client, &cloudformation.ListStackResourcesInput{StackName: aws.String(stack)})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list stack resources: %w", err)
+		}
+		for _, r := range page.StackResourceSummaries {
+			resource := StackResource{
+				LogicalID:  aws.ToString(r.LogicalResourceId),
+				PhysicalID: aws.ToString(r.PhysicalResourceId),
+				Type:       aws.ToString(r.ResourceType),
+				Status:     string(r.ResourceStatus),
+				Reason:     aws.ToString(r.ResourceStatusReason),
+				UpdatedAt:  aws.ToTime(r.LastUpdatedTimestamp),
+			}
+			if r.DriftInformation != nil {
+				resource.DriftStatus = string(r.DriftInformation.StackResourceDriftStatus)
+			}
+			resources = append(resources, resource)
+		}
+	}
+
+	return resources, nil
+}
+
+// GetStackTemplate returns the original template body of a stack, with JSON templates indented
+func GetStackTemplate(ctx context.Context, cfg config.Config, stack string) (string, error) {
+	awsCfg := GetAWSConfig(cfg).(aws.Config)
+	client := cloudformation.NewFromConfig(awsCfg)
+
+	resp, err := client.GetTemplate(ctx, &cloudformation.GetTemplateInput{
+		StackName:     aws.String(stack),
+		TemplateStage: types.TemplateStageOriginal,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to get template: %w", err)
+	}
+
+	body := aws.ToString(resp.TemplateBody)
+	var indented bytes.Buffer
+	if json.Indent(&indented, []byte(body), "", "  ") == nil {
+		return indented.String(), nil
+	}
+	return body, nil
+}
+
+// DetectStackDrift starts a drift detection of a stack and returns its ID
+func DetectStackDrift(ctx context.Context, cfg config.Config, stack string) (string, error) {
+	awsCfg := GetAWSConfig(cfg).(aws.Config)
+	client := cloudformation.NewFromConfig(awsCfg)
+
+	resp, err := client.DetectStackDrift(ctx, &cloudformation.DetectStackDriftInput{StackName: aws.String(stack)})
+	if err != nil {
+		return "", fmt.Errorf("failed to start drift detection: %w", err)
+	}
+	return aws.ToString(resp.StackDriftDetectionId), nil
+}
+
+// GetStackDriftDetection returns the progress of a drift detection
+func GetStackDriftDetection(ctx context.Context, cfg config.Config, id string) (*StackDriftDetection, error) {
+	awsCfg := GetAWSConfig(cfg).(aws.Config)
+	client := cloudformation.NewFromConfig(awsCfg)
+
+	resp, err := client.DescribeStackDriftDetectionStatus(ctx, &cloudformation.DescribeStackDriftDetectionStatusInput{
+		StackDriftDetectionId: aws.String(id),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get drift detection status: %w", err)
+	}
+
+	return &StackDriftDetection{
+		Status:       string(resp.DetectionStatus),
+		DriftStatus:  string(resp.StackDriftStatus),
+		Reason:       aws.ToString(resp.DetectionStatusReason),
+		DriftedCount: aws.ToInt32(resp.DriftedStackResourceCount),
+	}, nil
+}
+
+// ListResourceDrifts returns the resources of a stack that were modified or deleted outside
+// of CloudFormation, as found by the last drift detection
+func ListResourceDrifts(ctx context.Context, cfg config.Config, stack string) ([]ResourceDrift, error) {
+	awsCfg := GetAWSConfig(cfg).(aws.Config)
+	client := cloudformation.NewFromConfig(awsCfg)
+
+	var drifts []ResourceDrift
+	paginator := cloudformation.NewDescribeStackResourceDriftsPaginator(client, &cloudformation.DescribeStackResourceDriftsInput{
+		StackName: aws.String(stack),
+		StackResourceDriftStatusFilters: []types.StackResourceDriftStatus{
+			types.StackResourceDriftStatusModified,
+			types.StackResourceDriftStatusDeleted,
+		},
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list resource drifts: %w", err)
+		}
+		for _, d := range page.StackResourceDrifts {
+			drift := ResourceDrift{
+				LogicalID:  aws.ToString(d.LogicalResourceId),
+				PhysicalID: aws.ToString(d.PhysicalResourceId),
+				Type:       aws.ToString(d.ResourceType),
+				Status:     string(d.StackResourceDriftStatus),
+			}
+			for _, p := range d.PropertyDifferences {
+				drift.Differences = append(drift.Differences, PropertyDifference{
+					Path:     aws.ToString(p.PropertyPath),
+					Type:     string(p.DifferenceType),
+					Expected: aws.ToString(p.ExpectedValue),
+					Actual:   aws.ToString(p.ActualValue),
+				})
+			}
+			drifts = append(drifts, drift)
+		}
+	}
+
+	return drifts, nil
+}
+
+// StackDriftDetectionDone reports whether a drift detection has finished
+func StackDriftDetectionDone(status string) bool {
+	return status != string(types.StackDriftDetectionStatusDetectionInProgress)
+}
+
+// StackStatusCategory groups a stack or resource status into complete, in-progress, failed or
+// rollback. A failed rollback counts as failed and a finished rollback as a rollback.
+func StackStatusCategory(status string) string {
+	switch {
+	case strings.HasSuffix(status, "_FAILED"):
+		return StackFailed
+	case strings.Contains(status, "ROLLBACK"):
+		return StackRollback
+	case strings.HasSuffix(status, "_IN_PROGRESS"):
+		return StackInProgress
+	}
+	return StackComplete
+}
+
+func stack(s types.Stack) Stack {
+	result := Stack{
+		Name:         aws.ToString(s.StackName),
+		ID:           aws.ToString(s.StackId),
+		Status:       string(s.StackStatus),
+		StatusReason: aws.ToString(s.StackStatusReason),
+		Description:  aws.ToString(s.Description),
+		CreatedAt:    aws.ToTime(s.CreationTime),
+		UpdatedAt:    aws.ToTime(s.LastUpdatedTime),
+	}
+	if s.DriftInformation != nil {
+		result.DriftStatus = string(s.DriftInformation.StackDriftStatus)
+		result.DriftChecked = aws.ToTime(s.DriftInformation.LastCheckTimestamp)
+	}
+	for _, o := range s.Outputs {
+		result.Outputs = append(result.Outputs, StackOutput{
+			Key:         aws.ToString(o.OutputKey),
+			Value:       aws.ToString(o.OutputValue),
+			Description: aws.ToString(o.Description),
+			ExportName:  aws.ToString(o.ExportName),
+		})
+	}
+	for _, p := range s.Parameters {
+		result.Parameters = append(result.Parameters, StackParameter{
+			Key:           aws.ToString(p.ParameterKey),
+			Value:         aws.ToString(p.ParameterValue),
+			ResolvedValue: aws.ToString(p.ResolvedValue),
+		})
+	}
+	return result
+}
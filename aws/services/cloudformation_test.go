@@ -0,0 +1,37 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStackStatusCategory(t *testing.T) {
+	tests := map[string]string{
+		"CREATE_COMPLETE":                              StackComplete,
+		"UPDATE_COMPLETE":                              StackComplete,
+		"IMPORT_COMPLETE":                              StackComplete,
+		"CREATE_IN_PROGRESS":                           StackInProgress,
+		"UPDATE_COMPLETE_CLEANUP_IN_PROGRESS":          StackInProgress,
+		"CREATE_FAILED":                                StackFailed,
+		"DELETE_FAILED":                                StackFailed,
+		"UPDATE_ROLLBACK_FAILED":                       StackFailed,
+		"ROLLBACK_COMPLETE":                            StackRollback,
+		"ROLLBACK_IN_PROGRESS":                         StackRollback,
+		"UPDATE_ROLLBACK_COMPLETE":                     StackRollback,
+		"UPDATE_ROLLBACK_COMPLETE_CLEANUP_IN_PROGRESS": StackRollback,
+	}
+	for status, category := range tests {
+		assert.Equal(t, category, StackStatusCategory(status), status)
+	}
+}
+
+func TestNewStackEvents(t *testing.T) {
+	events := []StackEvent{{ID: "e4"}, {ID: "e3"}, {ID: "e2"}, {ID: "e1"}}
+
+	assert.Equal(t, []StackEvent{{ID: "e4"}, {ID: "e3"}}, NewStackEvents(events, "e2"))
+	assert.Empty(t, NewStackEvents(events, "e4"))
+
+	// The last seen event has dropped off the first page
+	assert.Equal(t, events, NewStackEvents(events, "e0"))
+}
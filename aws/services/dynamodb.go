@@ -0,0 +1,430 @@
+package services
+
+import (
+	"cmp"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+const (
+	// dynamoWorkers limits how many tables are described at the same time
+	dynamoWorkers = 8
+	// dynamoPageSize is how many items a Scan or Query reads per page
+	dynamoPageSize = 100
+	// maxDynamoColumns limits how many attributes are shown as item columns
+	maxDynamoColumns = 12
+)
+
+// DynamoSortOps lists the sort key conditions a Query supports
+var DynamoSortOps = []string{"=", "<", "<=", ">", ">=", "begins_with", "between"}
+
+// ListDynamoTables returns the tables of the region with their size, billing mode and key schema
+func ListDynamoTables(ctx context.Context, cfg config.Config) ([]DynamoTable, error) {
+	awsCfg := GetAWSConfig(cfg).(aws.Config)
+	client := dynamodb.NewFromConfig(awsCfg)
+
+	var names []string
+	paginator := dynamodb.NewListTablesPaginator(client, &dynamodb.ListTablesInput{})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list tables: %w", err)
+		}
+		names = append(names, page.TableNames...)
+	}
+
+	tables := make([]DynamoTable, len(names))
+	var wg sync.WaitGroup
+	workers := make(chan struct{}, dynamoWorkers)
+	for i, name := range names {
+		tables[i] = DynamoTable{Name: name}
+
+		wg.Add(1)
+		go func(table *DynamoTable) {
+			defer wg.Done()
+			workers <- struct{}{}
+			defer func() { <-workers }()
+
+			// Leave the details unknown for tables we may not describe
+			resp, err := client.DescribeTable(ctx, &dynamodb.DescribeTableInput{
+				TableName: aws.String(table.Name),
+			})
+			if err != nil || resp.Table == nil {
+				return
+			}
+			*table = dynamoTable(resp.Table)
+		}(&tables[i])
+	}
+	wg.Wait()
+
+	return tables, nil
+}
+
+// GetDynamoTable returns a single table with its key schema
+func GetDynamoTable(ctx context.Context, cfg config.Config, name string) (*DynamoTable, error) {
+	awsCfg := GetAWSConfig(cfg).(aws.Config)
+	client := dynamodb.NewFromConfig(awsCfg)
+
+	resp, err := client.DescribeTable(ctx, &dynamodb.DescribeTableInput{TableName: aws.String(name)})
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe table %s: %w", name, err)
+	}
+	if resp.Table == nil {
+		return nil, fmt.Errorf("table %s not found", name)
+	}
+	table := dynamoTable(resp.Table)
+	return &table, nil
+}
+
+// ScanDynamoTable reads a page of items of a table, starting after the item a previous page ended with
+func ScanDynamoTable(ctx context.Context, cfg config.Config, table, token string) (*DynamoItemPage, error) {
+	awsCfg := GetAWSConfig(cfg).(aws.Config)
+	client := dynamodb.NewFromConfig(awsCfg)
+
+	startKey, err := decodeDynamoKey(token)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.Scan(ctx, &dynamodb.ScanInput{
+		TableName:         aws.String(table),
+		Limit:             aws.Int32(dynamoPageSize),
+		ExclusiveStartKey: startKey,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan table: %w", err)
+	}
+
+	return dynamoItemPage(resp.Items, resp.LastEvaluatedKey)
+}
+
+// QueryDynamoTable reads a page of the items of a table or index matching a key condition
+func QueryDynamoTable(ctx context.Context, cfg config.Config, table DynamoTable, query DynamoQuery, token string) (*DynamoItemPage, error) {
+	awsCfg := GetAWSConfig(cfg).(aws.Config)
+	client := dynamodb.NewFromConfig(awsCfg)
+
+	partitionKey, sortKey, err := table.QueryKeys(query.Index)
+	if err != nil {
+		return nil, err
+	}
+	condition, names, values, err := keyCondition(partitionKey, sortKey, query)
+	if err != nil {
+		return nil, err
+	}
+	startKey, err := decodeDynamoKey(token)
+	if err != nil {
+		return nil, err
+	}
+
+	input := &dynamodb.QueryInput{
+		TableName:                 aws.String(table.Name),
+		KeyConditionExpression:    aws.String(condition),
+		ExpressionAttributeNames:  names,
+		ExpressionAttributeValues: values,
+		Limit:                     aws.Int32(dynamoPageSize),
+		ExclusiveStartKey:         startKey,
+	}
+	if query.Index != "" {
+		input.IndexName = aws.String(query.Index)
+	}
+
+	resp, err := client.Query(ctx, input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query table: %w", err)
+	}
+
+	return dynamoItemPage(resp.Items, resp.LastEvaluatedKey)
+}
+
+// QueryKeys returns the partition and sort key of the table, or of one of its indexes
+func (t DynamoTable) QueryKeys(index string) (DynamoKey, DynamoKey, error) {
+	if index == "" {
+		return t.PartitionKey, t.SortKey, nil
+	}
+	for _, idx := range t.Indexes {
+		if idx.Name == index {
+			return idx.PartitionKey, idx.SortKey, nil
+		}
+	}
+	return DynamoKey{}, DynamoKey{}, fmt.Errorf("table %s has no index %s", t.Name, index)
+}
+
+// InferDynamoColumns returns the attributes to show as columns, keys first and
+// then the other attributes by how many items have them
+func InferDynamoColumns(table DynamoTable, items []map[string]interface{}) []string {
+	var columns []string
+	for _, key := range []DynamoKey{table.PartitionKey, table.SortKey} {
+		if key.Name != "" {
+			columns = append(columns, key.Name)
+		}
+	}
+
+	counts := make(map[string]int)
+	var others []string
+	for _, item := range items {
+		for name := range item {
+			if slices.Contains(columns, name) {
+				continue
+			}
+			if counts[name] == 0 {
+				others = append(others, name)
+			}
+			counts[name]++
+		}
+	}
+	slices.SortFunc(others, func(a, b string) int {
+		if c := cmp.Compare(counts[b], counts[a]); c != 0 {
+			return c
+		}
+		return strings.Compare(a, b)
+	})
+
+	columns = append(columns, others...)
+	if len(columns) > maxDynamoColumns {
+		columns = columns[:maxDynamoColumns]
+	}
+	return columns
+}
+
+// dynamoTable converts a table description
+func dynamoTable(desc *types.TableDescription) DynamoTable {
+	attributeTypes := make(map[string]string)
+	for _, attr := range desc.AttributeDefinitions {
+		attributeTypes[aws.ToString(attr.AttributeName)] = string(attr.AttributeType)
+	}
+	keys := func(schema []types.KeySchemaElement) (DynamoKey, DynamoKey) {
+		var partitionKey, sortKey DynamoKey
+		for _, element := range schema {
+			key := DynamoKey{Name: aws.ToString(element.AttributeName)}
+			key.Type = attributeTypes[key.Name]
+			if element.KeyType == types.KeyTypeHash {
+				partitionKey = key
+			} else {
+				sortKey = key
+			}
+		}
+		return partitionKey, sortKey
+	}
+
+	table := DynamoTable{
+		Name:        aws.ToString(desc.TableName),
+		ARN:         aws.ToString(desc.TableArn),
+		Status:      string(desc.TableStatus),
+		ItemCount:   aws.ToInt64(desc.ItemCount),
+		SizeBytes:   aws.ToInt64(desc.TableSizeBytes),
+		BillingMode: string(types.BillingModeProvisioned),
+	}
+	// Tables that never switched to on-demand have no billing mode summary
+	if desc.BillingModeSummary != nil {
+		table.BillingMode = string(desc.BillingModeSummary.BillingMode)
+	}
+	table.PartitionKey, table.SortKey = keys(desc.KeySchema)
+
+	for _, idx := range desc.GlobalSecondaryIndexes {
+		index := DynamoIndex{Name: aws.ToString(idx.IndexName)}
+		index.PartitionKey, index.SortKey = keys(idx.KeySchema)
+		table.Indexes = append(table.Indexes, index)
+	}
+	for _, idx := range desc.LocalSecondaryIndexes {
+		index := DynamoIndex{Name: aws.ToString(idx.IndexName)}
+		index.PartitionKey, index.SortKey = keys(idx.KeySchema)
+		table.Indexes = append(table.Indexes, index)
+	}
+
+	return table
+}
+
+// keyCondition builds the key condition expression of a query
+func keyCondition(partitionKey, sortKey DynamoKey, query DynamoQuery) (string, map[string]string, map[string]types.AttributeValue, error) {
+	if query.PartitionValue == "" {
+		return "", nil, nil, fmt.Errorf("a value for the partition key %s is required", partitionKey.Name)
+	}
+	pk, err := keyValue(partitionKey, query.PartitionValue)
+	if err != nil {
+		return "", nil, nil, err
+	}
+
+	condition := "#pk = :pk"
+	names := map[string]string{"#pk": partitionKey.Name}
+	values := map[string]types.AttributeValue{":pk": pk}
+	if query.SortOp == "" {
+		return condition, names, values, nil
+	}
+
+	if sortKey.Name == "" {
+		return "", nil, nil, fmt.Errorf("there is no sort key to compare with")
+	}
+	names["#sk"] = sortKey.Name
+	sk, err := keyValue(sortKey, query.SortValue)
+	if err != nil {
+		return "", nil, nil, err
+	}
+	values[":sk"] = sk
+
+	switch query.SortOp {
+	case "=", "<", "<=", ">", ">=":
+		condition += " AND #sk " + query.SortOp + " :sk"
+	case "begins_with":
+		condition += " AND begins_with(#sk, :sk)"
+	case "between":
+		upper, err := keyValue(sortKey, query.SortValue2)
+		if err != nil {
+			return "", nil, nil, err
+		}
+		values[":sk2"] = upper
+		condition += " AND #sk BETWEEN :sk AND :sk2"
+	default:
+		return "", nil, nil, fmt.Errorf("unsupported sort key condition %q", query.SortOp)
+	}
+
+	return condition, names, values, nil
+}
+
+// keyValue converts text typed by the user to a value of a key attribute
+func keyValue(key DynamoKey, text string) (types.AttributeValue, error) {
+	switch key.Type {
+	case "N":
+		if _, err := strconv.ParseFloat(text, 64); err != nil {
+			return nil, fmt.Errorf("%s is a number, got %q", key.Name, text)
+		}
+		return &types.AttributeValueMemberN{Value: text}, nil
+	case "B":
+		data, err := base64.StdEncoding.DecodeString(text)
+		if err != nil {
+			return nil, fmt.Errorf("%s is binary, enter it as base64: %w", key.Name, err)
+		}
+		return &types.AttributeValueMemberB{Value: data}, nil
+	default:
+		return &types.AttributeValueMemberS{Value: text}, nil
+	}
+}
+
+// dynamoItemPage strips the type annotations of items and encodes the key to continue from
+func dynamoItemPage(items []map[string]types.AttributeValue, lastKey map[string]types.AttributeValue) (*DynamoItemPage, error) {
+	page := &DynamoItemPage{}
+	for _, item := range items {
+		page.Items = append(page.Items, StripDynamoTypes(item))
+	}
+
+	token, err := encodeDynamoKey(lastKey)
+	if err != nil {
+		return nil, err
+	}
+	page.NextToken = token
+
+	return page, nil
+}
+
+// StripDynamoTypes converts an item to plain values, such as {"id": "1"} for {"id": {"S": "1"}}.
+// Numbers stay json.Number so they keep their precision, and binary values become []byte.
+func StripDynamoTypes(item map[string]types.AttributeValue) map[string]interface{} {
+	plain := make(map[string]interface{}, len(item))
+	for name, value := range item {
+		plain[name] = plainValue(value)
+	}
+	return plain
+}
+
+func plainValue(value types.AttributeValue) interface{} {
+	switch v := value.(type) {
+	case *types.AttributeValueMemberS:
+		return v.Value
+	case *types.AttributeValueMemberN:
+		return json.Number(v.Value)
+	case *types.AttributeValueMemberB:
+		return v.Value
+	case *types.AttributeValueMemberBOOL:
+		return v.Value
+	case *types.AttributeValueMemberNULL:
+		return nil
+	case *types.AttributeValueMemberM:
+		return StripDynamoTypes(v.Value)
+	case *types.AttributeValueMemberL:
+		list := make([]interface{}, len(v.Value))
+		for i, element := range v.Value {
+			list[i] = plainValue(element)
+		}
+		return list
+	case *types.AttributeValueMemberSS:
+		return v.Value
+	case *types.AttributeValueMemberNS:
+		numbers := make([]json.Number, len(v.Value))
+		for i, n := range v.Value {
+			numbers[i] = json.Number(n)
+		}
+		return numbers
+	case *types.AttributeValueMemberBS:
+		return v.Value
+	}
+	return nil
+}
+
+// encodedKeyValue is the JSON form of a key attribute in a page token
+type encodedKeyValue struct {
+	S *string `json:"S,omitempty"`
+	N *string `json:"N,omitempty"`
+	B []byte  `json:"B,omitempty"`
+}
+
+// encodeDynamoKey turns the last evaluated key of a page into a token, empty when there is none
+func encodeDynamoKey(key map[string]types.AttributeValue) (string, error) {
+	if len(key) == 0 {
+		return "", nil
+	}
+
+	encoded := make(map[string]encodedKeyValue, len(key))
+	for name, value := range key {
+		switch v := value.(type) {
+		case *types.AttributeValueMemberS:
+			encoded[name] = encodedKeyValue{S: aws.String(v.Value)}
+		case *types.AttributeValueMemberN:
+			encoded[name] = encodedKeyValue{N: aws.String(v.Value)}
+		case *types.AttributeValueMemberB:
+			encoded[name] = encodedKeyValue{B: v.Value}
+		default:
+			return "", fmt.Errorf("unsupported key attribute type for %s", name)
+		}
+	}
+
+	data, err := json.Marshal(encoded)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode page token: %w", err)
+	}
+	return string(data), nil
+}
+
+// decodeDynamoKey turns a page token back into the key to start after, nil for the first page
+func decodeDynamoKey(token string) (map[string]types.AttributeValue, error) {
+	if token == "" {
+		return nil, nil
+	}
+
+	var encoded map[string]encodedKeyValue
+	if err := json.Unmarshal([]byte(token), &encoded); err != nil {
+		return nil, fmt.Errorf("invalid page token: %w", err)
+	}
+
+	key := make(map[string]types.AttributeValue, len(encoded))
+	for name, value := range encoded {
+		switch {
+		case value.S != nil:
+			key[name] = &types.AttributeValueMemberS{Value: *value.S}
+		case value.N != nil:
+			key[name] = &types.AttributeValueMemberN{Value: *value.N}
+		default:
+			key[name] = &types.AttributeValueMemberB{Value: value.B}
+		}
+	}
+	return key, nil
+}
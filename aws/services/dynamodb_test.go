@@ -0,0 +1,104 @@
+package services
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStripDynamoTypes(t *testing.T) {
+	item := map[string]types.AttributeValue{
+		"id":      &types.AttributeValueMemberS{Value: "user#1"},
+		"age":     &types.AttributeValueMemberN{Value: "42"},
+		"active":  &types.AttributeValueMemberBOOL{Value: true},
+		"deleted": &types.AttributeValueMemberNULL{Value: true},
+		"tags":    &types.AttributeValueMemberSS{Value: []string{"a", "b"}},
+		"address": &types.AttributeValueMemberM{Value: map[string]types.AttributeValue{
+			"city": &types.AttributeValueMemberS{Value: "Pune"},
+		}},
+		"scores": &types.AttributeValueMemberL{Value: []types.AttributeValue{
+			&types.AttributeValueMemberN{Value: "1.5"},
+			&types.AttributeValueMemberS{Value: "x"},
+		}},
+	}
+
+	data, err := json.Marshal(StripDynamoTypes(item))
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+		"id": "user#1",
+		"age": 42,
+		"active": true,
+		"deleted": null,
+		"tags": ["a", "b"],
+		"address": {"city": "Pune"},
+		"scores": [1.5, "x"]
+	}`, string(data))
+}
+
+func TestDynamoKeyToken(t *testing.T) {
+	key := map[string]types.AttributeValue{
+		"pk": &types.AttributeValueMemberS{Value: "user#1"},
+		"sk": &types.AttributeValueMemberN{Value: "7"},
+		"b":  &types.AttributeValueMemberB{Value: []byte{1, 2}},
+	}
+
+	token, err := encodeDynamoKey(key)
+	assert.NoError(t, err)
+	decoded, err := decodeDynamoKey(token)
+	assert.NoError(t, err)
+	assert.Equal(t, key, decoded)
+
+	// The last page has no token
+	token, err = encodeDynamoKey(nil)
+	assert.NoError(t, err)
+	assert.Empty(t, token)
+	decoded, err = decodeDynamoKey("")
+	assert.NoError(t, err)
+	assert.Nil(t, decoded)
+}
+
+func TestKeyCondition(t *testing.T) {
+	pk := DynamoKey{Name: "pk", Type: "S"}
+	sk := DynamoKey{Name: "created", Type: "N"}
+
+	condition, names, values, err := keyCondition(pk, sk, DynamoQuery{PartitionValue: "user#1"})
+	assert.NoError(t, err)
+	assert.Equal(t, "#pk = :pk", condition)
+	assert.Equal(t, map[string]string{"#pk": "pk"}, names)
+	assert.Equal(t, &types.AttributeValueMemberS{Value: "user#1"}, values[":pk"])
+
+	condition, _, values, err = keyCondition(pk, sk, DynamoQuery{PartitionValue: "user#1", SortOp: "between", SortValue: "1", SortValue2: "9"})
+	assert.NoError(t, err)
+	assert.Equal(t, "#pk = :pk AND #sk BETWEEN :sk AND :sk2", condition)
+	assert.Equal(t, &types.AttributeValueMemberN{Value: "9"}, values[":sk2"])
+
+	condition, _, _, err = keyCondition(pk, DynamoKey{Name: "sk", Type: "S"}, DynamoQuery{PartitionValue: "a", SortOp: "begins_with", SortValue: "order#"})
+	assert.NoError(t, err)
+	assert.Equal(t, "#pk = :pk AND begins_with(#sk, :sk)", condition)
+
+	t.Run("Invalid values", func(t *testing.T) {
+		_, _, _, err := keyCondition(pk, sk, DynamoQuery{})
+		assert.Error(t, err)
+		_, _, _, err = keyCondition(pk, sk, DynamoQuery{PartitionValue: "a", SortOp: ">", SortValue: "soon"})
+		assert.Error(t, err)
+		_, _, _, err = keyCondition(pk, DynamoKey{}, DynamoQuery{PartitionValue: "a", SortOp: "="})
+		assert.Error(t, err)
+	})
+}
+
+func TestInferDynamoColumns(t *testing.T) {
+	table := DynamoTable{
+		PartitionKey: DynamoKey{Name: "pk", Type: "S"},
+		SortKey:      DynamoKey{Name: "sk", Type: "S"},
+	}
+	items := []map[string]interface{}{
+		{"pk": "a", "sk": "1", "name": "x", "email": "x@example.com"},
+		{"pk": "b", "sk": "2", "name": "y"},
+		{"pk": "c", "sk": "3", "name": "z", "age": 3},
+	}
+
+	// Keys come first, then attributes by how many items have them
+	assert.Equal(t, []string{"pk", "sk", "name", "age", "email"}, InferDynamoColumns(table, items))
+}
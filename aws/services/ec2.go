@@ -8,6 +8,7 @@ import (
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
 )
 
 // ListEC2Instances returns a list of EC2 instances
@@ -26,11 +27,12 @@ func ListEC2Instances(ctx context.Context, cfg config.Config) ([]EC2Instance, er
 		for _, instance := range reservation.Instances {
 			// Get instance name from tags
 			var name string
+			tags := make(map[string]string)
 			for _, tag := range instance.Tags {
 				if *tag.Key == "Name" {
 					name = *tag.Value
-					break
 				}
+				tags[*tag.Key] = *tag.Value
 			}
 
 			// Create instance info
@@ -41,6 +43,7 @@ func ListEC2Instances(ctx context.Context, cfg config.Config) ([]EC2Instance, er
 				State:     string(instance.State.Name),
 				PrivateIP: stringOrEmpty(instance.PrivateIpAddress),
 				PublicIP:  stringOrEmpty(instance.PublicIpAddress),
+				Tags:      tags,
 			}
 			instances = append(instances, inst)
 		}
@@ -81,3 +84,251 @@ func GetInstanceDetail(cfg aws.Config, instanceID string) (string, error) {
 
 	return string(jsonBytes), nil
 }
+
+// GetInstanceInfo returns structured information about an EC2 instance
+func GetInstanceInfo(ctx context.Context, cfg config.Config, instanceID string) (*InstanceDetail, error) {
+	awsCfg := GetAWSConfig(cfg).(aws.Config)
+	client := ec2.NewFromConfig(awsCfg)
+
+	resp, err := client.DescribeInstances(ctx, &ec2.DescribeInstancesInput{
+		InstanceIds: []string{instanceID},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get instance details: %w", err)
+	}
+	if len(resp.Reservations) == 0 || len(resp.Reservations[0].Instances) == 0 {
+		return nil, fmt.Errorf("instance not found: %s", instanceID)
+	}
+	instance := resp.Reservations[0].Instances[0]
+
+	detail := &InstanceDetail{
+		InstanceInfo: InstanceInfo{
+			ID:         aws.ToString(instance.InstanceId),
+			Type:       string(instance.InstanceType),
+			PublicIP:   stringOrEmpty(instance.PublicIpAddress),
+			PrivateIP:  stringOrEmpty(instance.PrivateIpAddress),
+			LaunchTime: aws.ToTime(instance.LaunchTime),
+			Tags:       make(map[string]string),
+		},
+		ImageID:      aws.ToString(instance.ImageId),
+		VPCID:        aws.ToString(instance.VpcId),
+		SubnetID:     aws.ToString(instance.SubnetId),
+		KeyName:      aws.ToString(instance.KeyName),
+		Platform:     aws.ToString(instance.PlatformDetails),
+		Architecture: string(instance.Architecture),
+	}
+	if instance.State != nil {
+		detail.State = string(instance.State.Name)
+	}
+	if instance.Placement != nil {
+		detail.AvailabilityZone = aws.ToString(instance.Placement.AvailabilityZone)
+	}
+	if instance.IamInstanceProfile != nil {
+		detail.InstanceProfileARN = aws.ToString(instance.IamInstanceProfile.Arn)
+	}
+	for _, tag := range instance.Tags {
+		detail.Tags[aws.ToString(tag.Key)] = aws.ToString(tag.Value)
+	}
+	detail.Name = detail.Tags["Name"]
+	for _, group := range instance.SecurityGroups {
+		detail.SecurityGroupIDs = append(detail.SecurityGroupIDs, aws.ToString(group.GroupId))
+	}
+	for _, eni := range instance.NetworkInterfaces {
+		info := NetworkInterfaceInfo{
+			ID:          aws.ToString(eni.NetworkInterfaceId),
+			Description: aws.ToString(eni.Description),
+			SubnetID:    aws.ToString(eni.SubnetId),
+			PrivateIP:   aws.ToString(eni.PrivateIpAddress),
+			PublicIP:    "-",
+			MACAddress:  aws.ToString(eni.MacAddress),
+			Status:      string(eni.Status),
+		}
+		if eni.Association != nil && eni.Association.PublicIp != nil {
+			info.PublicIP = *eni.Association.PublicIp
+		}
+		for _, group := range eni.Groups {
+			info.SecurityGroups = append(info.SecurityGroups, aws.ToString(group.GroupId))
+		}
+		detail.NetworkInterfaces = append(detail.NetworkInterfaces, info)
+	}
+
+	return detail, nil
+}
+
+// ListSecurityGroupRules returns the resolved inbound and outbound rules of security groups
+func ListSecurityGroupRules(ctx context.Context, cfg config.Config, groupIDs []string) ([]SecurityGroupRule, error) {
+	if len(groupIDs) == 0 {
+		return nil, nil
+	}
+
+	awsCfg := GetAWSConfig(cfg).(aws.Config)
+	client := ec2.NewFromConfig(awsCfg)
+
+	resp, err := client.DescribeSecurityGroups(ctx, &ec2.DescribeSecurityGroupsInput{
+		GroupIds: groupIDs,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe security groups: %w", err)
+	}
+
+	var rules []SecurityGroupRule
+	for _, group := range resp.SecurityGroups {
+		base := SecurityGroupRule{
+			GroupID:   aws.ToString(group.GroupId),
+			GroupName: aws.ToString(group.GroupName),
+		}
+		rules = append(rules, expandPermissions(base, "inbound", group.IpPermissions)...)
+		rules = append(rules, expandPermissions(base, "outbound", group.IpPermissionsEgress)...)
+	}
+
+	return rules, nil
+}
+
+// expandPermissions returns one rule per peer of each permission
+func expandPermissions(base SecurityGroupRule, direction string, permissions []types.IpPermission) []SecurityGroupRule {
+	var rules []SecurityGroupRule
+	for _, perm := range permissions {
+		rule := base
+		rule.Direction = direction
+		rule.Protocol = aws.ToString(perm.IpProtocol)
+		rule.Ports = "all"
+		if rule.Protocol == "-1" {
+			rule.Protocol = "all"
+		} else if perm.FromPort != nil && perm.ToPort != nil {
+			if *perm.FromPort == *perm.ToPort {
+				rule.Ports = fmt.Sprintf("%d", *perm.FromPort)
+			} else {
+				rule.Ports = fmt.Sprintf("%d-%d", *perm.FromPort, *perm.ToPort)
+			}
+		}
+
+		add := func(peer string, description *string) {
+			r := rule
+			r.Peer = peer
+			r.Description = aws.ToString(description)
+			rules = append(rules, r)
+		}
+		for _, r := range perm.IpRanges {
+			add(aws.ToString(r.CidrIp), r.Description)
+		}
+		for _, r := range perm.Ipv6Ranges {
+			add(aws.ToString(r.CidrIpv6), r.Description)
+		}
+		for _, p := range perm.PrefixListIds {
+			add(aws.ToString(p.PrefixListId), p.Description)
+		}
+		for _, pair := range perm.UserIdGroupPairs {
+			peer := aws.ToString(pair.GroupId)
+			if pair.GroupName != nil {
+				peer += " (" + *pair.GroupName + ")"
+			}
+			add(peer, pair.Description)
+		}
+	}
+	return rules
+}
+
+// ListInstanceVolumes returns the EBS volumes attached to an instance
+func ListInstanceVolumes(ctx context.Context, cfg config.Config, instanceID string) ([]VolumeInfo, error) {
+	awsCfg := GetAWSConfig(cfg).(aws.Config)
+	client := ec2.NewFromConfig(awsCfg)
+
+	var volumes []VolumeInfo
+	paginator := ec2.NewDescribeVolumesPaginator(client, &ec2.DescribeVolumesInput{
+		Filters: []types.Filter{
+			{Name: aws.String("attachment.instance-id"), Values: []string{instanceID}},
+		},
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to describe volumes: %w", err)
+		}
+
+		for _, vol := range page.Volumes {
+			info := VolumeInfo{
+				ID:        aws.ToString(vol.VolumeId),
+				Type:      string(vol.VolumeType),
+				State:     string(vol.State),
+				SizeGiB:   aws.ToInt32(vol.Size),
+				IOPS:      aws.ToInt32(vol.Iops),
+				Encrypted: aws.ToBool(vol.Encrypted),
+			}
+			for _, att := range vol.Attachments {
+				if aws.ToString(att.InstanceId) == instanceID {
+					info.Device = aws.ToString(att.Device)
+					info.DeleteOnTermination = aws.ToBool(att.DeleteOnTermination)
+				}
+			}
+			volumes = append(volumes, info)
+		}
+	}
+
+	return volumes, nil
+}
+
+// GetSecurityGroupDetail returns detailed information about a security group
+func GetSecurityGroupDetail(cfg aws.Config, groupID string) (string, error) {
+	client := ec2.NewFromConfig(cfg)
+
+	result, err := client.DescribeSecurityGroups(context.TODO(), &ec2.DescribeSecurityGroupsInput{
+		GroupIds: []string{groupID},
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to get security group details: %w", err)
+	}
+	if len(result.SecurityGroups) == 0 {
+		return "", fmt.Errorf("security group not found: %s", groupID)
+	}
+
+	jsonBytes, err := json.MarshalIndent(result.SecurityGroups[0], "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal security group details: %w", err)
+	}
+
+	return string(jsonBytes), nil
+}
+
+// GetVolumeDetail returns detailed information about an EBS volume
+func GetVolumeDetail(cfg aws.Config, volumeID string) (string, error) {
+	client := ec2.NewFromConfig(cfg)
+
+	result, err := client.DescribeVolumes(context.TODO(), &ec2.DescribeVolumesInput{
+		VolumeIds: []string{volumeID},
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to get volume details: %w", err)
+	}
+	if len(result.Volumes) == 0 {
+		return "", fmt.Errorf("volume not found: %s", volumeID)
+	}
+
+	jsonBytes, err := json.MarshalIndent(result.Volumes[0], "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal volume details: %w", err)
+	}
+
+	return string(jsonBytes), nil
+}
+
+// GetNetworkInterfaceDetail returns detailed information about a network interface
+func GetNetworkInterfaceDetail(cfg aws.Config, eniID string) (string, error) {
+	client := ec2.NewFromConfig(cfg)
+
+	result, err := client.DescribeNetworkInterfaces(context.TODO(), &ec2.DescribeNetworkInterfacesInput{
+		NetworkInterfaceIds: []string{eniID},
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to get network interface details: %w", err)
+	}
+	if len(result.NetworkInterfaces) == 0 {
+		return "", fmt.Errorf("network interface not found: %s", eniID)
+	}
+
+	jsonBytes, err := json.MarshalIndent(result.NetworkInterfaces[0], "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal network interface details: %w", err)
+	}
+
+	return string(jsonBytes), nil
+}
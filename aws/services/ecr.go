@@ -8,6 +8,7 @@ import (
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/service/ecr"
+	"github.com/aws/aws-sdk-go-v2/service/ecr/types"
 )
 
 // ListECRRepositories returns a list of ECR repositories
@@ -35,6 +36,7 @@ func ListECRRepositories(ctx context.Context, cfg config.Config) ([]ECRRepositor
 
 		repos = append(repos, ECRRepository{
 			Name:       *repo.RepositoryName,
+			ARN:        aws.ToString(repo.RepositoryArn),
 			URI:        *repo.RepositoryUri,
 			ImageCount: int64(len(imgResp.ImageDetails)),
 			CreatedAt:  *repo.CreatedAt,
@@ -89,3 +91,156 @@ func GetRepoDetail(cfg aws.Config, repoName string) (string, error) {
 
 	return string(jsonBytes), nil
 }
+
+// ListECRImages returns the images of an ECR repository
+func ListECRImages(ctx context.Context, cfg config.Config, repoName string) ([]ECRImage, error) {
+	awsCfg := GetAWSConfig(cfg).(aws.Config)
+	client := ecr.NewFromConfig(awsCfg)
+
+	var images []ECRImage
+	paginator := ecr.NewDescribeImagesPaginator(client, &ecr.DescribeImagesInput{
+		RepositoryName: aws.String(repoName),
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, detail := range page.ImageDetails {
+			image := ECRImage{
+				Digest:     aws.ToString(detail.ImageDigest),
+				Tags:       detail.ImageTags,
+				PushedAt:   aws.ToTime(detail.ImagePushedAt),
+				SizeBytes:  aws.ToInt64(detail.ImageSizeInBytes),
+				ScanStatus: "-",
+			}
+			if detail.ImageScanStatus != nil {
+				image.ScanStatus = string(detail.ImageScanStatus.Status)
+			}
+			if detail.ImageScanFindingsSummary != nil {
+				image.SeverityCounts = detail.ImageScanFindingsSummary.FindingSeverityCounts
+			}
+			images = append(images, image)
+		}
+	}
+
+	return images, nil
+}
+
+// GetImageScanFindings returns all scan findings for an image
+func GetImageScanFindings(ctx context.Context, cfg config.Config, repoName, digest string) (*ImageScanResult, error) {
+	awsCfg := GetAWSConfig(cfg).(aws.Config)
+	client := ecr.NewFromConfig(awsCfg)
+
+	result := &ImageScanResult{}
+	paginator := ecr.NewDescribeImageScanFindingsPaginator(client, &ecr.DescribeImageScanFindingsInput{
+		RepositoryName: aws.String(repoName),
+		ImageId:        &types.ImageIdentifier{ImageDigest: aws.String(digest)},
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get scan findings: %w", err)
+		}
+
+		if page.ImageScanStatus != nil {
+			result.Status = string(page.ImageScanStatus.Status)
+		}
+		if page.ImageScanFindings == nil {
+			continue
+		}
+
+		findings := page.ImageScanFindings
+		result.SeverityCounts = findings.FindingSeverityCounts
+		result.CompletedAt = findings.ImageScanCompletedAt
+
+		// Basic scanning reports findings with package details as attributes
+		for _, f := range findings.Findings {
+			finding := ScanFinding{
+				Name:        aws.ToString(f.Name),
+				Severity:    string(f.Severity),
+				Description: aws.ToString(f.Description),
+				URI:         aws.ToString(f.Uri),
+			}
+			for _, attr := range f.Attributes {
+				switch aws.ToString(attr.Key) {
+				case "package_name":
+					finding.Package = aws.ToString(attr.Value)
+				case "package_version":
+					finding.Version = aws.ToString(attr.Value)
+				}
+			}
+			result.Findings = append(result.Findings, finding)
+		}
+
+		// Enhanced scanning reports findings from Amazon Inspector
+		for _, f := range findings.EnhancedFindings {
+			result.Findings = append(result.Findings, enhancedFindings(f)...)
+		}
+	}
+
+	return result, nil
+}
+
+// enhancedFindings returns one finding per vulnerable package of an Inspector finding, each with the
+// version its fix is in
+func enhancedFindings(f types.EnhancedImageScanFinding) []ScanFinding {
+	finding := ScanFinding{
+		Name:        aws.ToString(f.Title),
+		Severity:    aws.ToString(f.Severity),
+		Description: aws.ToString(f.Description),
+	}
+	details := f.PackageVulnerabilityDetails
+	if details == nil {
+		return []ScanFinding{finding}
+	}
+	if details.VulnerabilityId != nil {
+		finding.Name = *details.VulnerabilityId
+	}
+	finding.URI = aws.ToString(details.SourceUrl)
+	if len(details.VulnerablePackages) == 0 {
+		return []ScanFinding{finding}
+	}
+
+	result := make([]ScanFinding, len(details.VulnerablePackages))
+	for i, pkg := range details.VulnerablePackages {
+		result[i] = finding
+		result[i].Package = aws.ToString(pkg.Name)
+		result[i].Version = aws.ToString(pkg.Version)
+		result[i].FixedIn = aws.ToString(pkg.FixedInVersion)
+	}
+	return result
+}
+
+// GetRegistryScanType returns whether the registry uses BASIC or ENHANCED scanning
+func GetRegistryScanType(ctx context.Context, cfg config.Config) (string, error) {
+	awsCfg := GetAWSConfig(cfg).(aws.Config)
+	client := ecr.NewFromConfig(awsCfg)
+
+	resp, err := client.GetRegistryScanningConfiguration(ctx, &ecr.GetRegistryScanningConfigurationInput{})
+	if err != nil {
+		return "", fmt.Errorf("failed to get registry scanning configuration: %w", err)
+	}
+	if resp.ScanningConfiguration == nil {
+		return string(types.ScanTypeBasic), nil
+	}
+
+	return string(resp.ScanningConfiguration.ScanType), nil
+}
+
+// StartImageScan starts a basic scan of an image
+func StartImageScan(ctx context.Context, cfg config.Config, repoName, digest string) error {
+	awsCfg := GetAWSConfig(cfg).(aws.Config)
+	client := ecr.NewFromConfig(awsCfg)
+
+	_, err := client.StartImageScan(ctx, &ecr.StartImageScanInput{
+		RepositoryName: aws.String(repoName),
+		ImageId:        &types.ImageIdentifier{ImageDigest: aws.String(digest)},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to start image scan: %w", err)
+	}
+
+	return nil
+}
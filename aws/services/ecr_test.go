@@ -0,0 +1,47 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ecr/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEnhancedFindings(t *testing.T) {
+	finding := types.EnhancedImageScanFinding{
+		Title:    aws.String("CVE-2024-1234 - openssl"),
+		Severity: aws.String("HIGH"),
+		PackageVulnerabilityDetails: &types.PackageVulnerabilityDetails{
+			VulnerabilityId: aws.String("CVE-2024-1234"),
+			SourceUrl:       aws.String("https://nvd.nist.gov/vuln/detail/CVE-2024-1234"),
+			VulnerablePackages: []types.VulnerablePackage{
+				{Name: aws.String("openssl"), Version: aws.String("3.0.2"), FixedInVersion: aws.String("3.0.13")},
+				{Name: aws.String("libssl3"), Version: aws.String("3.0.2")},
+			},
+		},
+		Remediation: &types.Remediation{
+			Recommendation: &types.Recommendation{Text: aws.String("Upgrade your installed software packages")},
+		},
+	}
+
+	// Each package gets its own finding, fixed in the version of that package
+	findings := enhancedFindings(finding)
+	if assert.Len(t, findings, 2) {
+		assert.Equal(t, "CVE-2024-1234", findings[0].Name)
+		assert.Equal(t, "HIGH", findings[0].Severity)
+		assert.Equal(t, "openssl", findings[0].Package)
+		assert.Equal(t, "3.0.2", findings[0].Version)
+		assert.Equal(t, "3.0.13", findings[0].FixedIn)
+		assert.Equal(t, "libssl3", findings[1].Package)
+		assert.Empty(t, findings[1].FixedIn)
+		assert.Equal(t, findings[0].URI, findings[1].URI)
+	}
+
+	// Findings without package details are kept once
+	findings = enhancedFindings(types.EnhancedImageScanFinding{Title: aws.String("Weak config"), Severity: aws.String("LOW")})
+	if assert.Len(t, findings, 1) {
+		assert.Equal(t, "Weak config", findings[0].Name)
+		assert.Empty(t, findings[0].Package)
+	}
+}
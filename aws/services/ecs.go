@@ -0,0 +1,250 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/ecs"
+	"github.com/aws/aws-sdk-go-v2/service/ecs/types"
+)
+
+const (
+	// ecsDescribeClusters is how many clusters DescribeClusters accepts per call
+	ecsDescribeClusters = 100
+	// ecsDescribeServices is how many services DescribeServices accepts per call
+	ecsDescribeServices = 10
+	// ecsDescribeTasks is how many tasks DescribeTasks accepts per call
+	ecsDescribeTasks = 100
+)
+
+// ListECSClusters returns the clusters of the region with their service and task counts
+func ListECSClusters(ctx context.Context, cfg config.Config) ([]ECSCluster, error) {
+	awsCfg := GetAWSConfig(cfg).(aws.Config)
+	client := ecs.NewFromConfig(awsCfg)
+
+	var arns []string
+	paginator := ecs.NewListClustersPaginator(client, &ecs.ListClustersInput{})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list clusters: %w", err)
+		}
+		arns = append(arns, page.ClusterArns...)
+	}
+
+	var clusters []ECSCluster
+	for _, batch := range chunk(arns, ecsDescribeClusters) {
+		resp, err := client.DescribeClusters(ctx, &ecs.DescribeClustersInput{Clusters: batch})
+		if err != nil {
+			return nil, fmt.Errorf("failed to describe clusters: %w", err)
+		}
+		for _, c := range resp.Clusters {
+			clusters = append(clusters, ECSCluster{
+				Name:           aws.ToString(c.ClusterName),
+				ARN:            aws.ToString(c.ClusterArn),
+				Status:         aws.ToString(c.Status),
+				ActiveServices: c.ActiveServicesCount,
+				RunningTasks:   c.RunningTasksCount,
+				PendingTasks:   c.PendingTasksCount,
+			})
+		}
+	}
+
+	return clusters, nil
+}
+
+// ListECSServices returns the services of a cluster with their task counts and deployment state
+func ListECSServices(ctx context.Context, cfg config.Config, cluster string) ([]ECSService, error) {
+	awsCfg := GetAWSConfig(cfg).(aws.Config)
+	client := ecs.NewFromConfig(awsCfg)
+
+	var arns []string
+	paginator := ecs.NewListServicesPaginator(client, &ecs.ListServicesInput{Cluster: aws.String(cluster)})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list services: %w", err)
+		}
+		arns = append(arns, page.ServiceArns...)
+	}
+
+	var services []ECSService
+	for _, batch := range chunk(arns, ecsDescribeServices) {
+		resp, err := client.DescribeServices(ctx, &ecs.DescribeServicesInput{
+			Cluster:  aws.String(cluster),
+			Services: batch,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to describe services: %w", err)
+		}
+		for _, s := range resp.Services {
+			service := ECSService{
+				Name:           aws.ToString(s.ServiceName),
+				ARN:            aws.ToString(s.ServiceArn),
+				Status:         aws.ToString(s.Status),
+				LaunchType:     string(s.LaunchType),
+				Desired:        s.DesiredCount,
+				Running:        s.RunningCount,
+				Pending:        s.PendingCount,
+				TaskDefinition: ShortTaskDefinition(aws.ToString(s.TaskDefinition)),
+				Deployments:    len(s.Deployments),
+			}
+			for _, d := range s.Deployments {
+				if aws.ToString(d.Status) == "PRIMARY" {
+					service.RolloutState = string(d.RolloutState)
+				}
+			}
+			services = append(services, service)
+		}
+	}
+
+	return services, nil
+}
+
+// ListECSTasks returns the running tasks of a service together with their containers
+func ListECSTasks(ctx context.Context, cfg config.Config, cluster, service string) ([]ECSTask, error) {
+	awsCfg := GetAWSConfig(cfg).(aws.Config)
+	client := ecs.NewFromConfig(awsCfg)
+
+	var arns []string
+	paginator := ecs.NewListTasksPaginator(client, &ecs.ListTasksInput{
+		Cluster:     aws.String(cluster),
+		ServiceName: aws.String(service),
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list tasks: %w", err)
+		}
+		arns = append(arns, page.TaskArns...)
+	}
+
+	var tasks []ECSTask
+	for _, batch := range chunk(arns, ecsDescribeTasks) {
+		resp, err := client.DescribeTasks(ctx, &ecs.DescribeTasksInput{
+			Cluster: aws.String(cluster),
+			Tasks:   batch,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to describe tasks: %w", err)
+		}
+		for _, t := range resp.Tasks {
+			tasks = append(tasks, ecsTask(t))
+		}
+	}
+
+	return tasks, nil
+}
+
+// ECSContainerLogStream returns the CloudWatch log group and stream a container of a task writes
+// to, read from the awslogs log configuration of the task definition
+func ECSContainerLogStream(ctx context.Context, cfg config.Config, task ECSTask, container ECSContainer) (string, string, error) {
+	awsCfg := GetAWSConfig(cfg).(aws.Config)
+	client := ecs.NewFromConfig(awsCfg)
+
+	resp, err := client.DescribeTaskDefinition(ctx, &ecs.DescribeTaskDefinitionInput{
+		TaskDefinition: aws.String(task.TaskDefinitionARN),
+	})
+	if err != nil {
+		return "", "", fmt.Errorf("failed to describe task definition: %w", err)
+	}
+	if resp.TaskDefinition == nil {
+		return "", "", fmt.Errorf("task definition %s not found", task.TaskDefinitionARN)
+	}
+
+	for _, def := range resp.TaskDefinition.ContainerDefinitions {
+		if aws.ToString(def.Name) != container.Name {
+			continue
+		}
+		if def.LogConfiguration == nil || def.LogConfiguration.LogDriver != types.LogDriverAwslogs {
+			return "", "", fmt.Errorf("container %s does not log to CloudWatch Logs", container.Name)
+		}
+		return AWSLogsStream(def.LogConfiguration.Options, container, task.ID)
+	}
+	return "", "", fmt.Errorf("container %s not found in %s", container.Name, task.TaskDefinitionARN)
+}
+
+// AWSLogsStream returns the log group and stream of a container from the options of its awslogs
+// log driver. With a stream prefix the stream is "prefix/container/task", without one it is
+// named after the Docker container ID.
+func AWSLogsStream(options map[string]string, container ECSContainer, taskID string) (string, string, error) {
+	group := options["awslogs-group"]
+	if group == "" {
+		return "", "", fmt.Errorf("container %s has no awslogs-group", container.Name)
+	}
+	if prefix := options["awslogs-stream-prefix"]; prefix != "" {
+		return group, prefix + "/" + container.Name + "/" + taskID, nil
+	}
+	if container.RuntimeID == "" {
+		return "", "", fmt.Errorf("container %s has no stream prefix and has not started", container.Name)
+	}
+	return group, container.RuntimeID, nil
+}
+
+// ForceECSDeployment starts a new deployment of a service with its current task definition
+func ForceECSDeployment(ctx context.Context, cfg config.Config, cluster, service string) error {
+	awsCfg := GetAWSConfig(cfg).(aws.Config)
+	client := ecs.NewFromConfig(awsCfg)
+
+	_, err := client.UpdateService(ctx, &ecs.UpdateServiceInput{
+		Cluster:            aws.String(cluster),
+		Service:            aws.String(service),
+		ForceNewDeployment: true,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to force a new deployment of %s: %w", service, err)
+	}
+	return nil
+}
+
+// ShortTaskDefinition returns the "family:revision" of a task definition ARN
+func ShortTaskDefinition(arn string) string {
+	if i := strings.LastIndex(arn, "/"); i >= 0 {
+		return arn[i+1:]
+	}
+	return arn
+}
+
+func ecsTask(t types.Task) ECSTask {
+	arn := aws.ToString(t.TaskArn)
+	task := ECSTask{
+		ID:                arn[strings.LastIndex(arn, "/")+1:],
+		ARN:               arn,
+		TaskDefinitionARN: aws.ToString(t.TaskDefinitionArn),
+		LastStatus:        aws.ToString(t.LastStatus),
+		DesiredStatus:     aws.ToString(t.DesiredStatus),
+		Health:            string(t.HealthStatus),
+		LaunchType:        string(t.LaunchType),
+		StartedAt:         aws.ToTime(t.StartedAt),
+	}
+	for _, c := range t.Containers {
+		container := ECSContainer{
+			Name:       aws.ToString(c.Name),
+			LastStatus: aws.ToString(c.LastStatus),
+			Health:     string(c.HealthStatus),
+			RuntimeID:  aws.ToString(c.RuntimeId),
+		}
+		for _, eni := range c.NetworkInterfaces {
+			if ip := aws.ToString(eni.PrivateIpv4Address); ip != "" {
+				container.IPs = append(container.IPs, ip)
+			}
+		}
+		task.Containers = append(task.Containers, container)
+	}
+	return task
+}
+
+// chunk splits items into batches of at most size items
+func chunk(items []string, size int) [][]string {
+	var batches [][]string
+	for size < len(items) {
+		items, batches = items[size:], append(batches, items[:size])
+	}
+	if len(items) > 0 {
+		batches = append(batches, items)
+	}
+	return batches
+}
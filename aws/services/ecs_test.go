@@ -0,0 +1,42 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAWSLogsStream(t *testing.T) {
+	container := ECSContainer{Name: "web", RuntimeID: "0123abcd"}
+
+	group, stream, err := AWSLogsStream(map[string]string{
+		"awslogs-group":         "/ecs/shop",
+		"awslogs-stream-prefix": "ecs",
+	}, container, "9f8e7d")
+	assert.NoError(t, err)
+	assert.Equal(t, "/ecs/shop", group)
+	assert.Equal(t, "ecs/web/9f8e7d", stream)
+
+	// Without a prefix the stream is named after the Docker container
+	group, stream, err = AWSLogsStream(map[string]string{"awslogs-group": "/ecs/shop"}, container, "9f8e7d")
+	assert.NoError(t, err)
+	assert.Equal(t, "/ecs/shop", group)
+	assert.Equal(t, "0123abcd", stream)
+
+	_, _, err = AWSLogsStream(map[string]string{"awslogs-group": "/ecs/shop"}, ECSContainer{Name: "web"}, "9f8e7d")
+	assert.Error(t, err)
+
+	_, _, err = AWSLogsStream(map[string]string{}, container, "9f8e7d")
+	assert.Error(t, err)
+}
+
+func TestShortTaskDefinition(t *testing.T) {
+	assert.Equal(t, "shop-web:42", ShortTaskDefinition("arn:aws:ecs:eu-west-1:123456789012:task-definition/shop-web:42"))
+	assert.Equal(t, "shop-web:42", ShortTaskDefinition("shop-web:42"))
+}
+
+func TestChunk(t *testing.T) {
+	assert.Equal(t, [][]string{{"a", "b"}, {"c", "d"}, {"e"}}, chunk([]string{"a", "b", "c", "d", "e"}, 2))
+	assert.Equal(t, [][]string{{"a", "b"}}, chunk([]string{"a", "b"}, 2))
+	assert.Empty(t, chunk(nil, 2))
+}
@@ -0,0 +1,163 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/eks"
+	"github.com/aws/aws-sdk-go-v2/service/eks/types"
+)
+
+// eksWorkers limits how many clusters or node groups are described at the same time
+const eksWorkers = 8
+
+// ListEKSClusters returns the clusters of the region with their endpoint access and node groups
+func ListEKSClusters(ctx context.Context, cfg config.Config) ([]EKSCluster, error) {
+	awsCfg := GetAWSConfig(cfg).(aws.Config)
+	client := eks.NewFromConfig(awsCfg)
+
+	var names []string
+	paginator := eks.NewListClustersPaginator(client, &eks.ListClustersInput{})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list clusters: %w", err)
+		}
+		names = append(names, page.Clusters...)
+	}
+
+	clusters := make([]EKSCluster, len(names))
+	var wg sync.WaitGroup
+	workers := make(chan struct{}, eksWorkers)
+	for i, name := range names {
+		clusters[i] = EKSCluster{Name: name}
+
+		wg.Add(1)
+		go func(cluster *EKSCluster) {
+			defer wg.Done()
+			workers <- struct{}{}
+			defer func() { <-workers }()
+
+			// Leave the details unknown for clusters we may not describe
+			resp, err := client.DescribeCluster(ctx, &eks.DescribeClusterInput{Name: aws.String(cluster.Name)})
+			if err != nil {
+				return
+			}
+			*cluster = eksCluster(resp.Cluster)
+
+			nodeGroups := eks.NewListNodegroupsPaginator(client, &eks.ListNodegroupsInput{ClusterName: aws.String(cluster.Name)})
+			for nodeGroups.HasMorePages() {
+				page, err := nodeGroups.NextPage(ctx)
+				if err != nil {
+					return
+				}
+				cluster.NodeGroups = append(cluster.NodeGroups, page.Nodegroups...)
+			}
+		}(&clusters[i])
+	}
+	wg.Wait()
+
+	return clusters, nil
+}
+
+// eksCluster converts a cluster returned by DescribeCluster
+func eksCluster(c *types.Cluster) EKSCluster {
+	cluster := EKSCluster{
+		Name:            aws.ToString(c.Name),
+		ARN:             aws.ToString(c.Arn),
+		Version:         aws.ToString(c.Version),
+		PlatformVersion: aws.ToString(c.PlatformVersion),
+		Status:          string(c.Status),
+		Endpoint:        aws.ToString(c.Endpoint),
+		CreatedAt:       aws.ToTime(c.CreatedAt),
+	}
+	if c.CertificateAuthority != nil {
+		cluster.CertificateAuthority = aws.ToString(c.CertificateAuthority.Data)
+	}
+	if vpc := c.ResourcesVpcConfig; vpc != nil {
+		cluster.PublicAccess = vpc.EndpointPublicAccess
+		cluster.PrivateAccess = vpc.EndpointPrivateAccess
+		cluster.PublicCIDRs = vpc.PublicAccessCidrs
+	}
+	return cluster
+}
+
+// ListEKSNodeGroups returns the managed node groups of a cluster with their scaling configuration
+func ListEKSNodeGroups(ctx context.Context, cfg config.Config, clusterName string) ([]EKSNodeGroup, error) {
+	awsCfg := GetAWSConfig(cfg).(aws.Config)
+	client := eks.NewFromConfig(awsCfg)
+
+	var names []string
+	paginator := eks.NewListNodegroupsPaginator(client, &eks.ListNodegroupsInput{ClusterName: aws.String(clusterName)})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list node groups: %w", err)
+		}
+		names = append(names, page.Nodegroups...)
+	}
+
+	nodeGroups := make([]EKSNodeGroup, len(names))
+	var wg sync.WaitGroup
+	workers := make(chan struct{}, eksWorkers)
+	for i, name := range names {
+		nodeGroups[i] = EKSNodeGroup{Name: name}
+
+		wg.Add(1)
+		go func(nodeGroup *EKSNodeGroup) {
+			defer wg.Done()
+			workers <- struct{}{}
+			defer func() { <-workers }()
+
+			resp, err := client.DescribeNodegroup(ctx, &eks.DescribeNodegroupInput{
+				ClusterName:   aws.String(clusterName),
+				NodegroupName: aws.String(nodeGroup.Name),
+			})
+			if err != nil {
+				return
+			}
+			ng := resp.Nodegroup
+			nodeGroup.Status = string(ng.Status)
+			nodeGroup.Version = aws.ToString(ng.Version)
+			nodeGroup.ReleaseVersion = aws.ToString(ng.ReleaseVersion)
+			nodeGroup.InstanceTypes = ng.InstanceTypes
+			nodeGroup.CapacityType = string(ng.CapacityType)
+			nodeGroup.AMIType = string(ng.AmiType)
+			nodeGroup.CreatedAt = aws.ToTime(ng.CreatedAt)
+			if scaling := ng.ScalingConfig; scaling != nil {
+				nodeGroup.Desired = aws.ToInt32(scaling.DesiredSize)
+				nodeGroup.Min = aws.ToInt32(scaling.MinSize)
+				nodeGroup.Max = aws.ToInt32(scaling.MaxSize)
+			}
+		}(&nodeGroups[i])
+	}
+	wg.Wait()
+
+	return nodeGroups, nil
+}
+
+// EndpointAccess describes who can reach the API server of a cluster, such as "Public and private"
+// or "Public (10.0.0.0/8)"
+func EndpointAccess(cluster EKSCluster) string {
+	var access string
+	switch {
+	case cluster.PublicAccess && cluster.PrivateAccess:
+		access = "Public and private"
+	case cluster.PublicAccess:
+		access = "Public"
+	case cluster.PrivateAccess:
+		return "Private"
+	default:
+		return "-"
+	}
+
+	// Public endpoints open to everyone are the default, only call out restrictions
+	if len(cluster.PublicCIDRs) > 0 && !(len(cluster.PublicCIDRs) == 1 && cluster.PublicCIDRs[0] == "0.0.0.0/0") {
+		access += " (" + strings.Join(cluster.PublicCIDRs, ", ") + ")"
+	}
+	return access
+}
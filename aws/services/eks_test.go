@@ -0,0 +1,15 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEndpointAccess(t *testing.T) {
+	assert.Equal(t, "Public", EndpointAccess(EKSCluster{PublicAccess: true, PublicCIDRs: []string{"0.0.0.0/0"}}))
+	assert.Equal(t, "Public and private", EndpointAccess(EKSCluster{PublicAccess: true, PrivateAccess: true}))
+	assert.Equal(t, "Public (10.0.0.0/8, 192.168.0.0/16)", EndpointAccess(EKSCluster{PublicAccess: true, PublicCIDRs: []string{"10.0.0.0/8", "192.168.0.0/16"}}))
+	assert.Equal(t, "Private", EndpointAccess(EKSCluster{PrivateAccess: true, PublicCIDRs: []string{"0.0.0.0/0"}}))
+	assert.Equal(t, "-", EndpointAccess(EKSCluster{}))
+}
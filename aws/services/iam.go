@@ -0,0 +1,388 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	"github.com/aws/aws-sdk-go-v2/service/iam/types"
+)
+
+// iamWorkers limits how many users or roles have their details read at the same time
+const iamWorkers = 8
+
+// GetInstanceProfileRole returns the name of the role in an instance profile
+func GetInstanceProfileRole(ctx context.Context, cfg config.Config, profileARN string) (string, error) {
+	awsCfg := GetAWSConfig(cfg).(aws.Config)
+	client := iam.NewFromConfig(awsCfg)
+
+	// The profile name is the last segment of the ARN, after any path
+	name := profileARN[strings.LastIndex(profileARN, "/")+1:]
+
+	resp, err := client.GetInstanceProfile(ctx, &iam.GetInstanceProfileInput{
+		InstanceProfileName: aws.String(name),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to get instance profile: %w", err)
+	}
+	if len(resp.InstanceProfile.Roles) == 0 {
+		return "", fmt.Errorf("instance profile has no role: %s", name)
+	}
+
+	return aws.ToString(resp.InstanceProfile.Roles[0].RoleName), nil
+}
+
+// ListIAMUsers returns the users of the account with their access keys and MFA status. Keys and MFA
+// devices that cannot be read are left unknown, with the error kept on the user.
+func ListIAMUsers(ctx context.Context, cfg config.Config) ([]IAMUser, error) {
+	awsCfg := GetAWSConfig(cfg).(aws.Config)
+	client := iam.NewFromConfig(awsCfg)
+
+	var users []IAMUser
+	paginator := iam.NewListUsersPaginator(client, &iam.ListUsersInput{})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list users: %w", err)
+		}
+		for _, u := range page.Users {
+			users = append(users, IAMUser{
+				Name:             aws.ToString(u.UserName),
+				ARN:              aws.ToString(u.Arn),
+				Path:             aws.ToString(u.Path),
+				Created:          aws.ToTime(u.CreateDate),
+				PasswordLastUsed: aws.ToTime(u.PasswordLastUsed),
+			})
+		}
+	}
+
+	var wg sync.WaitGroup
+	workers := make(chan struct{}, iamWorkers)
+	for i := range users {
+		wg.Add(1)
+		go func(user *IAMUser) {
+			defer wg.Done()
+			workers <- struct{}{}
+			defer func() { <-workers }()
+
+			// Keep the errors so keys and MFA we may not read show as unknown rather than missing
+			user.AccessKeys, user.KeysErr = listAccessKeys(ctx, client, user.Name)
+			resp, err := client.ListMFADevices(ctx, &iam.ListMFADevicesInput{UserName: aws.String(user.Name)})
+			if err != nil {
+				user.MFAErr = fmt.Errorf("failed to list MFA devices: %w", err)
+			} else {
+				user.MFA = len(resp.MFADevices) > 0
+			}
+		}(&users[i])
+	}
+	wg.Wait()
+
+	return users, nil
+}
+
+// listAccessKeys returns the access keys of a user with when each was last used
+func listAccessKeys(ctx context.Context, client *iam.Client, userName string) ([]AccessKey, error) {
+	resp, err := client.ListAccessKeys(ctx, &iam.ListAccessKeysInput{UserName: aws.String(userName)})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list access keys: %w", err)
+	}
+
+	keys := make([]AccessKey, len(resp.AccessKeyMetadata))
+	for i, k := range resp.AccessKeyMetadata {
+		keys[i] = AccessKey{
+			ID:      aws.ToString(k.AccessKeyId),
+			Active:  k.Status == types.StatusTypeActive,
+			Created: aws.ToTime(k.CreateDate),
+		}
+		used, err := client.GetAccessKeyLastUsed(ctx, &iam.GetAccessKeyLastUsedInput{AccessKeyId: k.AccessKeyId})
+		if err != nil || used.AccessKeyLastUsed == nil {
+			continue
+		}
+		keys[i].LastUsed = aws.ToTime(used.AccessKeyLastUsed.LastUsedDate)
+		// IAM reports "N/A" for keys that were never used
+		if service := aws.ToString(used.AccessKeyLastUsed.ServiceName); service != "N/A" {
+			keys[i].LastService = service
+			keys[i].LastRegion = aws.ToString(used.AccessKeyLastUsed.Region)
+		}
+	}
+
+	return keys, nil
+}
+
+// ListIAMRoles returns the roles of the account with their trust policies and when they were last used
+func ListIAMRoles(ctx context.Context, cfg config.Config) ([]IAMRole, error) {
+	awsCfg := GetAWSConfig(cfg).(aws.Config)
+	client := iam.NewFromConfig(awsCfg)
+
+	var roles []IAMRole
+	paginator := iam.NewListRolesPaginator(client, &iam.ListRolesInput{})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list roles: %w", err)
+		}
+		for _, r := range page.Roles {
+			roles = append(roles, iamRole(r))
+		}
+	}
+
+	// ListRoles leaves out when a role was last used, which only GetRole returns
+	var wg sync.WaitGroup
+	workers := make(chan struct{}, iamWorkers)
+	for i := range roles {
+		wg.Add(1)
+		go func(role *IAMRole) {
+			defer wg.Done()
+			workers <- struct{}{}
+			defer func() { <-workers }()
+
+			resp, err := client.GetRole(ctx, &iam.GetRoleInput{RoleName: aws.String(role.Name)})
+			if err != nil {
+				return
+			}
+			*role = iamRole(*resp.Role)
+		}(&roles[i])
+	}
+	wg.Wait()
+
+	return roles, nil
+}
+
+// GetIAMRole returns a single role by name
+func GetIAMRole(ctx context.Context, cfg config.Config, name string) (IAMRole, error) {
+	awsCfg := GetAWSConfig(cfg).(aws.Config)
+	client := iam.NewFromConfig(awsCfg)
+
+	resp, err := client.GetRole(ctx, &iam.GetRoleInput{RoleName: aws.String(name)})
+	if err != nil {
+		return IAMRole{}, fmt.Errorf("failed to get role: %w", err)
+	}
+	return iamRole(*resp.Role), nil
+}
+
+// iamRole converts a role returned by the IAM API
+func iamRole(r types.Role) IAMRole {
+	role := IAMRole{
+		Name:        aws.ToString(r.RoleName),
+		ARN:         aws.ToString(r.Arn),
+		Path:        aws.ToString(r.Path),
+		Description: aws.ToString(r.Description),
+		Created:     aws.ToTime(r.CreateDate),
+		MaxSession:  time.Duration(aws.ToInt32(r.MaxSessionDuration)) * time.Second,
+		TrustPolicy: DecodePolicyDocument(aws.ToString(r.AssumeRolePolicyDocument)),
+	}
+	if r.RoleLastUsed != nil {
+		role.LastUsed = aws.ToTime(r.RoleLastUsed.LastUsedDate)
+		role.LastUsedRegion = aws.ToString(r.RoleLastUsed.Region)
+	}
+	return role
+}
+
+// ListIAMPolicies returns the customer managed policies of the account
+func ListIAMPolicies(ctx context.Context, cfg config.Config) ([]IAMPolicy, error) {
+	awsCfg := GetAWSConfig(cfg).(aws.Config)
+	client := iam.NewFromConfig(awsCfg)
+
+	var policies []IAMPolicy
+	paginator := iam.NewListPoliciesPaginator(client, &iam.ListPoliciesInput{Scope: types.PolicyScopeTypeLocal})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list policies: %w", err)
+		}
+		for _, p := range page.Policies {
+			policies = append(policies, IAMPolicy{
+				Name:           aws.ToString(p.PolicyName),
+				ARN:            aws.ToString(p.Arn),
+				Path:           aws.ToString(p.Path),
+				Description:    aws.ToString(p.Description),
+				DefaultVersion: aws.ToString(p.DefaultVersionId),
+				Attachments:    int(aws.ToInt32(p.AttachmentCount)),
+				Updated:        aws.ToTime(p.UpdateDate),
+			})
+		}
+	}
+
+	return policies, nil
+}
+
+// ListUserPolicies returns the managed policies attached to a user and its inline policies
+func ListUserPolicies(ctx context.Context, cfg config.Config, userName string) ([]PolicyRef, error) {
+	awsCfg := GetAWSConfig(cfg).(aws.Config)
+	client := iam.NewFromConfig(awsCfg)
+
+	var policies []PolicyRef
+	attached := iam.NewListAttachedUserPoliciesPaginator(client, &iam.ListAttachedUserPoliciesInput{UserName: aws.String(userName)})
+	for attached.HasMorePages() {
+		page, err := attached.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list attached policies: %w", err)
+		}
+		policies = append(policies, attachedPolicies(page.AttachedPolicies)...)
+	}
+
+	inline := iam.NewListUserPoliciesPaginator(client, &iam.ListUserPoliciesInput{UserName: aws.String(userName)})
+	for inline.HasMorePages() {
+		page, err := inline.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list inline policies: %w", err)
+		}
+		for _, name := range page.PolicyNames {
+			resp, err := client.GetUserPolicy(ctx, &iam.GetUserPolicyInput{UserName: aws.String(userName), PolicyName: aws.String(name)})
+			if err != nil {
+				return nil, fmt.Errorf("failed to get inline policy %s: %w", name, err)
+			}
+			policies = append(policies, PolicyRef{Name: name, Inline: true, Document: DecodePolicyDocument(aws.ToString(resp.PolicyDocument))})
+		}
+	}
+
+	return policies, nil
+}
+
+// ListRolePolicies returns the managed policies attached to a role and its inline policies
+func ListRolePolicies(ctx context.Context, cfg config.Config, roleName string) ([]PolicyRef, error) {
+	awsCfg := GetAWSConfig(cfg).(aws.Config)
+	client := iam.NewFromConfig(awsCfg)
+
+	var policies []PolicyRef
+	attached := iam.NewListAttachedRolePoliciesPaginator(client, &iam.ListAttachedRolePoliciesInput{RoleName: aws.String(roleName)})
+	for attached.HasMorePages() {
+		page, err := attached.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list attached policies: %w", err)
+		}
+		policies = append(policies, attachedPolicies(page.AttachedPolicies)...)
+	}
+
+	inline := iam.NewListRolePoliciesPaginator(client, &iam.ListRolePoliciesInput{RoleName: aws.String(roleName)})
+	for inline.HasMorePages() {
+		page, err := inline.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list inline policies: %w", err)
+		}
+		for _, name := range page.PolicyNames {
+			resp, err := client.GetRolePolicy(ctx, &iam.GetRolePolicyInput{RoleName: aws.String(roleName), PolicyName: aws.String(name)})
+			if err != nil {
+				return nil, fmt.Errorf("failed to get inline policy %s: %w", name, err)
+			}
+			policies = append(policies, PolicyRef{Name: name, Inline: true, Document: DecodePolicyDocument(aws.ToString(resp.PolicyDocument))})
+		}
+	}
+
+	return policies, nil
+}
+
+// attachedPolicies converts the attached managed policies returned by the IAM API
+func attachedPolicies(attached []types.AttachedPolicy) []PolicyRef {
+	policies := make([]PolicyRef, len(attached))
+	for i, p := range attached {
+		policies[i] = PolicyRef{Name: aws.ToString(p.PolicyName), ARN: aws.ToString(p.PolicyArn)}
+	}
+	return policies
+}
+
+// GetPolicyDocument returns the default version of a managed policy as indented JSON
+func GetPolicyDocument(ctx context.Context, cfg config.Config, policyARN string) (string, error) {
+	awsCfg := GetAWSConfig(cfg).(aws.Config)
+	client := iam.NewFromConfig(awsCfg)
+
+	policy, err := client.GetPolicy(ctx, &iam.GetPolicyInput{PolicyArn: aws.String(policyARN)})
+	if err != nil {
+		return "", fmt.Errorf("failed to get policy: %w", err)
+	}
+	version, err := client.GetPolicyVersion(ctx, &iam.GetPolicyVersionInput{
+		PolicyArn: aws.String(policyARN),
+		VersionId: policy.Policy.DefaultVersionId,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to get policy version: %w", err)
+	}
+
+	return DecodePolicyDocument(aws.ToString(version.PolicyVersion.Document)), nil
+}
+
+// DecodePolicyDocument turns a policy document as returned by IAM, which is URL encoded, into
+// indented JSON
+func DecodePolicyDocument(document string) string {
+	if decoded, err := url.PathUnescape(document); err == nil {
+		document = decoded
+	}
+	return FormatPolicy(document)
+}
+
+// RoleNameFromARN returns the name of a role from its ARN, dropping any path
+func RoleNameFromARN(arn string) string {
+	return arn[strings.LastIndex(arn, "/")+1:]
+}
+
+// KeyAgeDays returns how many whole days ago an access key was created
+func KeyAgeDays(created, now time.Time) int {
+	return int(now.Sub(created).Hours() / 24)
+}
+
+// TrustedPrincipals returns the principals a trust policy allows to assume the role, such as
+// "lambda.amazonaws.com" or an account ARN
+func TrustedPrincipals(policy string) []string {
+	type statement struct {
+		Effect    string
+		Principal json.RawMessage
+	}
+	var document struct {
+		Statement json.RawMessage
+	}
+	if json.Unmarshal([]byte(policy), &document) != nil {
+		return nil
+	}
+
+	// Statement and the principal values may each be a single item or a list
+	var statements []statement
+	if json.Unmarshal(document.Statement, &statements) != nil {
+		var single statement
+		if json.Unmarshal(document.Statement, &single) != nil {
+			return nil
+		}
+		statements = []statement{single}
+	}
+
+	var principals []string
+	for _, s := range statements {
+		if s.Effect != "Allow" {
+			continue
+		}
+		var everyone string
+		if json.Unmarshal(s.Principal, &everyone) == nil {
+			principals = append(principals, everyone)
+			continue
+		}
+		var byKind map[string]json.RawMessage
+		if json.Unmarshal(s.Principal, &byKind) != nil {
+			continue
+		}
+		kinds := make([]string, 0, len(byKind))
+		for kind := range byKind {
+			kinds = append(kinds, kind)
+		}
+		sort.Strings(kinds)
+		for _, kind := range kinds {
+			var values []string
+			if json.Unmarshal(byKind[kind], &values) != nil {
+				var value string
+				if json.Unmarshal(byKind[kind], &value) != nil {
+					continue
+				}
+				values = []string{value}
+			}
+			principals = append(principals, values...)
+		}
+	}
+
+	return principals
+}
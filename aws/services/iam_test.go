@@ -0,0 +1,56 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecodePolicyDocument(t *testing.T) {
+	document := "%7B%22Version%22%3A%222012-10-17%22%2C%22Statement%22%3A%5B%7B%22Effect%22%3A%22Allow%22%2C%22Principal%22%3A%7B%22Service%22%3A%22lambda.amazonaws.com%22%7D%7D%5D%7D"
+	assert.Equal(t, `{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Effect": "Allow",
+      "Principal": {
+        "Service": "lambda.amazonaws.com"
+      }
+    }
+  ]
+}`, DecodePolicyDocument(document))
+
+	assert.Equal(t, `{
+  "Sid": "a+b"
+}`, DecodePolicyDocument(`{"Sid":"a+b"}`))
+}
+
+func TestRoleNameFromARN(t *testing.T) {
+	assert.Equal(t, "app-role", RoleNameFromARN("arn:aws:iam::123456789012:role/app-role"))
+	assert.Equal(t, "app-role", RoleNameFromARN("arn:aws:iam::123456789012:role/service/app-role"))
+}
+
+func TestKeyAgeDays(t *testing.T) {
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	assert.Equal(t, 0, KeyAgeDays(now.Add(-time.Hour), now))
+	assert.Equal(t, 29, KeyAgeDays(time.Date(2024, 1, 31, 18, 0, 0, 0, time.UTC), now))
+	assert.Equal(t, 90, KeyAgeDays(now.AddDate(0, 0, -90), now))
+}
+
+func TestTrustedPrincipals(t *testing.T) {
+	policy := `{"Statement":[
+		{"Effect":"Allow","Principal":{"Service":["lambda.amazonaws.com","edgelambda.amazonaws.com"]},"Action":"sts:AssumeRole"},
+		{"Effect":"Allow","Principal":{"AWS":"arn:aws:iam::123456789012:root","Federated":"cognito-identity.amazonaws.com"}},
+		{"Effect":"Deny","Principal":"*"}
+	]}`
+	assert.Equal(t, []string{
+		"lambda.amazonaws.com",
+		"edgelambda.amazonaws.com",
+		"arn:aws:iam::123456789012:root",
+		"cognito-identity.amazonaws.com",
+	}, TrustedPrincipals(policy))
+
+	assert.Equal(t, []string{"*"}, TrustedPrincipals(`{"Statement":{"Effect":"Allow","Principal":"*"}}`))
+	assert.Empty(t, TrustedPrincipals("not json"))
+}
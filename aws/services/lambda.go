@@ -27,9 +27,11 @@ func ListLambdaFunctions(ctx context.Context, cfg config.Config) ([]LambdaFuncti
 		lastMod, _ := time.Parse(time.RFC3339, *fn.LastModified)
 		functions = append(functions, LambdaFunction{
 			Name:         *fn.FunctionName,
+			ARN:          aws.ToString(fn.FunctionArn),
 			Runtime:      string(fn.Runtime),
 			MemorySize:   int64(*fn.MemorySize),
 			LastModified: lastMod,
+			Role:         aws.ToString(fn.Role),
 		})
 	}
 
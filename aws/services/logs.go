@@ -0,0 +1,192 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs/types"
+)
+
+// maxLogStreams limits how many of the most recently written streams of a group are listed
+const maxLogStreams = 500
+
+// ListLogGroups returns the log groups of the region with their retention and stored bytes
+func ListLogGroups(ctx context.Context, cfg config.Config) ([]LogGroup, error) {
+	awsCfg := GetAWSConfig(cfg).(aws.Config)
+	client := cloudwatchlogs.NewFromConfig(awsCfg)
+
+	var groups []LogGroup
+	paginator := cloudwatchlogs.NewDescribeLogGroupsPaginator(client, &cloudwatchlogs.DescribeLogGroupsInput{})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list log groups: %w", err)
+		}
+		for _, g := range page.LogGroups {
+			groups = append(groups, LogGroup{
+				Name:          aws.ToString(g.LogGroupName),
+				ARN:           aws.ToString(g.Arn),
+				RetentionDays: aws.ToInt32(g.RetentionInDays),
+				StoredBytes:   aws.ToInt64(g.StoredBytes),
+				CreatedAt:     millisToTime(g.CreationTime),
+			})
+		}
+	}
+
+	return groups, nil
+}
+
+// ListLogStreams returns the streams of a log group, most recently written first
+func ListLogStreams(ctx context.Context, cfg config.Config, group string) ([]LogStream, error) {
+	awsCfg := GetAWSConfig(cfg).(aws.Config)
+	client := cloudwatchlogs.NewFromConfig(awsCfg)
+
+	var streams []LogStream
+	paginator := cloudwatchlogs.NewDescribeLogStreamsPaginator(client, &cloudwatchlogs.DescribeLogStreamsInput{
+		LogGroupName: aws.String(group),
+		OrderBy:      types.OrderByLastEventTime,
+		Descending:   aws.Bool(true),
+	})
+	for paginator.HasMorePages() && len(streams) < maxLogStreams {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list log streams: %w", err)
+		}
+		for _, s := range page.LogStreams {
+			streams = append(streams, LogStream{
+				Name:         aws.ToString(s.LogStreamName),
+				FirstEventAt: millisToTime(s.FirstEventTimestamp),
+				LastEventAt:  millisToTime(s.LastEventTimestamp),
+			})
+		}
+	}
+
+	return streams, nil
+}
+
+// GetLogEvents returns the latest events of a stream, or the events written after
+// token when it is set. The returned token is unchanged when there is nothing new.
+func GetLogEvents(ctx context.Context, cfg config.Config, group, stream, token string) (*LogEventPage, error) {
+	awsCfg := GetAWSConfig(cfg).(aws.Config)
+	client := cloudwatchlogs.NewFromConfig(awsCfg)
+
+	input := &cloudwatchlogs.GetLogEventsInput{
+		LogGroupName:  aws.String(group),
+		LogStreamName: aws.String(stream),
+	}
+	if token != "" {
+		input.NextToken = aws.String(token)
+		input.StartFromHead = aws.Bool(true)
+	}
+
+	resp, err := client.GetLogEvents(ctx, input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get log events: %w", err)
+	}
+
+	page := &LogEventPage{NextToken: aws.ToString(resp.NextForwardToken)}
+	for _, e := range resp.Events {
+		page.Events = append(page.Events, LogEvent{
+			Timestamp: millisToTime(e.Timestamp),
+			Message:   aws.ToString(e.Message),
+		})
+	}
+
+	return page, nil
+}
+
+// StartInsightsQuery starts a Logs Insights query over a time range and returns its ID
+func StartInsightsQuery(ctx context.Context, cfg config.Config, groups []string, query string, start, end time.Time) (string, error) {
+	awsCfg := GetAWSConfig(cfg).(aws.Config)
+	client := cloudwatchlogs.NewFromConfig(awsCfg)
+
+	resp, err := client.StartQuery(ctx, &cloudwatchlogs.StartQueryInput{
+		LogGroupNames: groups,
+		QueryString:   aws.String(query),
+		StartTime:     aws.Int64(start.Unix()),
+		EndTime:       aws.Int64(end.Unix()),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to start query: %w", err)
+	}
+
+	return aws.ToString(resp.QueryId), nil
+}
+
+// GetInsightsResults returns the status and the records found so far of a Logs Insights query
+func GetInsightsResults(ctx context.Context, cfg config.Config, queryID string) (*InsightsResult, error) {
+	awsCfg := GetAWSConfig(cfg).(aws.Config)
+	client := cloudwatchlogs.NewFromConfig(awsCfg)
+
+	resp, err := client.GetQueryResults(ctx, &cloudwatchlogs.GetQueryResultsInput{
+		QueryId: aws.String(queryID),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get query results: %w", err)
+	}
+
+	result := insightsResult(resp.Results)
+	result.Status = string(resp.Status)
+	if resp.Statistics != nil {
+		result.RecordsMatched = resp.Statistics.RecordsMatched
+		result.BytesScanned = resp.Statistics.BytesScanned
+	}
+
+	return result, nil
+}
+
+// StopInsightsQuery cancels a running Logs Insights query
+func StopInsightsQuery(ctx context.Context, cfg config.Config, queryID string) error {
+	awsCfg := GetAWSConfig(cfg).(aws.Config)
+	client := cloudwatchlogs.NewFromConfig(awsCfg)
+
+	_, err := client.StopQuery(ctx, &cloudwatchlogs.StopQueryInput{
+		QueryId: aws.String(queryID),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to stop query: %w", err)
+	}
+	return nil
+}
+
+// InsightsQueryDone reports whether a query status is final
+func InsightsQueryDone(status string) bool {
+	switch types.QueryStatus(status) {
+	case types.QueryStatusScheduled, types.QueryStatusRunning:
+		return false
+	}
+	return true
+}
+
+// insightsResult converts query records to field maps, dropping the internal @ptr field
+func insightsResult(records [][]types.ResultField) *InsightsResult {
+	result := &InsightsResult{}
+	for _, record := range records {
+		values := make(map[string]string, len(record))
+		for _, field := range record {
+			name := aws.ToString(field.Field)
+			if name == "@ptr" {
+				continue
+			}
+			if !slices.Contains(result.Fields, name) {
+				result.Fields = append(result.Fields, name)
+			}
+			values[name] = aws.ToString(field.Value)
+		}
+		result.Records = append(result.Records, values)
+	}
+	return result
+}
+
+// millisToTime converts a CloudWatch Logs timestamp in milliseconds since the epoch
+func millisToTime(ms *int64) time.Time {
+	if ms == nil {
+		return time.Time{}
+	}
+	return time.UnixMilli(*ms)
+}
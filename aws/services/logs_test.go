@@ -0,0 +1,32 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInsightsResult(t *testing.T) {
+	field := func(name, value string) types.ResultField {
+		return types.ResultField{Field: aws.String(name), Value: aws.String(value)}
+	}
+
+	result := insightsResult([][]types.ResultField{
+		{field("@timestamp", "2024-01-01 00:00:00.000"), field("@message", "started"), field("@ptr", "abc")},
+		{field("@timestamp", "2024-01-01 00:00:01.000"), field("level", "ERROR"), field("@message", "failed")},
+	})
+
+	// Fields keep the order they first appear in and never include @ptr
+	assert.Equal(t, []string{"@timestamp", "@message", "level"}, result.Fields)
+	assert.Equal(t, []map[string]string{
+		{"@timestamp": "2024-01-01 00:00:00.000", "@message": "started"},
+		{"@timestamp": "2024-01-01 00:00:01.000", "level": "ERROR", "@message": "failed"},
+	}, result.Records)
+
+	assert.False(t, InsightsQueryDone("Running"))
+	assert.False(t, InsightsQueryDone("Scheduled"))
+	assert.True(t, InsightsQueryDone("Complete"))
+	assert.True(t, InsightsQueryDone("Failed"))
+}
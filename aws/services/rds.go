@@ -0,0 +1,299 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/rds"
+	"github.com/aws/aws-sdk-go-v2/service/rds/types"
+)
+
+// ListRDSDatabases returns the DB clusters and DB instances of the region, clusters first
+func ListRDSDatabases(ctx context.Context, cfg config.Config) ([]RDSDatabase, error) {
+	awsCfg := GetAWSConfig(cfg).(aws.Config)
+	client := rds.NewFromConfig(awsCfg)
+
+	var databases []RDSDatabase
+
+	clusters := rds.NewDescribeDBClustersPaginator(client, &rds.DescribeDBClustersInput{})
+	for clusters.HasMorePages() {
+		page, err := clusters.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list DB clusters: %w", err)
+		}
+		for _, c := range page.DBClusters {
+			databases = append(databases, RDSDatabase{
+				ID:             aws.ToString(c.DBClusterIdentifier),
+				ARN:            aws.ToString(c.DBClusterArn),
+				Cluster:        true,
+				Engine:         aws.ToString(c.Engine),
+				EngineVersion:  aws.ToString(c.EngineVersion),
+				Class:          aws.ToString(c.DBClusterInstanceClass),
+				Status:         aws.ToString(c.Status),
+				MultiAZ:        aws.ToBool(c.MultiAZ),
+				Endpoint:       aws.ToString(c.Endpoint),
+				Port:           aws.ToInt32(c.Port),
+				StorageGiB:     aws.ToInt32(c.AllocatedStorage),
+				StorageType:    aws.ToString(c.StorageType),
+				MasterUsername: aws.ToString(c.MasterUsername),
+				DatabaseName:   aws.ToString(c.DatabaseName),
+				ParameterGroup: aws.ToString(c.DBClusterParameterGroup),
+				CreatedAt:      aws.ToTime(c.ClusterCreateTime),
+			})
+		}
+	}
+
+	instances := rds.NewDescribeDBInstancesPaginator(client, &rds.DescribeDBInstancesInput{})
+	for instances.HasMorePages() {
+		page, err := instances.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list DB instances: %w", err)
+		}
+		for _, i := range page.DBInstances {
+			db := RDSDatabase{
+				ID:             aws.ToString(i.DBInstanceIdentifier),
+				ARN:            aws.ToString(i.DBInstanceArn),
+				ClusterID:      aws.ToString(i.DBClusterIdentifier),
+				Engine:         aws.ToString(i.Engine),
+				EngineVersion:  aws.ToString(i.EngineVersion),
+				Class:          aws.ToString(i.DBInstanceClass),
+				Status:         aws.ToString(i.DBInstanceStatus),
+				MultiAZ:        aws.ToBool(i.MultiAZ),
+				StorageGiB:     aws.ToInt32(i.AllocatedStorage),
+				StorageType:    aws.ToString(i.StorageType),
+				MasterUsername: aws.ToString(i.MasterUsername),
+				DatabaseName:   aws.ToString(i.DBName),
+				CreatedAt:      aws.ToTime(i.InstanceCreateTime),
+			}
+			// Instances have no endpoint while they are being created
+			if i.Endpoint != nil {
+				db.Endpoint = aws.ToString(i.Endpoint.Address)
+				db.Port = aws.ToInt32(i.Endpoint.Port)
+			}
+			if len(i.DBParameterGroups) > 0 {
+				db.ParameterGroup = aws.ToString(i.DBParameterGroups[0].DBParameterGroupName)
+			}
+			databases = append(databases, db)
+		}
+	}
+
+	return databases, nil
+}
+
+// ListRDSSnapshots returns the snapshots of a DB instance or DB cluster
+func ListRDSSnapshots(ctx context.Context, cfg config.Config, db RDSDatabase) ([]RDSSnapshot, error) {
+	awsCfg := GetAWSConfig(cfg).(aws.Config)
+	client := rds.NewFromConfig(awsCfg)
+
+	var snapshots []RDSSnapshot
+
+	if db.Cluster {
+		paginator := rds.NewDescribeDBClusterSnapshotsPaginator(client, &rds.DescribeDBClusterSnapshotsInput{
+			DBClusterIdentifier: aws.String(db.ID),
+		})
+		for paginator.HasMorePages() {
+			page, err := paginator.NextPage(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to list DB cluster snapshots: %w", err)
+			}
+			for _, s := range page.DBClusterSnapshots {
+				snapshots = append(snapshots, RDSSnapshot{
+					ID:         aws.ToString(s.DBClusterSnapshotIdentifier),
+					Type:       aws.ToString(s.SnapshotType),
+					Status:     aws.ToString(s.Status),
+					StorageGiB: aws.ToInt32(s.AllocatedStorage),
+					Encrypted:  aws.ToBool(s.StorageEncrypted),
+					CreatedAt:  aws.ToTime(s.SnapshotCreateTime),
+				})
+			}
+		}
+		return snapshots, nil
+	}
+
+	paginator := rds.NewDescribeDBSnapshotsPaginator(client, &rds.DescribeDBSnapshotsInput{
+		DBInstanceIdentifier: aws.String(db.ID),
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list DB snapshots: %w", err)
+		}
+		for _, s := range page.DBSnapshots {
+			snapshots = append(snapshots, RDSSnapshot{
+				ID:         aws.ToString(s.DBSnapshotIdentifier),
+				Type:       aws.ToString(s.SnapshotType),
+				Status:     aws.ToString(s.Status),
+				StorageGiB: aws.ToInt32(s.AllocatedStorage),
+				Encrypted:  aws.ToBool(s.Encrypted),
+				CreatedAt:  aws.ToTime(s.SnapshotCreateTime),
+			})
+		}
+	}
+
+	return snapshots, nil
+}
+
+// ListRDSParameters returns the parameters of the parameter group of a DB instance or DB cluster
+func ListRDSParameters(ctx context.Context, cfg config.Config, db RDSDatabase) ([]RDSParameter, error) {
+	awsCfg := GetAWSConfig(cfg).(aws.Config)
+	client := rds.NewFromConfig(awsCfg)
+
+	if db.ParameterGroup == "" {
+		return nil, nil
+	}
+
+	var params []types.Parameter
+
+	if db.Cluster {
+		paginator := rds.NewDescribeDBClusterParametersPaginator(client, &rds.DescribeDBClusterParametersInput{
+			DBClusterParameterGroupName: aws.String(db.ParameterGroup),
+		})
+		for paginator.HasMorePages() {
+			page, err := paginator.NextPage(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to list DB cluster parameters: %w", err)
+			}
+			params = append(params, page.Parameters...)
+		}
+	} else {
+		paginator := rds.NewDescribeDBParametersPaginator(client, &rds.DescribeDBParametersInput{
+			DBParameterGroupName: aws.String(db.ParameterGroup),
+		})
+		for paginator.HasMorePages() {
+			page, err := paginator.NextPage(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to list DB parameters: %w", err)
+			}
+			params = append(params, page.Parameters...)
+		}
+	}
+
+	parameters := make([]RDSParameter, len(params))
+	for i, p := range params {
+		parameters[i] = RDSParameter{
+			Name:        aws.ToString(p.ParameterName),
+			Value:       aws.ToString(p.ParameterValue),
+			Source:      aws.ToString(p.Source),
+			ApplyType:   aws.ToString(p.ApplyType),
+			Modifiable:  aws.ToBool(p.IsModifiable),
+			Description: aws.ToString(p.Description),
+		}
+	}
+
+	return parameters, nil
+}
+
+// ListRDSMaintenance returns the pending maintenance actions of a DB instance or DB cluster
+func ListRDSMaintenance(ctx context.Context, cfg config.Config, db RDSDatabase) ([]RDSMaintenance, error) {
+	awsCfg := GetAWSConfig(cfg).(aws.Config)
+	client := rds.NewFromConfig(awsCfg)
+
+	var actions []RDSMaintenance
+	paginator := rds.NewDescribePendingMaintenanceActionsPaginator(client, &rds.DescribePendingMaintenanceActionsInput{
+		ResourceIdentifier: aws.String(db.ARN),
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list pending maintenance: %w", err)
+		}
+		for _, resource := range page.PendingMaintenanceActions {
+			for _, a := range resource.PendingMaintenanceActionDetails {
+				actions = append(actions, RDSMaintenance{
+					Action:           aws.ToString(a.Action),
+					Description:      aws.ToString(a.Description),
+					OptInStatus:      aws.ToString(a.OptInStatus),
+					AutoAppliedAfter: a.AutoAppliedAfterDate,
+					ForcedApplyDate:  a.ForcedApplyDate,
+				})
+			}
+		}
+	}
+
+	return actions, nil
+}
+
+// StartRDSDatabase starts a stopped DB instance or DB cluster
+func StartRDSDatabase(ctx context.Context, cfg config.Config, db RDSDatabase) error {
+	awsCfg := GetAWSConfig(cfg).(aws.Config)
+	client := rds.NewFromConfig(awsCfg)
+
+	var err error
+	if db.Cluster {
+		_, err = client.StartDBCluster(ctx, &rds.StartDBClusterInput{DBClusterIdentifier: aws.String(db.ID)})
+	} else {
+		_, err = client.StartDBInstance(ctx, &rds.StartDBInstanceInput{DBInstanceIdentifier: aws.String(db.ID)})
+	}
+	if err != nil {
+		return fmt.Errorf("failed to start %s: %w", db.ID, err)
+	}
+	return nil
+}
+
+// StopRDSDatabase stops a running DB instance or DB cluster
+func StopRDSDatabase(ctx context.Context, cfg config.Config, db RDSDatabase) error {
+	awsCfg := GetAWSConfig(cfg).(aws.Config)
+	client := rds.NewFromConfig(awsCfg)
+
+	var err error
+	if db.Cluster {
+		_, err = client.StopDBCluster(ctx, &rds.StopDBClusterInput{DBClusterIdentifier: aws.String(db.ID)})
+	} else {
+		_, err = client.StopDBInstance(ctx, &rds.StopDBInstanceInput{DBInstanceIdentifier: aws.String(db.ID)})
+	}
+	if err != nil {
+		return fmt.Errorf("failed to stop %s: %w", db.ID, err)
+	}
+	return nil
+}
+
+// RDSStartAction returns the API action that starts a database, as recorded in the audit log
+func RDSStartAction(db RDSDatabase) string {
+	if db.Cluster {
+		return "rds:StartDBCluster"
+	}
+	return "rds:StartDBInstance"
+}
+
+// RDSStopAction returns the API action that stops a database, as recorded in the audit log
+func RDSStopAction(db RDSDatabase) string {
+	if db.Cluster {
+		return "rds:StopDBCluster"
+	}
+	return "rds:StopDBInstance"
+}
+
+// RDSConnectionCommand returns a psql or mysql command that connects to a database and prompts for the password
+func RDSConnectionCommand(db RDSDatabase) (string, error) {
+	if db.Endpoint == "" {
+		return "", fmt.Errorf("%s has no endpoint yet", db.ID)
+	}
+
+	engine := db.Engine
+	switch {
+	case strings.Contains(engine, "postgres"):
+		dbname := db.DatabaseName
+		if dbname == "" {
+			dbname = "postgres"
+		}
+		conninfo := fmt.Sprintf("host=%s port=%d dbname=%s sslmode=require", db.Endpoint, db.Port, dbname)
+		if db.MasterUsername != "" {
+			conninfo += " user=" + db.MasterUsername
+		}
+		return fmt.Sprintf("psql %q", conninfo), nil
+	case strings.Contains(engine, "mysql"), engine == "mariadb", engine == "aurora":
+		command := fmt.Sprintf("mysql -h %s -P %d", db.Endpoint, db.Port)
+		if db.MasterUsername != "" {
+			command += " -u " + db.MasterUsername
+		}
+		command += " -p"
+		if db.DatabaseName != "" {
+			command += " " + db.DatabaseName
+		}
+		return command, nil
+	}
+	return "", fmt.Errorf("no psql or mysql connection string for the %s engine", engine)
+}
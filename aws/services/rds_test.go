@@ -0,0 +1,41 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRDSConnectionCommand(t *testing.T) {
+	postgres := RDSDatabase{
+		ID:             "orders",
+		Engine:         "aurora-postgresql",
+		Endpoint:       "orders.cluster-abc.eu-west-1.rds.amazonaws.com",
+		Port:           5432,
+		MasterUsername: "admin",
+	}
+	command, err := RDSConnectionCommand(postgres)
+	assert.NoError(t, err)
+	assert.Equal(t, `psql "host=orders.cluster-abc.eu-west-1.rds.amazonaws.com port=5432 dbname=postgres sslmode=require user=admin"`, command)
+
+	mysql := RDSDatabase{
+		ID:             "shop",
+		Engine:         "mysql",
+		Endpoint:       "shop.abc.eu-west-1.rds.amazonaws.com",
+		Port:           3306,
+		MasterUsername: "root",
+		DatabaseName:   "shop",
+	}
+	command, err = RDSConnectionCommand(mysql)
+	assert.NoError(t, err)
+	assert.Equal(t, "mysql -h shop.abc.eu-west-1.rds.amazonaws.com -P 3306 -u root -p shop", command)
+
+	t.Run("Unsupported", func(t *testing.T) {
+		_, err := RDSConnectionCommand(RDSDatabase{ID: "legacy", Engine: "sqlserver-ex", Endpoint: "legacy.example.com", Port: 1433})
+		assert.Error(t, err)
+
+		// Databases being created have no endpoint
+		_, err = RDSConnectionCommand(RDSDatabase{ID: "new", Engine: "postgres"})
+		assert.Error(t, err)
+	})
+}
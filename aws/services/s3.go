@@ -0,0 +1,237 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+const (
+	// s3Workers limits how many buckets are described at the same time
+	s3Workers = 8
+	// s3PageSize is how many keys are listed per page of the object browser
+	s3PageSize = 1000
+)
+
+// s3Client returns an S3 client for the region a bucket lives in
+func s3Client(cfg config.Config, region string) *s3.Client {
+	awsCfg := GetAWSConfig(cfg).(aws.Config)
+	return s3.NewFromConfig(awsCfg, func(o *s3.Options) {
+		if region != "" {
+			o.Region = region
+		}
+		// Custom endpoints such as LocalStack or MinIO do not resolve bucket subdomains
+		if awsCfg.BaseEndpoint != nil {
+			o.UsePathStyle = true
+		}
+	})
+}
+
+// ListS3Buckets returns the buckets of the account with their region and versioning status
+func ListS3Buckets(ctx context.Context, cfg config.Config) ([]S3Bucket, error) {
+	client := s3Client(cfg, "")
+
+	resp, err := client.ListBuckets(ctx, &s3.ListBucketsInput{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list buckets: %w", err)
+	}
+
+	buckets := make([]S3Bucket, len(resp.Buckets))
+	var wg sync.WaitGroup
+	workers := make(chan struct{}, s3Workers)
+	for i, b := range resp.Buckets {
+		buckets[i] = S3Bucket{
+			Name:      aws.ToString(b.Name),
+			CreatedAt: aws.ToTime(b.CreationDate),
+		}
+
+		wg.Add(1)
+		go func(bucket *S3Bucket) {
+			defer wg.Done()
+			workers <- struct{}{}
+			defer func() { <-workers }()
+
+			// Leave the details unknown for buckets we may not describe
+			region, err := GetS3BucketRegion(ctx, cfg, bucket.Name)
+			if err != nil {
+				return
+			}
+			bucket.Region = region
+
+			versioning, err := s3Client(cfg, region).GetBucketVersioning(ctx, &s3.GetBucketVersioningInput{
+				Bucket: aws.String(bucket.Name),
+			})
+			if err != nil {
+				return
+			}
+			bucket.Versioning = string(versioning.Status)
+			if bucket.Versioning == "" {
+				bucket.Versioning = "Disabled"
+			}
+		}(&buckets[i])
+	}
+	wg.Wait()
+
+	return buckets, nil
+}
+
+// GetS3BucketRegion returns the region a bucket was created in
+func GetS3BucketRegion(ctx context.Context, cfg config.Config, bucket string) (string, error) {
+	resp, err := s3Client(cfg, "").GetBucketLocation(ctx, &s3.GetBucketLocationInput{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to get bucket location: %w", err)
+	}
+
+	return bucketRegion(string(resp.LocationConstraint)), nil
+}
+
+// bucketRegion maps a bucket location constraint to its region. Buckets in us-east-1 report no
+// location, and the oldest EU buckets report "EU".
+func bucketRegion(constraint string) string {
+	switch constraint {
+	case "":
+		return "us-east-1"
+	case "EU":
+		return "eu-west-1"
+	default:
+		return constraint
+	}
+}
+
+// ListS3Objects returns one page of the objects and sub-prefixes directly under a prefix
+func ListS3Objects(ctx context.Context, cfg config.Config, bucket, region, prefix, token string) (*S3ObjectPage, error) {
+	input := &s3.ListObjectsV2Input{
+		Bucket:    aws.String(bucket),
+		Delimiter: aws.String("/"),
+		MaxKeys:   aws.Int32(s3PageSize),
+	}
+	if prefix != "" {
+		input.Prefix = aws.String(prefix)
+	}
+	if token != "" {
+		input.ContinuationToken = aws.String(token)
+	}
+
+	resp, err := s3Client(cfg, region).ListObjectsV2(ctx, input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list objects: %w", err)
+	}
+
+	return s3ObjectPage(resp, prefix), nil
+}
+
+// s3ObjectPage converts a listing of the objects under a prefix into a page of the object browser
+func s3ObjectPage(resp *s3.ListObjectsV2Output, prefix string) *S3ObjectPage {
+	page := &S3ObjectPage{}
+	for _, p := range resp.CommonPrefixes {
+		page.Prefixes = append(page.Prefixes, aws.ToString(p.Prefix))
+	}
+	for _, obj := range resp.Contents {
+		// Skip the placeholder object the console creates for folders
+		if aws.ToString(obj.Key) == prefix {
+			continue
+		}
+		page.Objects = append(page.Objects, S3Object{
+			Key:          aws.ToString(obj.Key),
+			Size:         aws.ToInt64(obj.Size),
+			StorageClass: string(obj.StorageClass),
+			LastModified: aws.ToTime(obj.LastModified),
+		})
+	}
+	if aws.ToBool(resp.IsTruncated) {
+		page.NextToken = aws.ToString(resp.NextContinuationToken)
+	}
+
+	return page
+}
+
+// GetS3ObjectDetail returns the metadata of an object as JSON
+func GetS3ObjectDetail(ctx context.Context, cfg config.Config, bucket, region, key string) (string, error) {
+	result, err := s3Client(cfg, region).HeadObject(ctx, &s3.HeadObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to get object metadata: %w", err)
+	}
+
+	details := struct {
+		Bucket   string
+		Key      string
+		URI      string
+		Metadata *s3.HeadObjectOutput
+	}{
+		Bucket:   bucket,
+		Key:      key,
+		URI:      S3URI(bucket, key),
+		Metadata: result,
+	}
+
+	jsonBytes, err := json.MarshalIndent(details, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal object metadata: %w", err)
+	}
+
+	return string(jsonBytes), nil
+}
+
+// DownloadS3Object writes an object to a local file, returning the number of bytes written
+func DownloadS3Object(ctx context.Context, cfg config.Config, bucket, region, key, path string) (int64, error) {
+	resp, err := s3Client(cfg, region).GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to get object: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// Download next to the destination so a failure never leaves a partial file behind
+	tmp, err := createDownloadFile(path)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	written, err := io.Copy(tmp, resp.Body)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to download object: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return 0, fmt.Errorf("failed to write %s: %w", path, err)
+	}
+
+	return written, nil
+}
+
+// createDownloadFile creates a hidden file next to path to download into. Unlike os.CreateTemp, which
+// always uses 0600, it gets 0644 less the umask, like any other new file.
+func createDownloadFile(path string) (*os.File, error) {
+	dir, base := filepath.Dir(path), filepath.Base(path)
+	for {
+		name := filepath.Join(dir, fmt.Sprintf(".%s.download%d", base, rand.Uint32()))
+		file, err := os.OpenFile(name, os.O_RDWR|os.O_CREATE|os.O_EXCL, 0o644)
+		if !os.IsExist(err) {
+			return file, err
+		}
+	}
+}
+
+// S3URI returns the s3:// URI of a bucket, prefix or object
+func S3URI(bucket, key string) string {
+	return "s3://" + bucket + "/" + key
+}
@@ -0,0 +1,91 @@
+package services
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBucketRegion(t *testing.T) {
+	tests := []struct {
+		constraint string
+		want       string
+	}{
+		{"", "us-east-1"},
+		{"EU", "eu-west-1"},
+		{"eu-central-1", "eu-central-1"},
+		{"ap-southeast-2", "ap-southeast-2"},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, bucketRegion(tt.constraint), "constraint %q", tt.constraint)
+	}
+}
+
+func TestS3ObjectPage(t *testing.T) {
+	resp := &s3.ListObjectsV2Output{
+		CommonPrefixes: []types.CommonPrefix{{Prefix: aws.String("logs/2024/")}},
+		Contents: []types.Object{
+			{Key: aws.String("logs/"), Size: aws.Int64(0)},
+			{Key: aws.String("logs/app.log"), Size: aws.Int64(512), StorageClass: types.ObjectStorageClassStandard},
+		},
+		IsTruncated:           aws.Bool(true),
+		NextContinuationToken: aws.String("next"),
+	}
+
+	// The console's folder placeholder is the prefix itself and is not listed
+	page := s3ObjectPage(resp, "logs/")
+	assert.Equal(t, []string{"logs/2024/"}, page.Prefixes)
+	if assert.Len(t, page.Objects, 1) {
+		assert.Equal(t, "logs/app.log", page.Objects[0].Key)
+		assert.Equal(t, int64(512), page.Objects[0].Size)
+		assert.Equal(t, "STANDARD", page.Objects[0].StorageClass)
+	}
+	assert.Equal(t, "next", page.NextToken)
+
+	// At the root an empty key is no placeholder, and the last page has no token
+	resp.IsTruncated = aws.Bool(false)
+	page = s3ObjectPage(resp, "")
+	assert.Len(t, page.Objects, 2)
+	assert.Empty(t, page.NextToken)
+}
+
+func TestS3URI(t *testing.T) {
+	tests := []struct {
+		bucket string
+		key    string
+		want   string
+	}{
+		{"assets", "", "s3://assets/"},
+		{"assets", "images/", "s3://assets/images/"},
+		{"assets", "images/logo.png", "s3://assets/images/logo.png"},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, S3URI(tt.bucket, tt.key))
+	}
+}
+
+func TestCreateDownloadFile(t *testing.T) {
+	dir := t.TempDir()
+
+	// A plain new file shows which bits the umask clears
+	probe := filepath.Join(dir, "probe")
+	require.NoError(t, os.WriteFile(probe, nil, 0o644))
+	want, err := os.Stat(probe)
+	require.NoError(t, err)
+
+	file, err := createDownloadFile(filepath.Join(dir, "report.csv"))
+	require.NoError(t, err)
+	defer file.Close()
+
+	info, err := file.Stat()
+	require.NoError(t, err)
+	assert.Equal(t, want.Mode().Perm(), info.Mode().Perm())
+	assert.Equal(t, dir, filepath.Dir(file.Name()))
+	assert.Contains(t, filepath.Base(file.Name()), ".report.csv.download")
+}
@@ -2,6 +2,7 @@ package services
 
 import (
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"time"
@@ -30,10 +31,17 @@ func ListSecrets(ctx context.Context, cfg config.Config) ([]Secret, error) {
 			daysUntilRotation = int64(time.Until(*s.NextRotationDate).Hours() / 24)
 		}
 
+		tags := make(map[string]string)
+		for _, tag := range s.Tags {
+			tags[aws.ToString(tag.Key)] = aws.ToString(tag.Value)
+		}
+
 		secrets = append(secrets, Secret{
 			Name:              *s.Name,
+			ARN:               aws.ToString(s.ARN),
 			LastModified:      *s.LastChangedDate,
 			DaysUntilRotation: daysUntilRotation,
+			Tags:              tags,
 		})
 	}
 
@@ -94,5 +102,9 @@ func GetSecretValue(cfg aws.Config, secretID string) (string, error) {
 		return "", fmt.Errorf("failed to get secret value: %w", err)
 	}
 
+	// Binary secrets have no string value, show them base64 encoded
+	if result.SecretString == nil {
+		return base64.StdEncoding.EncodeToString(result.SecretBinary), nil
+	}
 	return *result.SecretString, nil
 }
@@ -0,0 +1,144 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/sns"
+	"github.com/aws/aws-sdk-go-v2/service/sns/types"
+)
+
+const (
+	// snsWorkers limits how many topics have their attributes read at the same time
+	snsWorkers = 8
+	// pendingConfirmation is the subscription ARN SNS reports until an endpoint confirms
+	pendingConfirmation = "PendingConfirmation"
+)
+
+// ListTopics returns the topics of the region with their subscription counts and access policy
+func ListTopics(ctx context.Context, cfg config.Config) ([]Topic, error) {
+	awsCfg := GetAWSConfig(cfg).(aws.Config)
+	client := sns.NewFromConfig(awsCfg)
+
+	var arns []string
+	paginator := sns.NewListTopicsPaginator(client, &sns.ListTopicsInput{})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list topics: %w", err)
+		}
+		for _, t := range page.Topics {
+			arns = append(arns, aws.ToString(t.TopicArn))
+		}
+	}
+
+	topics := make([]Topic, len(arns))
+	var wg sync.WaitGroup
+	workers := make(chan struct{}, snsWorkers)
+	for i, arn := range arns {
+		topics[i] = Topic{Name: arn[strings.LastIndex(arn, ":")+1:], ARN: arn}
+
+		wg.Add(1)
+		go func(topic *Topic) {
+			defer wg.Done()
+			workers <- struct{}{}
+			defer func() { <-workers }()
+
+			// Leave the details unknown for topics we may not read
+			resp, err := client.GetTopicAttributes(ctx, &sns.GetTopicAttributesInput{TopicArn: aws.String(topic.ARN)})
+			if err != nil {
+				return
+			}
+			topic.DisplayName = resp.Attributes["DisplayName"]
+			topic.FIFO = resp.Attributes["FifoTopic"] == "true"
+			topic.Confirmed, _ = strconv.Atoi(resp.Attributes["SubscriptionsConfirmed"])
+			topic.Pending, _ = strconv.Atoi(resp.Attributes["SubscriptionsPending"])
+			topic.Policy = resp.Attributes["Policy"]
+		}(&topics[i])
+	}
+	wg.Wait()
+
+	return topics, nil
+}
+
+// ListSubscriptions returns the subscriptions of a topic, including those pending confirmation
+func ListSubscriptions(ctx context.Context, cfg config.Config, topicARN string) ([]Subscription, error) {
+	awsCfg := GetAWSConfig(cfg).(aws.Config)
+	client := sns.NewFromConfig(awsCfg)
+
+	var subscriptions []Subscription
+	paginator := sns.NewListSubscriptionsByTopicPaginator(client, &sns.ListSubscriptionsByTopicInput{TopicArn: aws.String(topicARN)})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list subscriptions: %w", err)
+		}
+		for _, s := range page.Subscriptions {
+			subscription := Subscription{
+				ARN:      aws.ToString(s.SubscriptionArn),
+				Protocol: aws.ToString(s.Protocol),
+				Endpoint: aws.ToString(s.Endpoint),
+				Owner:    aws.ToString(s.Owner),
+			}
+			if subscription.ARN == pendingConfirmation {
+				subscription.ARN = ""
+				subscription.Pending = true
+			}
+			subscriptions = append(subscriptions, subscription)
+		}
+	}
+
+	return subscriptions, nil
+}
+
+// PublishMessage publishes a message with string attributes to a topic and returns its ID. FIFO
+// topics need a group ID and, without content-based deduplication, a deduplication ID.
+func PublishMessage(ctx context.Context, cfg config.Config, topicARN, subject, message string, attributes map[string]string, groupID, dedupID string) (string, error) {
+	awsCfg := GetAWSConfig(cfg).(aws.Config)
+	client := sns.NewFromConfig(awsCfg)
+
+	input := &sns.PublishInput{
+		TopicArn: aws.String(topicARN),
+		Message:  aws.String(message),
+	}
+	if subject != "" {
+		input.Subject = aws.String(subject)
+	}
+	if groupID != "" {
+		input.MessageGroupId = aws.String(groupID)
+	}
+	if dedupID != "" {
+		input.MessageDeduplicationId = aws.String(dedupID)
+	}
+	if len(attributes) > 0 {
+		input.MessageAttributes = make(map[string]types.MessageAttributeValue)
+		for name, value := range attributes {
+			input.MessageAttributes[name] = types.MessageAttributeValue{
+				DataType:    aws.String("String"),
+				StringValue: aws.String(value),
+			}
+		}
+	}
+
+	resp, err := client.Publish(ctx, input)
+	if err != nil {
+		return "", fmt.Errorf("failed to publish message: %w", err)
+	}
+	return aws.ToString(resp.MessageId), nil
+}
+
+// FormatPolicy indents a JSON policy document, returning it unchanged when it is not valid JSON
+func FormatPolicy(policy string) string {
+	var indented bytes.Buffer
+	if err := json.Indent(&indented, []byte(policy), "", "  "); err != nil {
+		return policy
+	}
+	return indented.String()
+}
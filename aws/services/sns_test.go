@@ -0,0 +1,22 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatPolicy(t *testing.T) {
+	policy := `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":"SNS:Publish"}]}`
+	assert.Equal(t, `{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Effect": "Allow",
+      "Action": "SNS:Publish"
+    }
+  ]
+}`, FormatPolicy(policy))
+
+	assert.Equal(t, "not json", FormatPolicy("not json"))
+}
@@ -0,0 +1,221 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
+)
+
+const (
+	// sqsWorkers limits how many queues have their attributes read at the same time
+	sqsWorkers = 8
+	// sqsPeekSize is how many messages a single peek receives, the most SQS allows
+	sqsPeekSize = 10
+)
+
+// ListQueues returns the queues of the region with their approximate message counts, each dead-letter
+// queue listing the queues that send to it
+func ListQueues(ctx context.Context, cfg config.Config) ([]Queue, error) {
+	awsCfg := GetAWSConfig(cfg).(aws.Config)
+	client := sqs.NewFromConfig(awsCfg)
+
+	var urls []string
+	paginator := sqs.NewListQueuesPaginator(client, &sqs.ListQueuesInput{})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list queues: %w", err)
+		}
+		urls = append(urls, page.QueueUrls...)
+	}
+
+	queues := make([]Queue, len(urls))
+	var wg sync.WaitGroup
+	workers := make(chan struct{}, sqsWorkers)
+	for i, url := range urls {
+		queues[i] = Queue{Name: url[strings.LastIndex(url, "/")+1:], URL: url}
+
+		wg.Add(1)
+		go func(queue *Queue) {
+			defer wg.Done()
+			workers <- struct{}{}
+			defer func() { <-workers }()
+
+			// Leave the counts unknown for queues we may not read
+			resp, err := client.GetQueueAttributes(ctx, &sqs.GetQueueAttributesInput{
+				QueueUrl:       aws.String(queue.URL),
+				AttributeNames: []types.QueueAttributeName{types.QueueAttributeNameAll},
+			})
+			if err != nil {
+				return
+			}
+			queueAttributes(queue, resp.Attributes)
+		}(&queues[i])
+	}
+	wg.Wait()
+
+	LinkDeadLetterQueues(queues)
+
+	return queues, nil
+}
+
+// LinkDeadLetterQueues sets the Sources of every queue that is the dead-letter queue of another
+func LinkDeadLetterQueues(queues []Queue) {
+	byARN := make(map[string]*Queue)
+	for i := range queues {
+		if queues[i].ARN != "" {
+			byARN[queues[i].ARN] = &queues[i]
+		}
+	}
+	for _, queue := range queues {
+		if dlq, ok := byARN[queue.DeadLetterARN]; ok {
+			dlq.Sources = append(dlq.Sources, queue.Name)
+		}
+	}
+}
+
+// PeekQueue receives up to 10 messages and makes them visible again right away, so they stay available
+// to consumers. Receiving still counts towards the maxReceiveCount of the redrive policy.
+func PeekQueue(ctx context.Context, cfg config.Config, url string) ([]QueueMessage, error) {
+	awsCfg := GetAWSConfig(cfg).(aws.Config)
+	client := sqs.NewFromConfig(awsCfg)
+
+	return peekQueue(ctx, client, url)
+}
+
+// peekQueue receives messages and resets their visibility timeout to 0. SQS drops a VisibilityTimeout
+// of 0 from ReceiveMessage and from ChangeMessageVisibilityBatch entries, so the queue default would
+// apply, but ChangeMessageVisibility always sends it.
+func peekQueue(ctx context.Context, client *sqs.Client, url string) ([]QueueMessage, error) {
+	resp, err := client.ReceiveMessage(ctx, &sqs.ReceiveMessageInput{
+		QueueUrl:              aws.String(url),
+		MaxNumberOfMessages:   sqsPeekSize,
+		AttributeNames:        []types.QueueAttributeName{types.QueueAttributeNameAll},
+		MessageAttributeNames: []string{"All"},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to receive messages: %w", err)
+	}
+
+	for _, m := range resp.Messages {
+		_, err := client.ChangeMessageVisibility(ctx, &sqs.ChangeMessageVisibilityInput{
+			QueueUrl:          aws.String(url),
+			ReceiptHandle:     m.ReceiptHandle,
+			VisibilityTimeout: 0,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to make message %s visible again: %w", aws.ToString(m.MessageId), err)
+		}
+	}
+
+	messages := make([]QueueMessage, len(resp.Messages))
+	for i, m := range resp.Messages {
+		message := QueueMessage{
+			ID:      aws.ToString(m.MessageId),
+			Body:    aws.ToString(m.Body),
+			GroupID: m.Attributes["MessageGroupId"],
+		}
+		if millis, err := strconv.ParseInt(m.Attributes["SentTimestamp"], 10, 64); err == nil {
+			message.SentAt = millisToTime(&millis)
+		}
+		message.ReceiveCount, _ = strconv.Atoi(m.Attributes["ApproximateReceiveCount"])
+		for name, value := range m.MessageAttributes {
+			if value.StringValue != nil {
+				if message.Attributes == nil {
+					message.Attributes = make(map[string]string)
+				}
+				message.Attributes[name] = aws.ToString(value.StringValue)
+			}
+		}
+		messages[i] = message
+	}
+
+	return messages, nil
+}
+
+// SendQueueMessage sends a message to a queue and returns its ID. FIFO queues need a group ID and,
+// without content-based deduplication, a deduplication ID.
+func SendQueueMessage(ctx context.Context, cfg config.Config, url, body, groupID, dedupID string) (string, error) {
+	awsCfg := GetAWSConfig(cfg).(aws.Config)
+	client := sqs.NewFromConfig(awsCfg)
+
+	input := &sqs.SendMessageInput{
+		QueueUrl:    aws.String(url),
+		MessageBody: aws.String(body),
+	}
+	if groupID != "" {
+		input.MessageGroupId = aws.String(groupID)
+	}
+	if dedupID != "" {
+		input.MessageDeduplicationId = aws.String(dedupID)
+	}
+
+	resp, err := client.SendMessage(ctx, input)
+	if err != nil {
+		return "", fmt.Errorf("failed to send message: %w", err)
+	}
+	return aws.ToString(resp.MessageId), nil
+}
+
+// PurgeQueue deletes all messages of a queue
+func PurgeQueue(ctx context.Context, cfg config.Config, url string) error {
+	awsCfg := GetAWSConfig(cfg).(aws.Config)
+	client := sqs.NewFromConfig(awsCfg)
+
+	if _, err := client.PurgeQueue(ctx, &sqs.PurgeQueueInput{QueueUrl: aws.String(url)}); err != nil {
+		return fmt.Errorf("failed to purge queue: %w", err)
+	}
+	return nil
+}
+
+// RedriveQueue moves the messages of a dead-letter queue back to the queues they came from
+func RedriveQueue(ctx context.Context, cfg config.Config, arn string) error {
+	awsCfg := GetAWSConfig(cfg).(aws.Config)
+	client := sqs.NewFromConfig(awsCfg)
+
+	if _, err := client.StartMessageMoveTask(ctx, &sqs.StartMessageMoveTaskInput{SourceArn: aws.String(arn)}); err != nil {
+		return fmt.Errorf("failed to start redrive: %w", err)
+	}
+	return nil
+}
+
+// queueAttributes fills in a queue from the attributes returned by GetQueueAttributes
+func queueAttributes(queue *Queue, attributes map[string]string) {
+	count := func(name types.QueueAttributeName) int64 {
+		n, _ := strconv.ParseInt(attributes[string(name)], 10, 64)
+		return n
+	}
+
+	queue.ARN = attributes[string(types.QueueAttributeNameQueueArn)]
+	queue.FIFO = attributes[string(types.QueueAttributeNameFifoQueue)] == "true"
+	queue.Visible = count(types.QueueAttributeNameApproximateNumberOfMessages)
+	queue.InFlight = count(types.QueueAttributeNameApproximateNumberOfMessagesNotVisible)
+	queue.Delayed = count(types.QueueAttributeNameApproximateNumberOfMessagesDelayed)
+	if created := count(types.QueueAttributeNameCreatedTimestamp); created > 0 {
+		queue.CreatedAt = time.Unix(created, 0)
+	}
+	queue.DeadLetterARN, queue.MaxReceiveCount = parseRedrivePolicy(attributes[string(types.QueueAttributeNameRedrivePolicy)])
+}
+
+// parseRedrivePolicy returns the dead-letter queue ARN and maximum receive count of a redrive
+// policy, whose count is a number or a string depending on how the policy was written
+func parseRedrivePolicy(policy string) (string, int) {
+	var redrive struct {
+		DeadLetterTargetArn string      `json:"deadLetterTargetArn"`
+		MaxReceiveCount     json.Number `json:"maxReceiveCount"`
+	}
+	if policy == "" || json.Unmarshal([]byte(policy), &redrive) != nil {
+		return "", 0
+	}
+	count, _ := strconv.Atoi(redrive.MaxReceiveCount.String())
+	return redrive.DeadLetterTargetArn, count
+}
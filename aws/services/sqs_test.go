@@ -0,0 +1,115 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRedrivePolicy(t *testing.T) {
+	arn, count := parseRedrivePolicy(`{"deadLetterTargetArn":"arn:aws:sqs:eu-west-1:123456789012:orders-dlq","maxReceiveCount":5}`)
+	assert.Equal(t, "arn:aws:sqs:eu-west-1:123456789012:orders-dlq", arn)
+	assert.Equal(t, 5, count)
+
+	// The console writes the count as a string
+	_, count = parseRedrivePolicy(`{"deadLetterTargetArn":"arn:aws:sqs:eu-west-1:123456789012:orders-dlq","maxReceiveCount":"3"}`)
+	assert.Equal(t, 3, count)
+
+	arn, count = parseRedrivePolicy("")
+	assert.Empty(t, arn)
+	assert.Zero(t, count)
+}
+
+func TestLinkDeadLetterQueues(t *testing.T) {
+	queues := []Queue{
+		{Name: "orders", ARN: "arn:orders", DeadLetterARN: "arn:dlq"},
+		{Name: "payments", ARN: "arn:payments", DeadLetterARN: "arn:dlq"},
+		{Name: "dlq", ARN: "arn:dlq"},
+		{Name: "external", ARN: "arn:external", DeadLetterARN: "arn:elsewhere"},
+	}
+	LinkDeadLetterQueues(queues)
+
+	assert.Equal(t, []string{"orders", "payments"}, queues[2].Sources)
+	assert.Empty(t, queues[0].Sources)
+	assert.Empty(t, queues[3].Sources)
+}
+
+func TestQueueAttributes(t *testing.T) {
+	queue := Queue{Name: "orders.fifo"}
+	queueAttributes(&queue, map[string]string{
+		"QueueArn":                              "arn:aws:sqs:eu-west-1:123456789012:orders.fifo",
+		"FifoQueue":                             "true",
+		"ApproximateNumberOfMessages":           "12",
+		"ApproximateNumberOfMessagesNotVisible": "3",
+		"ApproximateNumberOfMessagesDelayed":    "1",
+		"CreatedTimestamp":                      "1700000000",
+	})
+
+	assert.True(t, queue.FIFO)
+	assert.Equal(t, int64(12), queue.Visible)
+	assert.Equal(t, int64(3), queue.InFlight)
+	assert.Equal(t, int64(1), queue.Delayed)
+	assert.Equal(t, int64(1700000000), queue.CreatedAt.Unix())
+	assert.Empty(t, queue.DeadLetterARN)
+}
+
+func TestPeekQueueResetsVisibility(t *testing.T) {
+	var mu sync.Mutex
+	requests := map[string][]map[string]interface{}{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		action := strings.TrimPrefix(r.Header.Get("X-Amz-Target"), "AmazonSQS.")
+		body, _ := io.ReadAll(r.Body)
+		var input map[string]interface{}
+		_ = json.Unmarshal(body, &input)
+		mu.Lock()
+		requests[action] = append(requests[action], input)
+		mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/x-amz-json-1.0")
+		switch action {
+		case "ReceiveMessage":
+			_, _ = io.WriteString(w, `{"Messages":[
+				{"MessageId":"m-1","ReceiptHandle":"handle-1","Body":"one","Attributes":{"ApproximateReceiveCount":"2"}},
+				{"MessageId":"m-2","ReceiptHandle":"handle-2","Body":"two"}]}`)
+		default:
+			_, _ = io.WriteString(w, `{}`)
+		}
+	}))
+	defer server.Close()
+
+	client := sqs.New(sqs.Options{
+		Region:                           "eu-west-1",
+		BaseEndpoint:                     aws.String(server.URL),
+		Credentials:                      credentials.NewStaticCredentialsProvider("AKID", "SECRET", ""),
+		DisableMessageChecksumValidation: true,
+	})
+	url := "https://sqs.eu-west-1.amazonaws.com/123456789012/orders"
+
+	messages, err := peekQueue(context.Background(), client, url)
+	require.NoError(t, err)
+	require.Len(t, messages, 2)
+	assert.Equal(t, "one", messages[0].Body)
+	assert.Equal(t, 2, messages[0].ReceiveCount)
+
+	// Each received message is made visible again, with the zero actually on the wire
+	changes := requests["ChangeMessageVisibility"]
+	require.Len(t, changes, 2)
+	for i, change := range changes {
+		assert.Equal(t, url, change["QueueUrl"])
+		assert.Equal(t, []string{"handle-1", "handle-2"}[i], change["ReceiptHandle"])
+		timeout, ok := change["VisibilityTimeout"]
+		require.True(t, ok, "VisibilityTimeout missing from the request")
+		assert.Equal(t, float64(0), timeout)
+	}
+}
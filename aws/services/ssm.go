@@ -0,0 +1,196 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	"github.com/aws/aws-sdk-go-v2/service/ssm/types"
+)
+
+// SecureStringType is the parameter type whose values are encrypted with KMS
+const SecureStringType = string(types.ParameterTypeSecureString)
+
+// ListParameters returns every parameter below a path, such as "/app/prod/". SecureString values
+// are left empty, use GetParameterValue to decrypt one on demand.
+func ListParameters(ctx context.Context, cfg config.Config, path string) ([]Parameter, error) {
+	awsCfg := GetAWSConfig(cfg).(aws.Config)
+	client := ssm.NewFromConfig(awsCfg)
+
+	var params []Parameter
+	byName := make(map[string]int)
+	paginator := ssm.NewGetParametersByPathPaginator(client, &ssm.GetParametersByPathInput{
+		Path:           aws.String(parameterPath(path)),
+		Recursive:      aws.Bool(true),
+		WithDecryption: aws.Bool(false),
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list parameters: %w", err)
+		}
+		for _, p := range page.Parameters {
+			param := Parameter{
+				Name:         aws.ToString(p.Name),
+				Type:         string(p.Type),
+				Version:      p.Version,
+				DataType:     aws.ToString(p.DataType),
+				LastModified: aws.ToTime(p.LastModifiedDate),
+			}
+			if p.Type != types.ParameterTypeSecureString {
+				param.Value = aws.ToString(p.Value)
+			}
+			byName[param.Name] = len(params)
+			params = append(params, param)
+		}
+	}
+
+	// GetParametersByPath leaves out the tier and who made the last change, which only
+	// DescribeParameters returns. At the root it also finds names without a leading slash.
+	input := &ssm.DescribeParametersInput{}
+	if parameterPath(path) != "/" {
+		input.ParameterFilters = []types.ParameterStringFilter{{
+			Key:    aws.String("Path"),
+			Option: aws.String("Recursive"),
+			Values: []string{strings.TrimSuffix(parameterPath(path), "/")},
+		}}
+	}
+	describe := ssm.NewDescribeParametersPaginator(client, input)
+	for describe.HasMorePages() {
+		page, err := describe.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to describe parameters: %w", err)
+		}
+		for _, p := range page.Parameters {
+			name := aws.ToString(p.Name)
+			i, ok := byName[name]
+			if !ok {
+				byName[name] = len(params)
+				params = append(params, Parameter{
+					Name:         name,
+					Type:         string(p.Type),
+					Version:      p.Version,
+					DataType:     aws.ToString(p.DataType),
+					LastModified: aws.ToTime(p.LastModifiedDate),
+				})
+				i = len(params) - 1
+			}
+			params[i].Tier = string(p.Tier)
+			params[i].LastModifiedUser = aws.ToString(p.LastModifiedUser)
+			params[i].Description = aws.ToString(p.Description)
+		}
+	}
+
+	return params, nil
+}
+
+// ParameterTree splits the parameters below a path into the folders directly under it, counting
+// the parameters each holds, and the parameters that sit at the path itself
+func ParameterTree(path string, params []Parameter) ([]ParameterFolder, []Parameter) {
+	path = parameterPath(path)
+
+	counts := make(map[string]int)
+	var leaves []Parameter
+	for _, param := range params {
+		rest, ok := strings.CutPrefix(param.Name, path)
+		if !ok {
+			// Names without a leading slash only show up at the root
+			if path == "/" && !strings.HasPrefix(param.Name, "/") {
+				leaves = append(leaves, param)
+			}
+			continue
+		}
+		if i := strings.Index(rest, "/"); i >= 0 {
+			counts[path+rest[:i+1]]++
+		} else {
+			leaves = append(leaves, param)
+		}
+	}
+
+	folders := make([]ParameterFolder, 0, len(counts))
+	for folder, count := range counts {
+		folders = append(folders, ParameterFolder{Path: folder, Count: count})
+	}
+	sort.Slice(folders, func(i, j int) bool { return folders[i].Path < folders[j].Path })
+	sort.Slice(leaves, func(i, j int) bool { return leaves[i].Name < leaves[j].Name })
+
+	return folders, leaves
+}
+
+// GetParameterValue returns the decrypted value of a parameter. The name may select a version or
+// label, such as "/app/db/password:3".
+func GetParameterValue(ctx context.Context, cfg config.Config, name string) (string, error) {
+	awsCfg := GetAWSConfig(cfg).(aws.Config)
+	client := ssm.NewFromConfig(awsCfg)
+
+	resp, err := client.GetParameter(ctx, &ssm.GetParameterInput{
+		Name:           aws.String(name),
+		WithDecryption: aws.Bool(true),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to get parameter value: %w", err)
+	}
+	return aws.ToString(resp.Parameter.Value), nil
+}
+
+// PutParameter creates a parameter, or replaces the value of an existing one when overwrite is set,
+// and returns its new version
+func PutParameter(ctx context.Context, cfg config.Config, name, value, paramType string, overwrite bool) (int64, error) {
+	awsCfg := GetAWSConfig(cfg).(aws.Config)
+	client := ssm.NewFromConfig(awsCfg)
+
+	resp, err := client.PutParameter(ctx, &ssm.PutParameterInput{
+		Name:      aws.String(name),
+		Value:     aws.String(value),
+		Type:      types.ParameterType(paramType),
+		Overwrite: aws.Bool(overwrite),
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to put parameter: %w", err)
+	}
+	return resp.Version, nil
+}
+
+// GetParameterHistory returns the versions of a parameter, oldest first. SecureString values are
+// left empty.
+func GetParameterHistory(ctx context.Context, cfg config.Config, name string) ([]ParameterVersion, error) {
+	awsCfg := GetAWSConfig(cfg).(aws.Config)
+	client := ssm.NewFromConfig(awsCfg)
+
+	var versions []ParameterVersion
+	paginator := ssm.NewGetParameterHistoryPaginator(client, &ssm.GetParameterHistoryInput{
+		Name:           aws.String(name),
+		WithDecryption: aws.Bool(false),
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get parameter history: %w", err)
+		}
+		for _, p := range page.Parameters {
+			version := ParameterVersion{
+				Version:      p.Version,
+				Type:         string(p.Type),
+				Labels:       p.Labels,
+				LastModified: aws.ToTime(p.LastModifiedDate),
+				User:         aws.ToString(p.LastModifiedUser),
+				Description:  aws.ToString(p.Description),
+			}
+			if p.Type != types.ParameterTypeSecureString {
+				version.Value = aws.ToString(p.Value)
+			}
+			versions = append(versions, version)
+		}
+	}
+
+	return versions, nil
+}
+
+// parameterPath returns a path with exactly one trailing slash, "/" for an empty path
+func parameterPath(path string) string {
+	return strings.TrimSuffix(path, "/") + "/"
+}
@@ -0,0 +1,36 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParameterTree(t *testing.T) {
+	params := []Parameter{
+		{Name: "/app/prod/db/password"},
+		{Name: "/app/prod/db/user"},
+		{Name: "/app/prod/region"},
+		{Name: "/app/dev/region"},
+		{Name: "legacy-flag"},
+	}
+
+	folders, leaves := ParameterTree("/", params)
+	assert.Equal(t, []ParameterFolder{{Path: "/app/", Count: 4}}, folders)
+	assert.Equal(t, []Parameter{{Name: "legacy-flag"}}, leaves)
+
+	folders, leaves = ParameterTree("/app/prod", params)
+	assert.Equal(t, []ParameterFolder{{Path: "/app/prod/db/", Count: 2}}, folders)
+	assert.Equal(t, []Parameter{{Name: "/app/prod/region"}}, leaves)
+
+	folders, leaves = ParameterTree("/app/", params)
+	assert.Equal(t, []ParameterFolder{{Path: "/app/dev/", Count: 1}, {Path: "/app/prod/", Count: 3}}, folders)
+	assert.Empty(t, leaves)
+}
+
+func TestParameterPath(t *testing.T) {
+	assert.Equal(t, "/", parameterPath(""))
+	assert.Equal(t, "/", parameterPath("/"))
+	assert.Equal(t, "/app/", parameterPath("/app"))
+	assert.Equal(t, "/app/", parameterPath("/app/"))
+}
@@ -0,0 +1,49 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+)
+
+// GetAccountID returns the account ID of the caller
+func GetAccountID(ctx context.Context, cfg config.Config) (string, error) {
+	awsCfg := GetAWSConfig(cfg).(aws.Config)
+	client := sts.NewFromConfig(awsCfg)
+
+	resp, err := client.GetCallerIdentity(ctx, &sts.GetCallerIdentityInput{})
+	if err != nil {
+		return "", fmt.Errorf("failed to get caller identity: %w", err)
+	}
+
+	return aws.ToString(resp.Account), nil
+}
+
+// GetIdentity returns the caller identity together with the account alias, if one is set
+func GetIdentity(ctx context.Context, cfg config.Config) (*Identity, error) {
+	awsCfg := GetAWSConfig(cfg).(aws.Config)
+	client := sts.NewFromConfig(awsCfg)
+
+	resp, err := client.GetCallerIdentity(ctx, &sts.GetCallerIdentityInput{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get caller identity: %w", err)
+	}
+
+	identity := &Identity{
+		Account: aws.ToString(resp.Account),
+		ARN:     aws.ToString(resp.Arn),
+		UserID:  aws.ToString(resp.UserId),
+	}
+
+	// Many roles may not list aliases, which should not fail the lookup
+	aliases, err := iam.NewFromConfig(awsCfg).ListAccountAliases(ctx, &iam.ListAccountAliasesInput{})
+	if err == nil && len(aliases.AccountAliases) > 0 {
+		identity.Alias = aliases.AccountAliases[0]
+	}
+
+	return identity, nil
+}
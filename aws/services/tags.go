@@ -0,0 +1,267 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/aws/aws-sdk-go-v2/service/ecr"
+	ecrtypes "github.com/aws/aws-sdk-go-v2/service/ecr/types"
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+	smtypes "github.com/aws/aws-sdk-go-v2/service/secretsmanager/types"
+)
+
+// GetTags returns the current tags of a resource
+func GetTags(ctx context.Context, cfg config.Config, target TagTarget) (map[string]string, error) {
+	awsCfg := GetAWSConfig(cfg).(aws.Config)
+	tags := make(map[string]string)
+
+	switch target.Service {
+	case "ec2":
+		client := ec2.NewFromConfig(awsCfg)
+		paginator := ec2.NewDescribeTagsPaginator(client, &ec2.DescribeTagsInput{
+			Filters: []ec2types.Filter{
+				{Name: aws.String("resource-id"), Values: []string{target.ID}},
+			},
+		})
+		for paginator.HasMorePages() {
+			page, err := paginator.NextPage(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get tags: %w", err)
+			}
+			for _, tag := range page.Tags {
+				tags[aws.ToString(tag.Key)] = aws.ToString(tag.Value)
+			}
+		}
+
+	case "ecr":
+		client := ecr.NewFromConfig(awsCfg)
+		resp, err := client.ListTagsForResource(ctx, &ecr.ListTagsForResourceInput{
+			ResourceArn: aws.String(target.ID),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get tags: %w", err)
+		}
+		for _, tag := range resp.Tags {
+			tags[aws.ToString(tag.Key)] = aws.ToString(tag.Value)
+		}
+
+	case "lambda":
+		client := lambda.NewFromConfig(awsCfg)
+		resp, err := client.ListTags(ctx, &lambda.ListTagsInput{
+			Resource: aws.String(target.ID),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get tags: %w", err)
+		}
+		for key, value := range resp.Tags {
+			tags[key] = value
+		}
+
+	case "secrets":
+		client := secretsmanager.NewFromConfig(awsCfg)
+		resp, err := client.DescribeSecret(ctx, &secretsmanager.DescribeSecretInput{
+			SecretId: aws.String(target.ID),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get tags: %w", err)
+		}
+		for _, tag := range resp.Tags {
+			tags[aws.ToString(tag.Key)] = aws.ToString(tag.Value)
+		}
+
+	default:
+		return nil, fmt.Errorf("tagging not supported for %s", target.Service)
+	}
+
+	return tags, nil
+}
+
+// ApplyTagChanges adds, updates and removes tags of a resource
+func ApplyTagChanges(ctx context.Context, cfg config.Config, target TagTarget, changes []TagChange) error {
+	set := make(map[string]string)
+	var remove []string
+	for _, change := range changes {
+		if change.Action == "remove" {
+			remove = append(remove, change.Key)
+		} else {
+			set[change.Key] = change.NewValue
+		}
+	}
+
+	awsCfg := GetAWSConfig(cfg).(aws.Config)
+
+	switch target.Service {
+	case "ec2":
+		client := ec2.NewFromConfig(awsCfg)
+		if len(set) > 0 {
+			var tags []ec2types.Tag
+			for key, value := range set {
+				tags = append(tags, ec2types.Tag{Key: aws.String(key), Value: aws.String(value)})
+			}
+			if _, err := client.CreateTags(ctx, &ec2.CreateTagsInput{
+				Resources: []string{target.ID},
+				Tags:      tags,
+			}); err != nil {
+				return fmt.Errorf("failed to create tags: %w", err)
+			}
+		}
+		if len(remove) > 0 {
+			var tags []ec2types.Tag
+			for _, key := range remove {
+				tags = append(tags, ec2types.Tag{Key: aws.String(key)})
+			}
+			if _, err := client.DeleteTags(ctx, &ec2.DeleteTagsInput{
+				Resources: []string{target.ID},
+				Tags:      tags,
+			}); err != nil {
+				return fmt.Errorf("failed to delete tags: %w", err)
+			}
+		}
+
+	case "ecr":
+		client := ecr.NewFromConfig(awsCfg)
+		if len(set) > 0 {
+			var tags []ecrtypes.Tag
+			for key, value := range set {
+				tags = append(tags, ecrtypes.Tag{Key: aws.String(key), Value: aws.String(value)})
+			}
+			if _, err := client.TagResource(ctx, &ecr.TagResourceInput{
+				ResourceArn: aws.String(target.ID),
+				Tags:        tags,
+			}); err != nil {
+				return fmt.Errorf("failed to tag resource: %w", err)
+			}
+		}
+		if len(remove) > 0 {
+			if _, err := client.UntagResource(ctx, &ecr.UntagResourceInput{
+				ResourceArn: aws.String(target.ID),
+				TagKeys:     remove,
+			}); err != nil {
+				return fmt.Errorf("failed to untag resource: %w", err)
+			}
+		}
+
+	case "lambda":
+		client := lambda.NewFromConfig(awsCfg)
+		if len(set) > 0 {
+			if _, err := client.TagResource(ctx, &lambda.TagResourceInput{
+				Resource: aws.String(target.ID),
+				Tags:     set,
+			}); err != nil {
+				return fmt.Errorf("failed to tag resource: %w", err)
+			}
+		}
+		if len(remove) > 0 {
+			if _, err := client.UntagResource(ctx, &lambda.UntagResourceInput{
+				Resource: aws.String(target.ID),
+				TagKeys:  remove,
+			}); err != nil {
+				return fmt.Errorf("failed to untag resource: %w", err)
+			}
+		}
+
+	case "secrets":
+		client := secretsmanager.NewFromConfig(awsCfg)
+		if len(set) > 0 {
+			var tags []smtypes.Tag
+			for key, value := range set {
+				tags = append(tags, smtypes.Tag{Key: aws.String(key), Value: aws.String(value)})
+			}
+			if _, err := client.TagResource(ctx, &secretsmanager.TagResourceInput{
+				SecretId: aws.String(target.ID),
+				Tags:     tags,
+			}); err != nil {
+				return fmt.Errorf("failed to tag resource: %w", err)
+			}
+		}
+		if len(remove) > 0 {
+			if _, err := client.UntagResource(ctx, &secretsmanager.UntagResourceInput{
+				SecretId: aws.String(target.ID),
+				TagKeys:  remove,
+			}); err != nil {
+				return fmt.Errorf("failed to untag resource: %w", err)
+			}
+		}
+
+	default:
+		return fmt.Errorf("tagging not supported for %s", target.Service)
+	}
+
+	return nil
+}
+
+// DiffTags returns the changes needed to turn the current tags into the desired ones.
+// Keys with the reserved "aws:" prefix are managed by AWS and never changed.
+func DiffTags(current, desired map[string]string) []TagChange {
+	var changes []TagChange
+	for key, value := range desired {
+		if strings.HasPrefix(key, "aws:") {
+			continue
+		}
+		old, exists := current[key]
+		if !exists {
+			changes = append(changes, TagChange{Key: key, NewValue: value, Action: "add"})
+		} else if old != value {
+			changes = append(changes, TagChange{Key: key, OldValue: old, NewValue: value, Action: "update"})
+		}
+	}
+	for key, value := range current {
+		if strings.HasPrefix(key, "aws:") {
+			continue
+		}
+		if _, exists := desired[key]; !exists {
+			changes = append(changes, TagChange{Key: key, OldValue: value, Action: "remove"})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Key < changes[j].Key
+	})
+
+	return changes
+}
+
+// MergeTagEdit applies an edit of shared tags to one resource's tags. Keys that were
+// in the shared set but are missing from the edit are removed, keys in the edit are
+// set, and tags outside the shared set are left alone.
+func MergeTagEdit(current, shared, edited map[string]string) map[string]string {
+	desired := make(map[string]string, len(current))
+	for key, value := range current {
+		desired[key] = value
+	}
+	for key := range shared {
+		if _, kept := edited[key]; !kept {
+			delete(desired, key)
+		}
+	}
+	for key, value := range edited {
+		desired[key] = value
+	}
+	return desired
+}
+
+// SharedTags returns the tags that have the same value on every resource
+func SharedTags(tagSets []map[string]string) map[string]string {
+	shared := make(map[string]string)
+	if len(tagSets) == 0 {
+		return shared
+	}
+	for key, value := range tagSets[0] {
+		shared[key] = value
+	}
+	for _, tags := range tagSets[1:] {
+		for key, value := range shared {
+			if other, ok := tags[key]; !ok || other != value {
+				delete(shared, key)
+			}
+		}
+	}
+	return shared
+}
@@ -0,0 +1,56 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffTags(t *testing.T) {
+	current := map[string]string{
+		"env":                      "dev",
+		"team":                     "web",
+		"owner":                    "alice",
+		"aws:cloudformation:stack": "web-stack",
+	}
+	desired := map[string]string{
+		"env":  "prod",
+		"team": "web",
+		"cost": "123",
+	}
+
+	changes := DiffTags(current, desired)
+
+	// Changes are sorted by key and never touch aws: tags
+	assert.Equal(t, []TagChange{
+		{Key: "cost", NewValue: "123", Action: "add"},
+		{Key: "env", OldValue: "dev", NewValue: "prod", Action: "update"},
+		{Key: "owner", OldValue: "alice", Action: "remove"},
+	}, changes)
+
+	t.Run("No changes", func(t *testing.T) {
+		assert.Empty(t, DiffTags(desired, desired))
+	})
+}
+
+func TestBulkTagEdit(t *testing.T) {
+	first := map[string]string{"env": "prod", "team": "web", "owner": "alice"}
+	second := map[string]string{"env": "prod", "team": "api"}
+
+	// Only tags with the same value everywhere are shared
+	shared := SharedTags([]map[string]string{first, second})
+	assert.Equal(t, map[string]string{"env": "prod"}, shared)
+
+	// Removing a shared tag and adding a new one keeps the other tags
+	edited := map[string]string{"cost": "123"}
+	assert.Equal(t,
+		map[string]string{"team": "web", "owner": "alice", "cost": "123"},
+		MergeTagEdit(first, shared, edited))
+	assert.Equal(t,
+		map[string]string{"team": "api", "cost": "123"},
+		MergeTagEdit(second, shared, edited))
+
+	t.Run("No resources", func(t *testing.T) {
+		assert.Empty(t, SharedTags(nil))
+	})
+}
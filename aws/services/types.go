@@ -14,29 +14,500 @@ type EC2Instance struct {
 	State     string
 	PrivateIP string
 	PublicIP  string
+	Tags      map[string]string
 }
 
 // ECRRepository represents simplified ECR repository information
 type ECRRepository struct {
 	Name       string
+	ARN        string
 	URI        string
 	ImageCount int64
 	CreatedAt  time.Time
 }
 
+// ECRImage represents simplified ECR image information
+type ECRImage struct {
+	Digest         string
+	Tags           []string
+	PushedAt       time.Time
+	SizeBytes      int64
+	ScanStatus     string
+	SeverityCounts map[string]int32
+}
+
+// ScanFinding represents a single vulnerability reported by an image scan
+type ScanFinding struct {
+	Name        string
+	Severity    string
+	Package     string
+	Version     string
+	FixedIn     string
+	Description string
+	URI         string
+}
+
+// ImageScanResult represents the findings of an image scan
+type ImageScanResult struct {
+	Status         string
+	CompletedAt    *time.Time
+	SeverityCounts map[string]int32
+	Findings       []ScanFinding
+}
+
 // LambdaFunction represents simplified Lambda function information
 type LambdaFunction struct {
 	Name         string
+	ARN          string
 	Runtime      string
 	MemorySize   int64
 	LastModified time.Time
+	Role         string // ARN of the execution role
 }
 
 // Secret represents simplified Secrets Manager secret information
 type Secret struct {
 	Name              string
+	ARN               string
 	LastModified      time.Time
 	DaysUntilRotation int64
+	Tags              map[string]string
+}
+
+// S3Bucket represents simplified S3 bucket information
+type S3Bucket struct {
+	Name       string
+	Region     string // Empty when the bucket location cannot be read
+	CreatedAt  time.Time
+	Versioning string // "Enabled", "Suspended" or "Disabled", empty when unknown
+}
+
+// S3Object represents simplified S3 object information
+type S3Object struct {
+	Key          string
+	Size         int64
+	StorageClass string
+	LastModified time.Time
+}
+
+// S3ObjectPage represents one page of the objects and sub-prefixes under a prefix
+type S3ObjectPage struct {
+	Prefixes  []string
+	Objects   []S3Object
+	NextToken string // Empty on the last page
+}
+
+// LogGroup represents simplified CloudWatch Logs log group information
+type LogGroup struct {
+	Name          string
+	ARN           string
+	RetentionDays int32 // 0 when events never expire
+	StoredBytes   int64
+	CreatedAt     time.Time
+}
+
+// LogStream represents simplified CloudWatch Logs log stream information
+type LogStream struct {
+	Name         string
+	FirstEventAt time.Time
+	LastEventAt  time.Time
+}
+
+// LogEvent represents a single event of a log stream
+type LogEvent struct {
+	Timestamp time.Time
+	Message   string
+}
+
+// LogEventPage represents a batch of log events and the token to read the events after them
+type LogEventPage struct {
+	Events    []LogEvent
+	NextToken string
+}
+
+// InsightsResult represents the state and records of a Logs Insights query
+type InsightsResult struct {
+	Status         string
+	Fields         []string // In the order they first appear in the records
+	Records        []map[string]string
+	RecordsMatched float64
+	BytesScanned   float64
+}
+
+// DynamoKey represents a key attribute of a DynamoDB table or index
+type DynamoKey struct {
+	Name string
+	Type string // "S", "N" or "B"
+}
+
+// DynamoIndex represents a secondary index of a DynamoDB table
+type DynamoIndex struct {
+	Name         string
+	PartitionKey DynamoKey
+	SortKey      DynamoKey // Empty when the index has no sort key
+}
+
+// DynamoTable represents simplified DynamoDB table information
+type DynamoTable struct {
+	Name         string
+	ARN          string
+	Status       string
+	ItemCount    int64 // Approximate, updated by DynamoDB every few hours
+	SizeBytes    int64
+	BillingMode  string
+	PartitionKey DynamoKey
+	SortKey      DynamoKey // Empty when the table has no sort key
+	Indexes      []DynamoIndex
+}
+
+// DynamoQuery represents a key condition on a table or one of its indexes
+type DynamoQuery struct {
+	Index          string // Empty to query the table itself
+	PartitionValue string
+	SortOp         string // One of DynamoSortOps, empty for no sort key condition
+	SortValue      string
+	SortValue2     string // Upper bound of "between"
+}
+
+// DynamoItemPage represents a page of items with their type annotations stripped
+type DynamoItemPage struct {
+	Items     []map[string]interface{}
+	NextToken string // Empty on the last page
+}
+
+// RDSDatabase represents a simplified RDS DB instance or DB cluster
+type RDSDatabase struct {
+	ID             string
+	ARN            string
+	Cluster        bool   // A DB cluster, such as Aurora, rather than a DB instance
+	ClusterID      string // The cluster a DB instance is a member of
+	Engine         string
+	EngineVersion  string
+	Class          string
+	Status         string
+	MultiAZ        bool
+	Endpoint       string
+	Port           int32
+	StorageGiB     int32
+	StorageType    string
+	MasterUsername string
+	DatabaseName   string
+	ParameterGroup string
+	CreatedAt      time.Time
+}
+
+// RDSSnapshot represents a simplified DB instance or DB cluster snapshot
+type RDSSnapshot struct {
+	ID         string
+	Type       string // "manual", "automated" or "awsbackup"
+	Status     string
+	StorageGiB int32
+	Encrypted  bool
+	CreatedAt  time.Time
+}
+
+// RDSParameter represents a single parameter of a DB parameter group
+type RDSParameter struct {
+	Name        string
+	Value       string
+	Source      string // "engine-default", "system" or "user"
+	ApplyType   string
+	Modifiable  bool
+	Description string
+}
+
+// RDSMaintenance represents a pending maintenance action of a DB instance or cluster
+type RDSMaintenance struct {
+	Action           string
+	Description      string
+	OptInStatus      string
+	AutoAppliedAfter *time.Time
+	ForcedApplyDate  *time.Time
+}
+
+// ECSCluster represents simplified ECS cluster information
+type ECSCluster struct {
+	Name           string
+	ARN            string
+	Status         string
+	ActiveServices int32
+	RunningTasks   int32
+	PendingTasks   int32
+}
+
+// ECSService represents simplified ECS service information
+type ECSService struct {
+	Name           string
+	ARN            string
+	Status         string
+	LaunchType     string
+	Desired        int32
+	Running        int32
+	Pending        int32
+	TaskDefinition string // "family:revision"
+	RolloutState   string // Of the primary deployment: "COMPLETED", "IN_PROGRESS" or "FAILED"
+	Deployments    int
+}
+
+// ECSTask represents simplified ECS task information
+type ECSTask struct {
+	ID                string
+	ARN               string
+	TaskDefinitionARN string
+	LastStatus        string
+	DesiredStatus     string
+	Health            string
+	LaunchType        string
+	StartedAt         time.Time
+	Containers        []ECSContainer
+}
+
+// ECSContainer represents a container of an ECS task
+type ECSContainer struct {
+	Name       string
+	LastStatus string
+	Health     string
+	RuntimeID  string // The Docker container ID
+	IPs        []string
+}
+
+// Stack represents simplified CloudFormation stack information
+type Stack struct {
+	Name         string
+	ID           string // The stack ARN
+	Status       string
+	StatusReason string
+	Description  string
+	CreatedAt    time.Time
+	UpdatedAt    time.Time // Zero when the stack was never updated
+	DriftStatus  string    // "DRIFTED", "IN_SYNC", "UNKNOWN" or "NOT_CHECKED"
+	DriftChecked time.Time // Zero when drift was never detected
+	Outputs      []StackOutput
+	Parameters   []StackParameter
+}
+
+// StackOutput represents an output of a CloudFormation stack
+type StackOutput struct {
+	Key         string
+	Value       string
+	Description string
+	ExportName  string
+}
+
+// StackParameter represents a parameter of a CloudFormation stack
+type StackParameter struct {
+	Key           string
+	Value         string
+	ResolvedValue string // The value of SSM parameter types
+}
+
+// StackEvent represents a single event of a CloudFormation stack
+type StackEvent struct {
+	ID         string
+	Timestamp  time.Time
+	LogicalID  string
+	PhysicalID string
+	Type       string
+	Status     string
+	Reason     string
+}
+
+// StackResource represents a resource of a CloudFormation stack
+type StackResource struct {
+	LogicalID   string
+	PhysicalID  string
+	Type        string
+	Status      string
+	Reason      string
+	DriftStatus string
+	UpdatedAt   time.Time
+}
+
+// StackDriftDetection represents the progress and result of a stack drift detection
+type StackDriftDetection struct {
+	Status       string // "DETECTION_IN_PROGRESS", "DETECTION_COMPLETE" or "DETECTION_FAILED"
+	DriftStatus  string
+	Reason       string
+	DriftedCount int32
+}
+
+// ResourceDrift represents a stack resource that differs from its template
+type ResourceDrift struct {
+	LogicalID   string
+	PhysicalID  string
+	Type        string
+	Status      string // "MODIFIED" or "DELETED"
+	Differences []PropertyDifference
+}
+
+// PropertyDifference represents a single property of a drifted resource
+type PropertyDifference struct {
+	Path     string
+	Type     string // "ADD", "REMOVE" or "NOT_EQUAL"
+	Expected string
+	Actual   string
+}
+
+// Queue represents simplified SQS queue information
+type Queue struct {
+	Name            string
+	URL             string
+	ARN             string
+	FIFO            bool
+	Visible         int64  // Approximate number of messages available for retrieval
+	InFlight        int64  // Approximate number of messages received but not yet deleted
+	Delayed         int64  // Approximate number of messages not yet available
+	DeadLetterARN   string // From the redrive policy, empty when the queue has no DLQ
+	MaxReceiveCount int
+	Sources         []string // Names of the queues this queue is the DLQ of
+	CreatedAt       time.Time
+}
+
+// QueueMessage represents a message read from a queue without deleting it
+type QueueMessage struct {
+	ID           string
+	Body         string
+	SentAt       time.Time
+	ReceiveCount int
+	GroupID      string            // FIFO queues only
+	Attributes   map[string]string // Message attributes with string or number values
+}
+
+// Topic represents simplified SNS topic information
+type Topic struct {
+	Name        string
+	ARN         string
+	DisplayName string
+	FIFO        bool
+	Confirmed   int
+	Pending     int    // Subscriptions pending confirmation
+	Policy      string // The access policy as JSON
+}
+
+// Subscription represents a subscription to an SNS topic
+type Subscription struct {
+	ARN      string // Empty while the subscription is pending confirmation
+	Protocol string
+	Endpoint string
+	Owner    string
+	Pending  bool
+}
+
+// Parameter represents an SSM Parameter Store parameter. Value is empty for SecureString parameters.
+type Parameter struct {
+	Name             string
+	Type             string
+	Value            string
+	Version          int64
+	Tier             string
+	DataType         string
+	Description      string
+	LastModified     time.Time
+	LastModifiedUser string
+}
+
+// ParameterFolder represents a path segment holding parameters, such as "/app/prod/"
+type ParameterFolder struct {
+	Path  string
+	Count int
+}
+
+// ParameterVersion represents a version of a parameter. Value is empty for SecureString parameters.
+type ParameterVersion struct {
+	Version      int64
+	Type         string
+	Value        string
+	Labels       []string
+	LastModified time.Time
+	User         string
+	Description  string
+}
+
+// IAMUser represents an IAM user with its access keys
+type IAMUser struct {
+	Name             string
+	ARN              string
+	Path             string
+	Created          time.Time
+	PasswordLastUsed time.Time
+	MFA              bool
+	AccessKeys       []AccessKey
+	KeysErr          error // Set when the access keys could not be read, so they are unknown
+	MFAErr           error // Set when the MFA devices could not be read, so MFA is unknown
+}
+
+// AccessKey represents an access key of an IAM user
+type AccessKey struct {
+	ID          string
+	Active      bool
+	Created     time.Time
+	LastUsed    time.Time
+	LastService string
+	LastRegion  string
+}
+
+// IAMRole represents an IAM role. TrustPolicy is indented JSON.
+type IAMRole struct {
+	Name           string
+	ARN            string
+	Path           string
+	Description    string
+	Created        time.Time
+	MaxSession     time.Duration
+	TrustPolicy    string
+	LastUsed       time.Time
+	LastUsedRegion string
+}
+
+// IAMPolicy represents a customer managed IAM policy
+type IAMPolicy struct {
+	Name           string
+	ARN            string
+	Path           string
+	Description    string
+	DefaultVersion string
+	Attachments    int
+	Updated        time.Time
+}
+
+// PolicyRef represents a policy of a user or role. Managed policies have an ARN, inline policies
+// carry their Document as indented JSON.
+type PolicyRef struct {
+	Name     string
+	ARN      string
+	Inline   bool
+	Document string
+}
+
+// EKSCluster represents an EKS cluster. CertificateAuthority is base64 encoded.
+type EKSCluster struct {
+	Name                 string
+	ARN                  string
+	Version              string
+	PlatformVersion      string
+	Status               string
+	Endpoint             string
+	CertificateAuthority string
+	PublicAccess         bool
+	PrivateAccess        bool
+	PublicCIDRs          []string
+	NodeGroups           []string
+	CreatedAt            time.Time
+}
+
+// EKSNodeGroup represents a managed node group of an EKS cluster
+type EKSNodeGroup struct {
+	Name           string
+	Status         string
+	Version        string
+	ReleaseVersion string
+	InstanceTypes  []string
+	CapacityType   string
+	AMIType        string
+	Desired        int32
+	Min            int32
+	Max            int32
+	CreatedAt      time.Time
 }
 
 // InstanceInfo represents simplified EC2 instance information
@@ -51,6 +522,79 @@ type InstanceInfo struct {
 	Tags       map[string]string
 }
 
+// InstanceDetail represents an EC2 instance together with its related resources
+type InstanceDetail struct {
+	InstanceInfo
+	ImageID            string
+	AvailabilityZone   string
+	VPCID              string
+	SubnetID           string
+	KeyName            string
+	Platform           string
+	Architecture       string
+	InstanceProfileARN string
+	SecurityGroupIDs   []string
+	NetworkInterfaces  []NetworkInterfaceInfo
+}
+
+// SecurityGroupRule represents a single resolved security group rule
+type SecurityGroupRule struct {
+	GroupID     string
+	GroupName   string
+	Direction   string
+	Protocol    string
+	Ports       string
+	Peer        string
+	Description string
+}
+
+// VolumeInfo represents simplified EBS volume information
+type VolumeInfo struct {
+	ID                  string
+	Type                string
+	State               string
+	Device              string
+	SizeGiB             int32
+	IOPS                int32
+	Encrypted           bool
+	DeleteOnTermination bool
+}
+
+// NetworkInterfaceInfo represents simplified network interface information
+type NetworkInterfaceInfo struct {
+	ID             string
+	Description    string
+	SubnetID       string
+	PrivateIP      string
+	PublicIP       string
+	MACAddress     string
+	Status         string
+	SecurityGroups []string
+}
+
+// TagTarget identifies a resource whose tags can be viewed and edited
+type TagTarget struct {
+	Service string // One of "ec2", "ecr", "lambda" or "secrets"
+	ID      string // Instance ID for EC2, ARN for the other services
+	Name    string
+}
+
+// TagChange represents a single difference between two sets of tags
+type TagChange struct {
+	Key      string
+	OldValue string
+	NewValue string
+	Action   string // One of "add", "update" or "remove"
+}
+
+// Identity represents the principal behind a set of credentials
+type Identity struct {
+	Account string
+	Alias   string
+	ARN     string
+	UserID  string
+}
+
 // RepoInfo represents simplified ECR repository information
 type RepoInfo struct {
 	Name       string
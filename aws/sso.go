@@ -0,0 +1,283 @@
+package aws
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials/ssocreds"
+	"github.com/aws/aws-sdk-go-v2/service/ssooidc"
+	ssooidctypes "github.com/aws/aws-sdk-go-v2/service/ssooidc/types"
+	"github.com/go-ini/ini"
+)
+
+// deviceCodeGrantType is the OAuth grant used to poll for a device authorization
+const deviceCodeGrantType = "urn:ietf:params:oauth:grant-type:device_code"
+
+// SSOSettings represents the IAM Identity Center settings of a profile
+type SSOSettings struct {
+	SessionName string // Empty for legacy profiles that set sso_start_url directly
+	StartURL    string
+	Region      string
+	Scopes      []string
+}
+
+// SSOToken represents a cached SSO access token in the AWS CLI format
+type SSOToken struct {
+	StartURL              string `json:"startUrl"`
+	Region                string `json:"region"`
+	AccessToken           string `json:"accessToken"`
+	ExpiresAt             string `json:"expiresAt"`
+	ClientID              string `json:"clientId,omitempty"`
+	ClientSecret          string `json:"clientSecret,omitempty"`
+	RegistrationExpiresAt string `json:"registrationExpiresAt,omitempty"`
+	RefreshToken          string `json:"refreshToken,omitempty"`
+}
+
+// DeviceAuthorization represents a pending SSO device authorization
+type DeviceAuthorization struct {
+	VerificationURI         string
+	VerificationURIComplete string
+	UserCode                string
+	ExpiresAt               time.Time
+
+	settings              *SSOSettings
+	client                *ssooidc.Client
+	clientID              string
+	clientSecret          string
+	registrationExpiresAt time.Time
+	deviceCode            string
+	interval              time.Duration
+}
+
+// LoadSSOSettings returns the SSO settings of a profile, or nil if it does not use SSO
+func LoadSSOSettings(profileName string) (*SSOSettings, error) {
+	path, err := ConfigFilePath()
+	if err != nil {
+		return nil, err
+	}
+
+	cfg, err := ini.Load(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return ssoSettingsFromConfig(cfg, profileName)
+}
+
+func ssoSettingsFromConfig(cfg *ini.File, profileName string) (*SSOSettings, error) {
+	sectionName := "profile " + profileName
+	if profileName == "default" && !cfg.HasSection(sectionName) {
+		sectionName = "default"
+	}
+	section, err := cfg.GetSection(sectionName)
+	if err != nil {
+		return nil, nil
+	}
+
+	// Profiles using an sso-session take the start URL and region from that section
+	if name := section.Key("sso_session").String(); name != "" {
+		session, err := cfg.GetSection("sso-session " + name)
+		if err != nil {
+			return nil, fmt.Errorf("sso-session %q not found for profile %s", name, profileName)
+		}
+		settings := &SSOSettings{
+			SessionName: name,
+			StartURL:    session.Key("sso_start_url").String(),
+			Region:      session.Key("sso_region").String(),
+		}
+		for _, scope := range session.Key("sso_registration_scopes").Strings(",") {
+			if scope != "" {
+				settings.Scopes = append(settings.Scopes, scope)
+			}
+		}
+		return settings, nil
+	}
+
+	if startURL := section.Key("sso_start_url").String(); startURL != "" {
+		return &SSOSettings{
+			StartURL: startURL,
+			Region:   section.Key("sso_region").String(),
+		}, nil
+	}
+
+	return nil, nil
+}
+
+// CacheKey returns the key the SDK and the AWS CLI hash to name the token cache file
+func (s *SSOSettings) CacheKey() string {
+	if s.SessionName != "" {
+		return s.SessionName
+	}
+	return s.StartURL
+}
+
+// CachePath returns the location of the cached token
+func (s *SSOSettings) CachePath() (string, error) {
+	return ssocreds.StandardCachedTokenFilepath(s.CacheKey())
+}
+
+// HasValidToken reports whether a cached token exists that has not expired
+func (s *SSOSettings) HasValidToken() bool {
+	path, err := s.CachePath()
+	if err != nil {
+		return false
+	}
+	token, err := readSSOToken(path)
+	if err != nil || token.AccessToken == "" {
+		return false
+	}
+	expiresAt, err := time.Parse(time.RFC3339, token.ExpiresAt)
+	if err != nil {
+		return false
+	}
+
+	// Leave a minute of slack so the token does not expire while loading
+	return time.Now().Add(time.Minute).Before(expiresAt)
+}
+
+// StartDeviceAuthorization registers a client and starts the device authorization flow
+func (s *SSOSettings) StartDeviceAuthorization(ctx context.Context) (*DeviceAuthorization, error) {
+	cfg, err := config.LoadDefaultConfig(ctx,
+		config.WithRegion(s.Region),
+		config.WithCredentialsProvider(aws.AnonymousCredentials{}),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config for SSO: %w", err)
+	}
+	client := ssooidc.NewFromConfig(cfg)
+
+	registration, err := client.RegisterClient(ctx, &ssooidc.RegisterClientInput{
+		ClientName: aws.String("awstui"),
+		ClientType: aws.String("public"),
+		Scopes:     s.Scopes,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to register SSO client: %w", err)
+	}
+
+	auth, err := client.StartDeviceAuthorization(ctx, &ssooidc.StartDeviceAuthorizationInput{
+		ClientId:     registration.ClientId,
+		ClientSecret: registration.ClientSecret,
+		StartUrl:     aws.String(s.StartURL),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to start device authorization: %w", err)
+	}
+
+	interval := time.Duration(auth.Interval) * time.Second
+	if interval <= 0 {
+		interval = 5 * time.Second
+	}
+
+	return &DeviceAuthorization{
+		VerificationURI:         aws.ToString(auth.VerificationUri),
+		VerificationURIComplete: aws.ToString(auth.VerificationUriComplete),
+		UserCode:                aws.ToString(auth.UserCode),
+		ExpiresAt:               time.Now().Add(time.Duration(auth.ExpiresIn) * time.Second),
+		settings:                s,
+		client:                  client,
+		clientID:                aws.ToString(registration.ClientId),
+		clientSecret:            aws.ToString(registration.ClientSecret),
+		registrationExpiresAt:   time.Unix(registration.ClientSecretExpiresAt, 0),
+		deviceCode:              aws.ToString(auth.DeviceCode),
+		interval:                interval,
+	}, nil
+}
+
+// Wait polls until the user approves the device, then writes the token cache
+func (d *DeviceAuthorization) Wait(ctx context.Context) error {
+	interval := d.interval
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(interval):
+		}
+		if time.Now().After(d.ExpiresAt) {
+			return errors.New("device authorization expired, please try again")
+		}
+
+		resp, err := d.client.CreateToken(ctx, &ssooidc.CreateTokenInput{
+			ClientId:     aws.String(d.clientID),
+			ClientSecret: aws.String(d.clientSecret),
+			GrantType:    aws.String(deviceCodeGrantType),
+			DeviceCode:   aws.String(d.deviceCode),
+		})
+		var pending *ssooidctypes.AuthorizationPendingException
+		var slowDown *ssooidctypes.SlowDownException
+		switch {
+		case errors.As(err, &pending):
+			continue
+		case errors.As(err, &slowDown):
+			interval += 5 * time.Second
+			continue
+		case err != nil:
+			return fmt.Errorf("failed to create SSO token: %w", err)
+		}
+
+		token := SSOToken{
+			StartURL:     d.settings.StartURL,
+			Region:       d.settings.Region,
+			AccessToken:  aws.ToString(resp.AccessToken),
+			ExpiresAt:    formatTokenTime(time.Now().Add(time.Duration(resp.ExpiresIn) * time.Second)),
+			RefreshToken: aws.ToString(resp.RefreshToken),
+		}
+
+		// Only sso-session tokens can be refreshed, so only they keep the client registration
+		if d.settings.SessionName != "" {
+			token.ClientID = d.clientID
+			token.ClientSecret = d.clientSecret
+			token.RegistrationExpiresAt = formatTokenTime(d.registrationExpiresAt)
+		} else {
+			token.RefreshToken = ""
+		}
+
+		path, err := d.settings.CachePath()
+		if err != nil {
+			return err
+		}
+		return writeSSOToken(path, token)
+	}
+}
+
+func readSSOToken(path string) (*SSOToken, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var token SSOToken
+	if err := json.Unmarshal(data, &token); err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+// writeSSOToken writes the token cache with the same permissions as the AWS CLI
+func writeSSOToken(path string, token SSOToken) error {
+	data, err := json.Marshal(token)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("failed to create SSO cache directory: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write SSO token cache: %w", err)
+	}
+	return nil
+}
+
+// formatTokenTime formats a time the way the SDK parses the token cache
+func formatTokenTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
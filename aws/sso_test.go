@@ -0,0 +1,95 @@
+package aws
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadSSOSettings(t *testing.T) {
+	tmpDir := t.TempDir()
+	awsDir := filepath.Join(tmpDir, ".aws")
+	os.MkdirAll(awsDir, 0755)
+
+	configContent := `[profile dev]
+sso_session = corp
+sso_account_id = 123456789012
+sso_role_name = Developer
+
+[profile legacy]
+sso_start_url = https://legacy.awsapps.com/start
+sso_region = eu-west-1
+
+[profile static]
+region = us-east-1
+
+[sso-session corp]
+sso_start_url = https://corp.awsapps.com/start
+sso_region = us-east-1
+sso_registration_scopes = sso:account:access
+`
+	err := os.WriteFile(filepath.Join(awsDir, "config"), []byte(configContent), 0644)
+	assert.NoError(t, err)
+
+	originalHome := os.Getenv("HOME")
+	os.Setenv("HOME", tmpDir)
+	defer os.Setenv("HOME", originalHome)
+
+	t.Run("SSO session", func(t *testing.T) {
+		settings, err := LoadSSOSettings("dev")
+		assert.NoError(t, err)
+		assert.Equal(t, &SSOSettings{
+			SessionName: "corp",
+			StartURL:    "https://corp.awsapps.com/start",
+			Region:      "us-east-1",
+			Scopes:      []string{"sso:account:access"},
+		}, settings)
+		assert.Equal(t, "corp", settings.CacheKey())
+	})
+
+	t.Run("Legacy SSO", func(t *testing.T) {
+		settings, err := LoadSSOSettings("legacy")
+		assert.NoError(t, err)
+		assert.Equal(t, "https://legacy.awsapps.com/start", settings.CacheKey())
+		assert.Equal(t, "eu-west-1", settings.Region)
+	})
+
+	t.Run("Not SSO", func(t *testing.T) {
+		settings, err := LoadSSOSettings("static")
+		assert.NoError(t, err)
+		assert.Nil(t, settings)
+	})
+
+	t.Run("Token cache", func(t *testing.T) {
+		settings, err := LoadSSOSettings("dev")
+		assert.NoError(t, err)
+		assert.False(t, settings.HasValidToken())
+
+		path, err := settings.CachePath()
+		assert.NoError(t, err)
+		assert.Equal(t, filepath.Join(awsDir, "sso", "cache"), filepath.Dir(path))
+
+		// Expired token
+		err = writeSSOToken(path, SSOToken{
+			StartURL:    settings.StartURL,
+			Region:      settings.Region,
+			AccessToken: "token",
+			ExpiresAt:   formatTokenTime(time.Now().Add(-time.Hour)),
+		})
+		assert.NoError(t, err)
+		assert.False(t, settings.HasValidToken())
+
+		// Valid token
+		err = writeSSOToken(path, SSOToken{
+			StartURL:    settings.StartURL,
+			Region:      settings.Region,
+			AccessToken: "token",
+			ExpiresAt:   formatTokenTime(time.Now().Add(time.Hour)),
+		})
+		assert.NoError(t, err)
+		assert.True(t, settings.HasValidToken())
+	})
+}
@@ -0,0 +1,56 @@
+package clipboard
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// ErrUnavailable is returned when no clipboard tool is installed
+var ErrUnavailable = errors.New("no clipboard tool found (install pbcopy, wl-copy, xclip or xsel)")
+
+// Copy writes text to the system clipboard
+func Copy(text string) error {
+	name, args, err := command()
+	if err != nil {
+		return err
+	}
+
+	cmd := exec.Command(name, args...)
+	cmd.Stdin = strings.NewReader(text)
+	return cmd.Run()
+}
+
+// tool represents a clipboard command and its arguments
+type tool struct {
+	name string
+	args []string
+}
+
+// command returns the clipboard tool for the current platform
+func command() (string, []string, error) {
+	switch runtime.GOOS {
+	case "darwin":
+		return "pbcopy", nil, nil
+	case "windows":
+		return "clip", nil, nil
+	}
+
+	var candidates []tool
+	if os.Getenv("WAYLAND_DISPLAY") != "" {
+		candidates = append(candidates, tool{"wl-copy", nil})
+	}
+	candidates = append(candidates,
+		tool{"xclip", []string{"-selection", "clipboard"}},
+		tool{"xsel", []string{"--clipboard", "--input"}},
+	)
+
+	for _, c := range candidates {
+		if _, err := exec.LookPath(c.name); err == nil {
+			return c.name, c.args, nil
+		}
+	}
+	return "", nil, ErrUnavailable
+}
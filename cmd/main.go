@@ -1,10 +1,14 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"os"
+	"path/filepath"
 
+	"github.com/Ninad-Bhangui/awstui/audit"
 	"github.com/Ninad-Bhangui/awstui/aws"
+	"github.com/Ninad-Bhangui/awstui/settings"
 	"github.com/Ninad-Bhangui/awstui/ui"
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/gdamore/tcell/v2"
@@ -12,47 +16,82 @@ import (
 )
 
 func main() {
+	readOnly := flag.Bool("readonly", false, "disable every action that changes AWS resources")
+	flag.Parse()
+
+	// Load settings
+	appSettings, err := settings.Load()
+	if err != nil {
+		fmt.Printf("Error loading settings: %v\n", err)
+		os.Exit(1)
+	}
+	appSettings.ReadOnly = appSettings.ReadOnly || *readOnly
+
+	// Set up audit log
+	stateDir, err := settings.StateDir()
+	if err != nil {
+		fmt.Printf("Error finding state directory: %v\n", err)
+		os.Exit(1)
+	}
+	auditLog := audit.NewLogger(filepath.Join(stateDir, "audit.jsonl"))
+
+	// Load favorite and recent profiles and the query history
+	state, err := settings.LoadState()
+	if err != nil {
+		fmt.Printf("Error loading state: %v\n", err)
+		os.Exit(1)
+	}
+
 	app := tview.NewApplication()
-	layout := ui.NewLayout(app)
 	pages := tview.NewPages()
+	layout := ui.NewLayout(app, pages)
 
-	var currentConfig config.Config
+	var session *ui.Session
 
 	// Create profile selector
-	profileSelector := ui.NewProfileSelector(func(profile aws.Profile, cfg config.Config) {
-		// Store config for later use
-		currentConfig = cfg
+	profileSelector := ui.NewProfileSelector(layout, appSettings, state, func(profile aws.Profile, cfg config.Config) {
+		// Store session for later use
+		session = ui.NewSession(layout, profile, cfg, appSettings, state, auditLog)
 
 		// Create home screen
-		homeScreen := ui.NewHomeScreen(func(service string) {
-			showResourceList(app, layout, pages, service, currentConfig)
+		homeScreen := ui.NewHomeScreen(session, func(service string) {
+			showResourceList(session, service)
 		})
 
 		// Update layout
-		layout.SetContent(homeScreen)
-		layout.SetContext(fmt.Sprintf("Profile: %s", profile.Name))
-		layout.SetKeybindings("<?> Help • <:> Quick Nav • <q> Back")
+		layout.ResetViews()
+		layout.SetProfile(profile.Name)
+		layout.SetReadOnly(session.ReadOnly())
+		layout.SetProtected(session.Protected())
+		layout.Push(homeScreen)
 		layout.SetStatus("Ready")
 	})
 
+	showProfileSelector := func() {
+		layout.ResetViews()
+		layout.SetProtected(false)
+		layout.SetContent(profileSelector)
+		layout.SetContext("Select AWS Profile")
+		layout.SetKeybindings("<?> Help • </> Filter • <f> Favorite • <g> Group • <a> Add • <e> Edit • <d> Delete")
+	}
+
 	// Load profiles
 	if err := profileSelector.LoadProfiles(); err != nil {
 		fmt.Printf("Error loading profiles: %v\n", err)
 		os.Exit(1)
 	}
 
-	// Set up initial screen
-	layout.SetContent(profileSelector)
-	layout.SetContext("Select AWS Profile")
-	layout.SetKeybindings("<?> Help • <q> Quit")
+	// Set up initial screen, going straight to the default chain without profile files
+	showProfileSelector()
+	profileSelector.AutoSelect()
 
 	// Set up pages
 	pages.AddPage("main", layout, true, true)
 
 	// Set up input capture for quick navigation
 	app.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
-		// Let layout handle its own keys first
-		if layout.GetContent() == profileSelector {
+		// Let layout, modals and text inputs handle their own keys first
+		if layout.GetContent() == profileSelector || layout.HasModal() || layout.IsTyping() {
 			return event
 		}
 
@@ -61,19 +100,16 @@ func main() {
 			switch event.Rune() {
 			case ':':
 				// Show resource prompt
-				var resourceType string
 				var modal *tview.InputField
 				modal = tview.NewInputField().
 					SetLabel(":").
 					SetFieldWidth(20).
 					SetDoneFunc(func(key tcell.Key) {
 						if key == tcell.KeyEnter {
-							resourceType = modal.GetText()
-							pages.RemovePage("modal")
-							showResourceList(app, layout, pages, resourceType, currentConfig)
+							layout.CloseModal("modal")
+							showResourceList(session, modal.GetText())
 						} else if key == tcell.KeyEscape {
-							pages.RemovePage("modal")
-							app.SetFocus(layout.GetContent())
+							layout.CloseModal("modal")
 						}
 					})
 
@@ -81,29 +117,19 @@ func main() {
 				modal.SetTitle("Quick Navigation")
 				modal.SetTitleAlign(tview.AlignLeft)
 
-				pages.AddPage("modal", modal, true, true)
-				app.SetFocus(modal)
+				layout.ShowModal("modal", modal)
 				return nil
 			case 'q':
-				if layout.GetContent() != profileSelector {
-					// Return to profile selector
-					layout.SetContent(profileSelector)
-					layout.SetContext("Select AWS Profile")
-					layout.SetKeybindings("<?> Help • <q> Quit")
-					return nil
+				// Go back one view, then to the profile selector
+				if !layout.Pop() {
+					showProfileSelector()
 				}
-				app.Stop()
 				return nil
 			}
 		case tcell.KeyEscape:
-			if layout.GetContent() != profileSelector {
-				// Return to profile selector
-				layout.SetContent(profileSelector)
-				layout.SetContext("Select AWS Profile")
-				layout.SetKeybindings("<?> Help • <q> Quit")
-				return nil
+			if !layout.Pop() {
+				showProfileSelector()
 			}
-			app.Stop()
 			return nil
 		}
 		return event
@@ -115,25 +141,23 @@ func main() {
 	}
 }
 
-func showResourceList(app *tview.Application, layout *ui.Layout, pages *tview.Pages, resourceType string, cfg config.Config) {
+func showResourceList(session *ui.Session, resourceType string) {
 	switch resourceType {
-	case "ec2", "ecr", "lambda", "secrets":
-		list := ui.NewResourceList(resourceType, cfg)
-		layout.SetContent(list)
-		layout.SetContext(fmt.Sprintf("Viewing %s", list.GetTitle()))
-		layout.SetKeybindings("<?> Help • <:> Quick Nav • <q> Back")
-		app.SetFocus(list)
+	case "ec2", "ecr", "lambda", "secrets", "s3", "logs", "dynamodb", "rds", "ecs", "cfn", "sqs", "sns", "eks":
+		list := ui.NewResourceList(resourceType, session)
+		session.Layout.PopToRoot()
+		session.Layout.Push(list)
+	case "ssm":
+		session.Layout.PopToRoot()
+		session.Layout.Push(ui.NewParameterList(session, "/"))
+	case "iam":
+		session.Layout.PopToRoot()
+		session.Layout.Push(ui.NewIAMPage(session))
+	case "audit":
+		session.Layout.PopToRoot()
+		session.Layout.Push(ui.NewAuditView(session))
 	default:
 		// Show error modal
-		modal := tview.NewModal().
-			SetText(fmt.Sprintf("Unknown resource type: %s", resourceType)).
-			AddButtons([]string{"OK"}).
-			SetDoneFunc(func(buttonIndex int, buttonLabel string) {
-				pages.RemovePage("error")
-				app.SetFocus(layout.GetContent())
-			})
-
-		pages.AddPage("error", modal, true, true)
-		app.SetFocus(modal)
+		session.Layout.ShowError(fmt.Errorf("unknown resource type: %s", resourceType))
 	}
 }
@@ -0,0 +1,163 @@
+package export
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"strings"
+	"time"
+)
+
+// Format represents an export file format
+type Format string
+
+const (
+	CSV      Format = "csv"
+	JSONL    Format = "jsonl"
+	Markdown Format = "md"
+	HTML     Format = "html"
+)
+
+// Formats lists the supported formats in display order
+var Formats = []Format{CSV, JSONL, Markdown, HTML}
+
+// Header describes where an export came from
+type Header struct {
+	Profile string
+	Region  string
+	Time    time.Time
+}
+
+// Table represents the rows to export with their column titles
+type Table struct {
+	Title   string
+	Columns []string
+	Rows    [][]string
+}
+
+// String returns a one-line description of the header
+func (h Header) String() string {
+	return fmt.Sprintf("profile: %s, region: %s, exported: %s", h.Profile, h.Region, h.Time.UTC().Format(time.RFC3339))
+}
+
+// Write writes the table in the given format
+func Write(w io.Writer, format Format, header Header, table Table) error {
+	switch format {
+	case CSV:
+		return writeCSV(w, table)
+	case JSONL:
+		return writeJSONL(w, header, table)
+	case Markdown:
+		return writeMarkdown(w, header, table)
+	case HTML:
+		return writeHTML(w, header, table)
+	default:
+		return fmt.Errorf("unsupported export format: %s", format)
+	}
+}
+
+// writeCSV writes the column titles and rows only. CSV has no comments, so a header line would be
+// read as a data row by spreadsheets and CSV parsers.
+func writeCSV(w io.Writer, table Table) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(table.Columns); err != nil {
+		return err
+	}
+	if err := writer.WriteAll(table.Rows); err != nil {
+		return err
+	}
+	return writer.Error()
+}
+
+// writeJSONL writes one object per row, preceded by a metadata line since JSON has no comments
+func writeJSONL(w io.Writer, header Header, table Table) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetEscapeHTML(false)
+
+	meta := map[string]map[string]string{
+		"_export": {
+			"title":    table.Title,
+			"profile":  header.Profile,
+			"region":   header.Region,
+			"exported": header.Time.UTC().Format(time.RFC3339),
+		},
+	}
+	if err := encoder.Encode(meta); err != nil {
+		return err
+	}
+
+	for _, row := range table.Rows {
+		record := make(map[string]string, len(table.Columns))
+		for i, col := range table.Columns {
+			if i < len(row) {
+				record[col] = row[i]
+			}
+		}
+		if err := encoder.Encode(record); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeMarkdown(w io.Writer, header Header, table Table) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "<!-- %s: %s -->\n\n", table.Title, header)
+
+	escape := strings.NewReplacer("|", `\|`, "\n", " ")
+	b.WriteString("|")
+	for _, col := range table.Columns {
+		b.WriteString(" " + escape.Replace(col) + " |")
+	}
+	b.WriteString("\n|")
+	for range table.Columns {
+		b.WriteString(" --- |")
+	}
+	b.WriteString("\n")
+	for _, row := range table.Rows {
+		b.WriteString("|")
+		for _, cell := range row {
+			b.WriteString(" " + escape.Replace(cell) + " |")
+		}
+		b.WriteString("\n")
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func writeHTML(w io.Writer, header Header, table Table) error {
+	var b strings.Builder
+	title := html.EscapeString(table.Title)
+
+	b.WriteString("<!DOCTYPE html>\n")
+	fmt.Fprintf(&b, "<!-- %s -->\n", html.EscapeString(header.String()))
+	b.WriteString("<html>\n<head>\n<meta charset=\"utf-8\">\n")
+	fmt.Fprintf(&b, "<title>%s</title>\n", title)
+	b.WriteString("<style>\n")
+	b.WriteString("body { font-family: sans-serif; }\n")
+	b.WriteString("table { border-collapse: collapse; }\n")
+	b.WriteString("th, td { border: 1px solid #ccc; padding: 4px 8px; text-align: left; }\n")
+	b.WriteString("th { background: #f0f0f0; }\n")
+	b.WriteString("</style>\n</head>\n<body>\n")
+	fmt.Fprintf(&b, "<h1>%s</h1>\n", title)
+	fmt.Fprintf(&b, "<p>%s</p>\n", html.EscapeString(header.String()))
+	b.WriteString("<table>\n<thead>\n<tr>")
+	for _, col := range table.Columns {
+		fmt.Fprintf(&b, "<th>%s</th>", html.EscapeString(col))
+	}
+	b.WriteString("</tr>\n</thead>\n<tbody>\n")
+	for _, row := range table.Rows {
+		b.WriteString("<tr>")
+		for _, cell := range row {
+			fmt.Fprintf(&b, "<td>%s</td>", html.EscapeString(cell))
+		}
+		b.WriteString("</tr>\n")
+	}
+	b.WriteString("</tbody>\n</table>\n</body>\n</html>\n")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
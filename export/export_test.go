@@ -0,0 +1,71 @@
+package export
+
+import (
+	"encoding/csv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWrite(t *testing.T) {
+	header := Header{
+		Profile: "dev",
+		Region:  "eu-west-1",
+		Time:    time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC),
+	}
+	table := Table{
+		Title:   "EC2 Instances",
+		Columns: []string{"ID", "Name"},
+		Rows: [][]string{
+			{"i-1", "web, primary"},
+			{"i-2", "a|b <c>"},
+		},
+	}
+
+	t.Run("CSV", func(t *testing.T) {
+		var b strings.Builder
+		assert.NoError(t, Write(&b, CSV, header, table))
+		assert.Equal(t,
+			"ID,Name\n"+
+				"i-1,\"web, primary\"\n"+
+				"i-2,a|b <c>\n",
+			b.String())
+
+		// Every record has the fields of the header row
+		records, err := csv.NewReader(strings.NewReader(b.String())).ReadAll()
+		assert.NoError(t, err)
+		assert.Len(t, records, 3)
+	})
+
+	t.Run("JSON lines", func(t *testing.T) {
+		var b strings.Builder
+		assert.NoError(t, Write(&b, JSONL, header, table))
+		lines := strings.Split(strings.TrimSpace(b.String()), "\n")
+		assert.Len(t, lines, 3)
+		assert.Equal(t, `{"_export":{"exported":"2024-01-15T10:30:00Z","profile":"dev","region":"eu-west-1","title":"EC2 Instances"}}`, lines[0])
+		assert.Equal(t, `{"ID":"i-2","Name":"a|b <c>"}`, lines[2])
+	})
+
+	t.Run("Markdown", func(t *testing.T) {
+		var b strings.Builder
+		assert.NoError(t, Write(&b, Markdown, header, table))
+		assert.Contains(t, b.String(), "<!-- EC2 Instances: profile: dev")
+		assert.Contains(t, b.String(), "| ID | Name |\n| --- | --- |\n")
+		assert.Contains(t, b.String(), `| i-2 | a\|b <c> |`)
+	})
+
+	t.Run("HTML", func(t *testing.T) {
+		var b strings.Builder
+		assert.NoError(t, Write(&b, HTML, header, table))
+		assert.Contains(t, b.String(), "<th>ID</th><th>Name</th>")
+		assert.Contains(t, b.String(), "<td>a|b &lt;c&gt;</td>")
+		assert.Contains(t, b.String(), "region: eu-west-1")
+	})
+
+	t.Run("Unknown format", func(t *testing.T) {
+		var b strings.Builder
+		assert.Error(t, Write(&b, Format("xml"), header, table))
+	})
+}
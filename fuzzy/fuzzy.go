@@ -0,0 +1,64 @@
+// Package fuzzy matches short patterns against names the way fuzzy finders do
+package fuzzy
+
+import (
+	"strings"
+	"unicode"
+)
+
+const (
+	matchScore       = 1
+	consecutiveBonus = 5
+	boundaryBonus    = 8
+	firstCharBonus   = 10
+	gapPenalty       = 1
+	maxGapPenalty    = 5
+)
+
+// Match reports whether every character of pattern appears in text in order, ignoring case.
+// Higher scores mean better matches: consecutive characters and characters at the start of
+// words count more, gaps between matched characters count less.
+func Match(pattern, text string) (int, bool) {
+	needle := []rune(strings.ToLower(pattern))
+	haystack := []rune(strings.ToLower(text))
+	if len(needle) == 0 {
+		return 0, true
+	}
+
+	score, n, last := 0, 0, -1
+	for i, r := range haystack {
+		if n == len(needle) {
+			break
+		}
+		if r != needle[n] {
+			continue
+		}
+
+		score += matchScore
+		switch {
+		case i == 0:
+			score += firstCharBonus
+		case isBoundary(haystack[i-1]):
+			score += boundaryBonus
+		}
+		if last >= 0 {
+			if i == last+1 {
+				score += consecutiveBonus
+			} else {
+				score -= min(i-last-1, maxGapPenalty) * gapPenalty
+			}
+		}
+		last = i
+		n++
+	}
+
+	if n < len(needle) {
+		return 0, false
+	}
+	return score, true
+}
+
+// isBoundary reports whether a character separates words in names like "prod-eu_admin"
+func isBoundary(r rune) bool {
+	return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+}
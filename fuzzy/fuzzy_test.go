@@ -0,0 +1,35 @@
+package fuzzy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatch(t *testing.T) {
+	t.Run("Subsequence", func(t *testing.T) {
+		_, ok := Match("pda", "prod-admin")
+		assert.True(t, ok)
+		_, ok = Match("PRAD", "prod-admin")
+		assert.True(t, ok)
+		_, ok = Match("adp", "prod-admin")
+		assert.False(t, ok)
+		_, ok = Match("", "anything")
+		assert.True(t, ok)
+	})
+
+	t.Run("Ranking", func(t *testing.T) {
+		prefix, _ := Match("dev", "dev-admin")
+		boundary, _ := Match("dev", "team-dev")
+		scattered, _ := Match("dev", "dxxexxv")
+		inside, _ := Match("dev", "xdevx")
+		assert.Greater(t, prefix, boundary)
+		assert.Greater(t, boundary, inside)
+		assert.Greater(t, inside, scattered)
+
+		// Word starts beat a tighter match in the middle of a word
+		initials, _ := Match("pa", "prod-admin")
+		middle, _ := Match("pa", "xxpax")
+		assert.Greater(t, initials, middle)
+	})
+}
@@ -0,0 +1,165 @@
+package settings
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-ini/ini"
+)
+
+// Settings represents the awstui configuration file
+type Settings struct {
+	// ReadOnly disables every action that changes AWS resources
+	ReadOnly bool
+	// ProtectedPatterns are shell-style name patterns such as "prod*" that mark profiles as protected
+	ProtectedPatterns []string
+	// Profiles holds the per-profile settings keyed by profile name
+	Profiles map[string]ProfileSettings
+	// Environments label profiles by name pattern, checked in file order
+	Environments []EnvironmentRule
+	// AccountGroupDigits is how many leading digits of the account ID group profiles, 0 for all of them
+	AccountGroupDigits int
+	// AccessKeyMaxAgeDays is the age in days from which IAM access keys are flagged for rotation
+	AccessKeyMaxAgeDays int
+}
+
+// DefaultAccessKeyMaxAgeDays is the access key age flagged when the settings file sets none
+const DefaultAccessKeyMaxAgeDays = 90
+
+// ProfileSettings represents the settings of a single profile
+type ProfileSettings struct {
+	Protected   bool
+	Environment string
+}
+
+// EnvironmentRule represents a "label = patterns" line of the [environments] section
+type EnvironmentRule struct {
+	Label    string
+	Patterns []string
+}
+
+// Path returns the location of the configuration file
+func Path() (string, error) {
+	if path := os.Getenv("AWSTUI_CONFIG"); path != "" {
+		return path, nil
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "awstui", "config"), nil
+}
+
+// StateDir returns the directory for state such as the audit log, following XDG_STATE_HOME
+func StateDir() (string, error) {
+	if dir := os.Getenv("XDG_STATE_HOME"); dir != "" {
+		return filepath.Join(dir, "awstui"), nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".local", "state", "awstui"), nil
+}
+
+// Load reads the configuration file, returning defaults when it does not exist
+func Load() (*Settings, error) {
+	path, err := Path()
+	if err != nil {
+		return nil, err
+	}
+	return LoadFile(path)
+}
+
+// LoadFile reads settings from a specific file
+func LoadFile(path string) (*Settings, error) {
+	settings := &Settings{
+		Profiles:            make(map[string]ProfileSettings),
+		AccessKeyMaxAgeDays: DefaultAccessKeyMaxAgeDays,
+	}
+
+	cfg, err := ini.Load(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return settings, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load settings file %s: %w", path, err)
+	}
+
+	// Global settings live in the unnamed section
+	global := cfg.Section(ini.DefaultSection)
+	settings.ReadOnly = global.Key("readonly").MustBool(false)
+	for _, pattern := range global.Key("protected_profiles").Strings(",") {
+		if pattern != "" {
+			settings.ProtectedPatterns = append(settings.ProtectedPatterns, pattern)
+		}
+	}
+	settings.AccountGroupDigits = global.Key("account_group_digits").MustInt(0)
+	settings.AccessKeyMaxAgeDays = global.Key("access_key_max_age_days").MustInt(DefaultAccessKeyMaxAgeDays)
+
+	// Environment labels such as "prod = prod*, *-live" group the profile selector
+	if section, err := cfg.GetSection("environments"); err == nil {
+		for _, key := range section.Keys() {
+			rule := EnvironmentRule{Label: key.Name()}
+			for _, pattern := range key.Strings(",") {
+				if pattern != "" {
+					rule.Patterns = append(rule.Patterns, pattern)
+				}
+			}
+			settings.Environments = append(settings.Environments, rule)
+		}
+	}
+
+	// Profile settings use the same "[profile name]" sections as the AWS config file
+	for _, section := range cfg.Sections() {
+		name, ok := strings.CutPrefix(section.Name(), "profile ")
+		if !ok {
+			continue
+		}
+		settings.Profiles[strings.TrimSpace(name)] = ProfileSettings{
+			Protected:   section.Key("protected").MustBool(false),
+			Environment: section.Key("environment").String(),
+		}
+	}
+
+	return settings, nil
+}
+
+// IsProtected reports whether changes to a profile need an explicit confirmation
+func (s *Settings) IsProtected(profile string) bool {
+	if s.Profiles[profile].Protected {
+		return true
+	}
+	for _, pattern := range s.ProtectedPatterns {
+		if matched, err := filepath.Match(pattern, profile); err == nil && matched {
+			return true
+		}
+	}
+	return false
+}
+
+// Environment returns the environment label of a profile, or an empty string if none applies
+func (s *Settings) Environment(profile string) string {
+	if label := s.Profiles[profile].Environment; label != "" {
+		return label
+	}
+	for _, rule := range s.Environments {
+		for _, pattern := range rule.Patterns {
+			if matched, err := filepath.Match(pattern, profile); err == nil && matched {
+				return rule.Label
+			}
+		}
+	}
+	return ""
+}
+
+// AccountGroup returns the part of an account ID that profiles are grouped by
+func (s *Settings) AccountGroup(accountID string) string {
+	if s.AccountGroupDigits > 0 && s.AccountGroupDigits < len(accountID) {
+		return accountID[:s.AccountGroupDigits]
+	}
+	return accountID
+}
@@ -0,0 +1,135 @@
+package settings
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadFile(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	content := `readonly = true
+protected_profiles = prod*, *-live
+account_group_digits = 4
+access_key_max_age_days = 30
+
+[environments]
+prod = prod*, *-live
+dev = dev*, sandbox
+
+[profile billing]
+protected: true
+environment = finance
+
+[profile dev]
+protected = false
+`
+	path := filepath.Join(tmpDir, "config")
+	err := os.WriteFile(path, []byte(content), 0644)
+	assert.NoError(t, err)
+
+	settings, err := LoadFile(path)
+	assert.NoError(t, err)
+	assert.True(t, settings.ReadOnly)
+	assert.Equal(t, []string{"prod*", "*-live"}, settings.ProtectedPatterns)
+	assert.Equal(t, 30, settings.AccessKeyMaxAgeDays)
+
+	t.Run("Protected profiles", func(t *testing.T) {
+		assert.True(t, settings.IsProtected("billing"))
+		assert.True(t, settings.IsProtected("prod"))
+		assert.True(t, settings.IsProtected("prod-admin"))
+		assert.True(t, settings.IsProtected("shop-live"))
+		assert.False(t, settings.IsProtected("dev"))
+		assert.False(t, settings.IsProtected("staging"))
+	})
+
+	t.Run("Environments", func(t *testing.T) {
+		assert.Equal(t, "finance", settings.Environment("billing"))
+		assert.Equal(t, "prod", settings.Environment("prod-admin"))
+		assert.Equal(t, "prod", settings.Environment("shop-live"))
+		assert.Equal(t, "dev", settings.Environment("dev"))
+		assert.Equal(t, "dev", settings.Environment("sandbox"))
+		assert.Equal(t, "", settings.Environment("staging"))
+	})
+
+	t.Run("Account groups", func(t *testing.T) {
+		assert.Equal(t, "1234", settings.AccountGroup("123456789012"))
+		assert.Equal(t, "", settings.AccountGroup(""))
+
+		settings.AccountGroupDigits = 0
+		assert.Equal(t, "123456789012", settings.AccountGroup("123456789012"))
+	})
+
+	t.Run("Missing file", func(t *testing.T) {
+		settings, err := LoadFile(filepath.Join(tmpDir, "missing"))
+		assert.NoError(t, err)
+		assert.False(t, settings.ReadOnly)
+		assert.False(t, settings.IsProtected("prod"))
+		assert.Equal(t, DefaultAccessKeyMaxAgeDays, settings.AccessKeyMaxAgeDays)
+	})
+}
+
+func TestState(t *testing.T) {
+	tmpDir := t.TempDir()
+	path := filepath.Join(tmpDir, "state", "state.json")
+
+	state, err := LoadStateFile(path)
+	assert.NoError(t, err)
+	assert.Empty(t, state.Favorites)
+	assert.Equal(t, -1, state.RecentRank("dev"))
+
+	t.Run("Favorites", func(t *testing.T) {
+		assert.True(t, state.ToggleFavorite("prod"))
+		assert.True(t, state.ToggleFavorite("dev"))
+		assert.False(t, state.ToggleFavorite("prod"))
+		assert.True(t, state.IsFavorite("dev"))
+		assert.False(t, state.IsFavorite("prod"))
+	})
+
+	t.Run("Recently used", func(t *testing.T) {
+		state.UseProfile("a")
+		state.UseProfile("b")
+		state.UseProfile("a")
+		assert.Equal(t, []string{"a", "b"}, state.Recent)
+		assert.Equal(t, 0, state.RecentRank("a"))
+		assert.Equal(t, 1, state.RecentRank("b"))
+
+		for i := 0; i < 30; i++ {
+			state.UseProfile(string(rune('c' + i)))
+		}
+		assert.Len(t, state.Recent, maxRecent)
+	})
+
+	t.Run("Query history", func(t *testing.T) {
+		assert.Empty(t, state.QueryHistory("prod"))
+
+		state.AddQuery("prod", "fields @message")
+		state.AddQuery("prod", "stats count(*)")
+		state.AddQuery("prod", "fields @message")
+		state.AddQuery("dev", "fields @timestamp")
+		assert.Equal(t, []string{"fields @message", "stats count(*)"}, state.QueryHistory("prod"))
+		assert.Equal(t, []string{"fields @timestamp"}, state.QueryHistory("dev"))
+
+		for i := 0; i < 60; i++ {
+			state.AddQuery("dev", fmt.Sprintf("limit %d", i))
+		}
+		assert.Len(t, state.QueryHistory("dev"), maxQueries)
+	})
+
+	t.Run("Save and load", func(t *testing.T) {
+		state.GroupBy = "environment"
+		err := state.Save()
+		assert.NoError(t, err)
+
+		loaded, err := LoadStateFile(path)
+		assert.NoError(t, err)
+		assert.Equal(t, state.Favorites, loaded.Favorites)
+		assert.Equal(t, state.Recent, loaded.Recent)
+		assert.Equal(t, "environment", loaded.GroupBy)
+		assert.Equal(t, state.Queries, loaded.Queries)
+	})
+}
@@ -0,0 +1,121 @@
+package settings
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+)
+
+const (
+	// maxRecent is how many recently used profiles are remembered
+	maxRecent = 20
+	// maxQueries is how many Logs Insights queries are remembered per profile
+	maxQueries = 50
+)
+
+// State represents what awstui remembers between runs, such as favorite and recent profiles
+type State struct {
+	Favorites []string `json:"favorites,omitempty"`
+	Recent    []string `json:"recent,omitempty"` // Most recently used first
+	GroupBy   string   `json:"group_by,omitempty"`
+	// Logs Insights queries per profile, most recently run first
+	Queries map[string][]string `json:"queries,omitempty"`
+
+	path string
+}
+
+// LoadState reads the state file from the state directory, returning empty state when it does not exist
+func LoadState() (*State, error) {
+	dir, err := StateDir()
+	if err != nil {
+		return nil, err
+	}
+	return LoadStateFile(filepath.Join(dir, "state.json"))
+}
+
+// LoadStateFile reads state from a specific file
+func LoadStateFile(path string) (*State, error) {
+	state := &State{path: path}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return state, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, fmt.Errorf("could not parse state file %s: %w", path, err)
+	}
+	return state, nil
+}
+
+// Save writes the state file
+func (s *State) Save() error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o700); err != nil {
+		return fmt.Errorf("failed to create state directory: %w", err)
+	}
+	if err := os.WriteFile(s.path, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write state file: %w", err)
+	}
+	return nil
+}
+
+// IsFavorite reports whether a profile is pinned to the top of the selector
+func (s *State) IsFavorite(profile string) bool {
+	return slices.Contains(s.Favorites, profile)
+}
+
+// ToggleFavorite pins or unpins a profile, reporting whether it is now a favorite
+func (s *State) ToggleFavorite(profile string) bool {
+	if i := slices.Index(s.Favorites, profile); i >= 0 {
+		s.Favorites = slices.Delete(s.Favorites, i, i+1)
+		return false
+	}
+	s.Favorites = append(s.Favorites, profile)
+	return true
+}
+
+// UseProfile moves a profile to the front of the recently used list
+func (s *State) UseProfile(profile string) {
+	if i := slices.Index(s.Recent, profile); i >= 0 {
+		s.Recent = slices.Delete(s.Recent, i, i+1)
+	}
+	s.Recent = append([]string{profile}, s.Recent...)
+	if len(s.Recent) > maxRecent {
+		s.Recent = s.Recent[:maxRecent]
+	}
+}
+
+// RecentRank returns how recently a profile was used, 0 being the latest, or -1 if it was not
+func (s *State) RecentRank(profile string) int {
+	return slices.Index(s.Recent, profile)
+}
+
+// AddQuery moves a Logs Insights query to the front of a profile's history
+func (s *State) AddQuery(profile, query string) {
+	if s.Queries == nil {
+		s.Queries = make(map[string][]string)
+	}
+	history := s.Queries[profile]
+	if i := slices.Index(history, query); i >= 0 {
+		history = slices.Delete(history, i, i+1)
+	}
+	history = append([]string{query}, history...)
+	if len(history) > maxQueries {
+		history = history[:maxQueries]
+	}
+	s.Queries[profile] = history
+}
+
+// QueryHistory returns the Logs Insights queries run with a profile, most recent first
+func (s *State) QueryHistory(profile string) []string {
+	return s.Queries[profile]
+}
@@ -0,0 +1,90 @@
+package ui
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/Ninad-Bhangui/awstui/audit"
+	"github.com/gdamore/tcell/v2"
+)
+
+// AuditView represents the entries of the local audit log, newest first
+type AuditView struct {
+	*DataTable
+	session *Session
+}
+
+// NewAuditView creates a new audit log view
+func NewAuditView(session *Session) *AuditView {
+	view := &AuditView{
+		DataTable: NewDataTable(session.Layout, "Audit Log", []Column{
+			{"Time", "time", 20},
+			{"Profile", "profile", 20},
+			{"Account", "account", 14},
+			{"Region", "region", 14},
+			{"Action", "action", 30},
+			{"Resources", "resources", 60},
+			{"Result", "result", 8},
+		}),
+		session: session,
+	}
+
+	// Show the entry with its parameters on Enter
+	view.SetOpenFunc(func(row TableRow) {
+		entry := row.Ref.(audit.Entry)
+		showDetail(session, "Audit: "+entry.Action, func() (string, error) {
+			data, err := json.MarshalIndent(entry, "", "  ")
+			if err != nil {
+				return "", fmt.Errorf("failed to marshal audit entry: %w", err)
+			}
+			return string(data), nil
+		})
+	})
+
+	view.LoadData()
+	view.SortBy(0, true)
+
+	return view
+}
+
+// LoadData loads the entries from the audit log
+func (v *AuditView) LoadData() {
+	entries, skipped, err := v.session.AuditLog.Read()
+	if err != nil {
+		v.SetError(err)
+		return
+	}
+	title := "Audit Log"
+	if skipped == 1 {
+		title = "Audit Log - skipped 1 unreadable line"
+	} else if skipped > 1 {
+		title = fmt.Sprintf("Audit Log - skipped %d unreadable lines", skipped)
+	}
+	v.SetTitle(title)
+
+	var rows []TableRow
+	for i, entry := range entries {
+		row := TableRow{
+			ID: fmt.Sprintf("%d", i),
+			Cells: []string{
+				formatTime(entry.Time.Local()),
+				entry.Profile,
+				orDash(entry.Account),
+				entry.Region,
+				entry.Action,
+				strings.Join(entry.Resources, ", "),
+				entry.Result,
+			},
+			SortKeys: map[int]string{0: entry.Time.UTC().Format("2006-01-02T15:04:05.000000000")},
+			Ref:      entry,
+		}
+		if entry.Result == "success" {
+			row.Colors = map[int]tcell.Color{6: tcell.ColorGreen}
+		} else {
+			row.Colors = map[int]tcell.Color{6: tcell.ColorRed}
+		}
+		rows = append(rows, row)
+	}
+	v.SetRows(rows)
+}
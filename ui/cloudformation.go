@@ -0,0 +1,529 @@
+package ui
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	awsservices "github.com/Ninad-Bhangui/awstui/aws/services"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// driftPollInterval is how often a running drift detection is checked
+const driftPollInterval = 2 * time.Second
+
+// StackPage represents the drill-down of a CloudFormation stack
+type StackPage struct {
+	*TabView
+	session   *Session
+	stack     awsservices.Stack
+	events    *StackEventList
+	drift     *DataTable
+	detection string // ID of the running drift detection, empty when none is running
+}
+
+// NewStackPage creates a new page with the events, resources, outputs, parameters, template
+// and drift of a stack
+func NewStackPage(session *Session, stack awsservices.Stack) *StackPage {
+	page := &StackPage{
+		session: session,
+		stack:   stack,
+	}
+	page.events = newStackEventList(page)
+
+	page.TabView = NewTabView(session.Layout, "Stack: "+stack.Name, []Tab{
+		{"Events", page.events},
+		{"Resources", page.resourcesTab()},
+		{"Outputs", page.outputsTab()},
+		{"Parameters", page.parametersTab()},
+		{"Template", page.templateTab()},
+		{"Drift", page.driftTab()},
+	})
+	page.SetActions([]KeyAction{
+		newAction('d', "Detect drift", page.detectDrift),
+	})
+
+	return page
+}
+
+func (p *StackPage) resourcesTab() tview.Primitive {
+	table := NewDataTable(p.session.Layout, "Resources", []Column{
+		{"Logical ID", "logical_id", 35},
+		{"Physical ID", "physical_id", 50},
+		{"Type", "type", 35},
+		{"Status", "status", 25},
+		{"Drift", "drift", 12},
+		{"Updated", "updated", 20},
+	})
+
+	resources, err := awsservices.ListStackResources(context.Background(), p.session.Config, p.stack.ID)
+	if err != nil {
+		table.SetError(err)
+		return table
+	}
+
+	var rows []TableRow
+	for _, resource := range resources {
+		rows = append(rows, TableRow{
+			ID: resource.LogicalID,
+			Cells: []string{
+				resource.LogicalID,
+				orDash(resource.PhysicalID),
+				resource.Type,
+				resource.Status,
+				orDash(resource.DriftStatus),
+				formatOptionalTime(resource.UpdatedAt),
+			},
+			Colors: map[int]tcell.Color{3: stackStatusColor(resource.Status), 4: driftColor(resource.DriftStatus)},
+			Ref:    resource,
+		})
+	}
+	table.SetRows(rows)
+
+	table.SetOpenFunc(func(row TableRow) {
+		openStackResource(p.session, row.Ref.(awsservices.StackResource))
+	})
+
+	return table
+}
+
+func (p *StackPage) outputsTab() tview.Primitive {
+	table := NewDataTable(p.session.Layout, "Outputs", []Column{
+		{"Key", "key", 30},
+		{"Value", "value", 70},
+		{"Export", "export", 30},
+		{"Description", "description", 50},
+	})
+
+	var rows []TableRow
+	for _, output := range p.stack.Outputs {
+		rows = append(rows, TableRow{
+			ID:    output.Key,
+			Cells: []string{output.Key, output.Value, orDash(output.ExportName), orDash(output.Description)},
+			Ref:   output,
+		})
+	}
+	table.SetRows(rows)
+
+	// Outputs are mostly looked up to be pasted elsewhere
+	table.SetOpenFunc(func(row TableRow) {
+		copyText(p.session, row.Ref.(awsservices.StackOutput).Value)
+	})
+
+	return table
+}
+
+func (p *StackPage) parametersTab() tview.Primitive {
+	table := NewDataTable(p.session.Layout, "Parameters", []Column{
+		{"Key", "key", 30},
+		{"Value", "value", 70},
+		{"Resolved Value", "resolved_value", 50},
+	})
+
+	var rows []TableRow
+	for _, param := range p.stack.Parameters {
+		rows = append(rows, TableRow{
+			ID:    param.Key,
+			Cells: []string{param.Key, param.Value, orDash(param.ResolvedValue)},
+			Ref:   param,
+		})
+	}
+	table.SetRows(rows)
+
+	return table
+}
+
+func (p *StackPage) templateTab() tview.Primitive {
+	view := tview.NewTextView()
+	view.SetScrollable(true)
+
+	template, err := awsservices.GetStackTemplate(context.Background(), p.session.Config, p.stack.ID)
+	if err != nil {
+		view.SetDynamicColors(true)
+		view.SetText("[red]Error: " + tview.Escape(err.Error()))
+		return view
+	}
+	view.SetText(template)
+
+	return view
+}
+
+func (p *StackPage) driftTab() tview.Primitive {
+	p.drift = NewDataTable(p.session.Layout, "Drift", []Column{
+		{"Logical ID", "logical_id", 35},
+		{"Physical ID", "physical_id", 50},
+		{"Type", "type", 35},
+		{"Drift", "drift", 12},
+		{"Properties", "properties", 60},
+	})
+
+	p.drift.SetOpenFunc(func(row TableRow) {
+		drift := row.Ref.(awsservices.ResourceDrift)
+		showDetail(p.session, "Drift: "+drift.LogicalID, func() (string, error) {
+			return formatDrift(drift), nil
+		})
+	})
+
+	p.loadDrift(p.stack.DriftStatus, p.stack.DriftChecked)
+
+	return p.drift
+}
+
+// loadDrift shows the drifted resources found by the last drift detection
+func (p *StackPage) loadDrift(status string, checked time.Time) {
+	title := "Drift: " + orDash(status)
+	if !checked.IsZero() {
+		title += " (checked " + formatTime(checked) + ")"
+	}
+	p.drift.SetTitle(title)
+
+	if status != "DRIFTED" {
+		p.drift.SetRows(nil)
+		return
+	}
+
+	drifts, err := awsservices.ListResourceDrifts(context.Background(), p.session.Config, p.stack.ID)
+	if err != nil {
+		p.drift.SetError(err)
+		return
+	}
+
+	var rows []TableRow
+	for _, drift := range drifts {
+		var paths []string
+		for _, diff := range drift.Differences {
+			paths = append(paths, diff.Path)
+		}
+		rows = append(rows, TableRow{
+			ID:     drift.LogicalID,
+			Cells:  []string{drift.LogicalID, orDash(drift.PhysicalID), drift.Type, drift.Status, orDash(strings.Join(paths, ", "))},
+			Colors: map[int]tcell.Color{3: driftColor(drift.Status)},
+			Ref:    drift,
+		})
+	}
+	p.drift.SetRows(rows)
+}
+
+// detectDrift starts a drift detection and shows the drifted resources once it finishes
+func (p *StackPage) detectDrift() {
+	if p.detection != "" {
+		p.session.Layout.SetStatus("Drift detection is already running")
+		return
+	}
+	p.detection = "starting"
+	p.session.Layout.SetStatus("Starting drift detection of " + p.stack.Name)
+
+	go func() {
+		id, err := awsservices.DetectStackDrift(context.Background(), p.session.Config, p.stack.ID)
+		p.session.Layout.QueueUpdateDraw(func() {
+			if err != nil {
+				p.detection = ""
+				p.session.Layout.ShowError(err)
+				return
+			}
+			p.detection = id
+		})
+		if err == nil {
+			p.pollDrift(id)
+		}
+	}()
+}
+
+// pollDrift waits for a drift detection to finish, reporting its progress in the status bar
+func (p *StackPage) pollDrift(id string) {
+	ticker := time.NewTicker(driftPollInterval)
+	defer ticker.Stop()
+
+	for {
+		<-ticker.C
+		detection, err := awsservices.GetStackDriftDetection(context.Background(), p.session.Config, id)
+		done := err != nil || awsservices.StackDriftDetectionDone(detection.Status)
+		p.session.Layout.QueueUpdateDraw(func() {
+			if p.detection != id {
+				return
+			}
+			if !done {
+				p.session.Layout.SetStatus("Detecting drift of " + p.stack.Name)
+				return
+			}
+			p.detection = ""
+			if err != nil {
+				p.session.Layout.ShowError(err)
+				return
+			}
+			if detection.Status == "DETECTION_FAILED" && detection.DriftStatus == "" {
+				p.session.Layout.ShowError(fmt.Errorf("drift detection failed: %s", detection.Reason))
+				return
+			}
+			p.loadDrift(detection.DriftStatus, time.Now())
+			p.session.Layout.SetStatus(fmt.Sprintf("Stack %s is %s, resources drifted: %d", p.stack.Name, detection.DriftStatus, detection.DriftedCount))
+			if p.session.Layout.CurrentView() == p {
+				p.SelectTab(5)
+			}
+		})
+		if done {
+			return
+		}
+	}
+}
+
+// StackEventList represents the events of a stack, newest first, optionally following new events
+type StackEventList struct {
+	*DataTable
+	page   *StackPage
+	events []awsservices.StackEvent
+	follow chan struct{} // Closed to stop following, nil when not following
+}
+
+func newStackEventList(page *StackPage) *StackEventList {
+	list := &StackEventList{
+		DataTable: NewDataTable(page.session.Layout, "Events", []Column{
+			{"Time", "time", 20},
+			{"Logical ID", "logical_id", 35},
+			{"Type", "type", 35},
+			{"Status", "status", 30},
+			{"Reason", "reason", 80},
+		}),
+		page: page,
+	}
+
+	// Set up selection handler
+	list.SetOpenFunc(func(row TableRow) {
+		showDetail(page.session, "Event: "+row.Cells[1], func() (string, error) {
+			data, err := json.MarshalIndent(row.Ref, "", "  ")
+			if err != nil {
+				return "", fmt.Errorf("failed to marshal event: %w", err)
+			}
+			return string(data), nil
+		})
+	})
+
+	events, err := awsservices.ListStackEvents(context.Background(), page.session.Config, page.stack.ID)
+	if err != nil {
+		list.SetError(err)
+		return list
+	}
+	list.showEvents(events)
+	list.SortBy(0, true)
+
+	// Follow stacks that are being changed right away
+	if strings.HasSuffix(page.stack.Status, "_IN_PROGRESS") {
+		list.follow = make(chan struct{})
+		go list.poll(list.follow)
+		list.updateTitle()
+	}
+
+	return list
+}
+
+// Actions returns the key bindings for the event list
+func (l *StackEventList) Actions() []KeyAction {
+	follow := "Follow"
+	if l.follow != nil {
+		follow = "Stop following"
+	}
+	return append(l.DataTable.Actions(),
+		newAction('f', follow, l.toggleFollow),
+	)
+}
+
+// showEvents adds events, newest first, to those already shown
+func (l *StackEventList) showEvents(events []awsservices.StackEvent) {
+	l.events = append(slices.Clone(events), l.events...)
+
+	rows := make([]TableRow, len(l.events))
+	for i, event := range l.events {
+		rows[i] = TableRow{
+			ID:     event.ID,
+			Cells:  []string{formatTime(event.Timestamp), event.LogicalID, event.Type, event.Status, orDash(event.Reason)},
+			Colors: map[int]tcell.Color{3: stackStatusColor(event.Status)},
+			Ref:    event,
+		}
+	}
+	l.SetRows(rows)
+}
+
+func (l *StackEventList) toggleFollow() {
+	session := l.page.session
+	if l.follow != nil {
+		l.stopFollowing()
+		session.Layout.SetStatus("Stopped following " + l.page.stack.Name)
+	} else {
+		l.follow = make(chan struct{})
+		go l.poll(l.follow)
+		session.Layout.SetStatus("Following " + l.page.stack.Name)
+	}
+	l.updateTitle()
+	session.Layout.Refresh()
+}
+
+func (l *StackEventList) stopFollowing() {
+	if l.follow != nil {
+		close(l.follow)
+		l.follow = nil
+	}
+}
+
+// poll adds new events until done is closed, stopping once the stack page is left
+func (l *StackEventList) poll(done chan struct{}) {
+	session := l.page.session
+	ticker := time.NewTicker(followInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+		}
+
+		events, err := awsservices.ListStackEvents(context.Background(), session.Config, l.page.stack.ID)
+		session.Layout.QueueUpdateDraw(func() {
+			// Ignore results that arrive after following stopped
+			if l.follow != done {
+				return
+			}
+			if session.Layout.CurrentView() != l.page {
+				l.stopFollowing()
+				l.updateTitle()
+				return
+			}
+			if err != nil {
+				l.stopFollowing()
+				l.updateTitle()
+				session.Layout.Refresh()
+				session.Layout.ShowError(err)
+				return
+			}
+			var lastID string
+			if len(l.events) > 0 {
+				lastID = l.events[0].ID
+			}
+			if events = awsservices.NewStackEvents(events, lastID); len(events) > 0 {
+				l.showEvents(events)
+			}
+		})
+		if err != nil {
+			return
+		}
+	}
+}
+
+func (l *StackEventList) updateTitle() {
+	title := "Events"
+	if l.follow != nil {
+		title += " [following]"
+	}
+	l.SetTitle(title)
+}
+
+// openStackResource jumps to the view of a stack resource when awstui has one for its type
+func openStackResource(session *Session, resource awsservices.StackResource) {
+	ctx := context.Background()
+	id := resource.PhysicalID
+	if id == "" {
+		session.Layout.SetStatus(resource.LogicalID + " has not been created")
+		return
+	}
+
+	switch resource.Type {
+	case "AWS::EC2::Instance":
+		page, err := NewInstancePage(session, id)
+		if err != nil {
+			session.Layout.ShowError(err)
+			return
+		}
+		session.Layout.Push(page)
+	case "AWS::S3::Bucket":
+		// Like the bucket list, fall back to the session region when the location is not readable
+		bucket := awsservices.S3Bucket{Name: id}
+		if region, err := awsservices.GetS3BucketRegion(ctx, session.Config, id); err == nil {
+			bucket.Region = region
+		}
+		session.Layout.Push(NewObjectList(session, bucket, ""))
+	case "AWS::Logs::LogGroup":
+		session.Layout.Push(NewLogStreamList(session, id))
+	case "AWS::ECR::Repository":
+		session.Layout.Push(NewImageList(session, id))
+	case "AWS::ECS::Cluster":
+		session.Layout.Push(NewServiceList(session, id))
+	case "AWS::ECS::Service":
+		// Service ARNs end in "service/cluster/name"
+		parts := strings.Split(id, "/")
+		if len(parts) < 3 {
+			session.Layout.SetStatus("Cannot tell the cluster of " + id)
+			return
+		}
+		session.Layout.Push(NewTaskList(session, parts[len(parts)-2], parts[len(parts)-1]))
+	case "AWS::DynamoDB::Table":
+		table, err := awsservices.GetDynamoTable(ctx, session.Config, id)
+		if err != nil {
+			session.Layout.ShowError(err)
+			return
+		}
+		session.Layout.Push(NewItemList(session, *table))
+	case "AWS::RDS::DBInstance", "AWS::RDS::DBCluster":
+		databases, err := awsservices.ListRDSDatabases(ctx, session.Config)
+		if err != nil {
+			session.Layout.ShowError(err)
+			return
+		}
+		for _, db := range databases {
+			if db.ID == id && db.Cluster == (resource.Type == "AWS::RDS::DBCluster") {
+				session.Layout.Push(NewDatabasePage(session, db))
+				return
+			}
+		}
+		session.Layout.SetStatus("Database " + id + " not found")
+	case "AWS::CloudFormation::Stack":
+		stack, err := awsservices.GetStack(ctx, session.Config, id)
+		if err != nil {
+			session.Layout.ShowError(err)
+			return
+		}
+		session.Layout.Push(NewStackPage(session, *stack))
+	default:
+		session.Layout.SetStatus("No view for " + resource.Type)
+	}
+}
+
+// formatDrift renders the property differences of a drifted resource
+func formatDrift(drift awsservices.ResourceDrift) string {
+	if drift.Status == "DELETED" {
+		return drift.LogicalID + " (" + drift.Type + ") was deleted outside of CloudFormation\n"
+	}
+
+	var b strings.Builder
+	for _, diff := range drift.Differences {
+		fmt.Fprintf(&b, "%s (%s)\n  expected: %s\n  actual:   %s\n\n", diff.Path, diff.Type, orDash(diff.Expected), orDash(diff.Actual))
+	}
+	return b.String()
+}
+
+// stackStatusColor colors a stack or resource status by its category
+func stackStatusColor(status string) tcell.Color {
+	switch awsservices.StackStatusCategory(status) {
+	case awsservices.StackInProgress:
+		return tcell.ColorYellow
+	case awsservices.StackFailed:
+		return tcell.ColorRed
+	case awsservices.StackRollback:
+		return tcell.ColorOrange
+	}
+	return tcell.ColorGreen
+}
+
+// driftColor colors resources in sync green and drifted ones red
+func driftColor(status string) tcell.Color {
+	switch status {
+	case "IN_SYNC":
+		return tcell.ColorGreen
+	case "DRIFTED", "MODIFIED", "DELETED":
+		return tcell.ColorRed
+	}
+	return tcell.ColorDefault
+}
@@ -0,0 +1,87 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+
+	"github.com/Ninad-Bhangui/awstui/aws"
+	"github.com/Ninad-Bhangui/awstui/clipboard"
+	"github.com/rivo/tview"
+)
+
+// credentialEnv resolves the session credentials off the UI goroutine and passes their environment to onDone
+func credentialEnv(session *Session, onDone func([]aws.EnvVar)) {
+	session.Layout.SetStatus("Resolving credentials for " + session.Profile.Name)
+	go func() {
+		cfg := session.AWSConfig()
+		creds, err := cfg.Credentials.Retrieve(context.Background())
+		session.Layout.QueueUpdateDraw(func() {
+			if err != nil {
+				session.Layout.ShowError(fmt.Errorf("failed to resolve credentials: %w", err))
+				return
+			}
+			onDone(aws.CredentialEnv(creds, cfg.Region))
+		})
+	}()
+}
+
+// copyCredentials asks for a shell and copies export lines for the session credentials
+func copyCredentials(session *Session) {
+	var buttons []string
+	for _, shell := range aws.Shells {
+		buttons = append(buttons, string(shell))
+	}
+	modal := tview.NewModal().
+		SetText("Copy credential exports for which shell?").
+		AddButtons(append(buttons, "Cancel")).
+		SetDoneFunc(func(buttonIndex int, buttonLabel string) {
+			session.Layout.CloseModal("credentials")
+			if buttonIndex < 0 || buttonIndex >= len(aws.Shells) {
+				return
+			}
+			shell := aws.Shells[buttonIndex]
+			credentialEnv(session, func(vars []aws.EnvVar) {
+				if err := clipboard.Copy(aws.ExportLines(vars, shell)); err != nil {
+					session.Layout.ShowError(fmt.Errorf("failed to copy to clipboard: %w", err))
+					return
+				}
+				session.Layout.SetStatus(fmt.Sprintf("Copied %s credential exports for %s", shell, session.Profile.Name))
+			})
+		})
+	session.Layout.ShowModal("credentials", modal)
+}
+
+// openShell suspends the TUI and runs $SHELL with the session credentials until it exits
+func openShell(session *Session) {
+	credentialEnv(session, func(vars []aws.EnvVar) {
+		shell := os.Getenv("SHELL")
+		if shell == "" {
+			shell = "/bin/sh"
+			if runtime.GOOS == "windows" {
+				shell = "powershell.exe"
+			}
+		}
+
+		// Let prompts show which profile the shell belongs to
+		vars = append(vars, aws.EnvVar{Name: "AWSTUI_PROFILE", Value: session.Profile.Name})
+
+		var runErr error
+		session.Layout.Suspend(func() {
+			fmt.Printf("Starting %s with credentials for %s. Exit the shell to return to awstui.\n", shell, session.Profile.Name)
+			cmd := exec.Command(shell)
+			cmd.Env = aws.ShellEnv(os.Environ(), vars)
+			cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+			runErr = cmd.Run()
+		})
+
+		// A non-zero exit status is just the last command the user ran
+		if _, exited := runErr.(*exec.ExitError); runErr != nil && !exited {
+			session.Layout.ShowError(fmt.Errorf("failed to run %s: %w", shell, runErr))
+			return
+		}
+		session.Layout.SetStatus("Returned from " + shell)
+	})
+}
@@ -0,0 +1,50 @@
+package ui
+
+import (
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// DetailView represents a scrollable view of a resource's JSON details
+type DetailView struct {
+	*tview.TextView
+	title string
+}
+
+// NewDetailView creates a new detail view
+func NewDetailView(title, content string) *DetailView {
+	view := &DetailView{
+		TextView: tview.NewTextView(),
+		title:    title,
+	}
+
+	// Basic setup
+	view.SetBorder(true)
+	view.SetTitle(tview.Escape(title))
+	view.SetTitleAlign(tview.AlignLeft)
+	view.SetTextColor(tcell.ColorWhite)
+	view.SetScrollable(true)
+	view.SetText(content)
+
+	return view
+}
+
+// GetTitle returns the plain title of the view
+func (v *DetailView) GetTitle() string {
+	return v.title
+}
+
+// Actions returns the key bindings for the detail view
+func (v *DetailView) Actions() []KeyAction {
+	return nil
+}
+
+// showDetail loads details and pushes them as a new view, showing errors in a modal
+func showDetail(session *Session, title string, load func() (string, error)) {
+	content, err := load()
+	if err != nil {
+		session.Layout.ShowError(err)
+		return
+	}
+	session.Layout.Push(NewDetailView(title, content))
+}
@@ -0,0 +1,213 @@
+package ui
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+
+	awsservices "github.com/Ninad-Bhangui/awstui/aws/services"
+	"github.com/rivo/tview"
+)
+
+// ItemList represents the items of a DynamoDB table, read one page at a time by a Scan or a Query
+type ItemList struct {
+	*DataTable
+	session   *Session
+	table     awsservices.DynamoTable
+	query     *awsservices.DynamoQuery // Nil to scan the table
+	items     []map[string]interface{}
+	nextToken string
+}
+
+// NewItemList creates a new item browser that starts with a Scan of the table
+func NewItemList(session *Session, table awsservices.DynamoTable) *ItemList {
+	list := &ItemList{
+		DataTable: NewDataTable(session.Layout, "Items: "+table.Name, nil),
+		session:   session,
+		table:     table,
+	}
+
+	// Set up selection handler
+	list.SetOpenFunc(list.showItem)
+
+	list.LoadData()
+
+	return list
+}
+
+// Actions returns the key bindings for the item browser
+func (l *ItemList) Actions() []KeyAction {
+	actions := append(l.DataTable.Actions(),
+		newAction('Q', "Query", l.showQueryForm),
+	)
+	if l.query != nil {
+		actions = append(actions, newAction('s', "Scan", l.scan))
+	}
+	if l.nextToken != "" {
+		actions = append(actions, newAction('n', "Next page", l.loadNextPage))
+	}
+	return actions
+}
+
+// LoadData loads the first page of the Scan or Query from AWS
+func (l *ItemList) LoadData() {
+	l.items = nil
+	l.nextToken = ""
+	l.loadPage("")
+}
+
+func (l *ItemList) loadNextPage() {
+	l.loadPage(l.nextToken)
+}
+
+// loadPage appends a page of items, adding columns for attributes seen for the first time
+func (l *ItemList) loadPage(token string) {
+	ctx := context.Background()
+	var page *awsservices.DynamoItemPage
+	var err error
+	if l.query != nil {
+		page, err = awsservices.QueryDynamoTable(ctx, l.session.Config, l.table, *l.query, token)
+	} else {
+		page, err = awsservices.ScanDynamoTable(ctx, l.session.Config, l.table.Name, token)
+	}
+	if err != nil {
+		l.SetError(err)
+		l.session.Layout.Refresh()
+		return
+	}
+	l.items = append(l.items, page.Items...)
+	l.nextToken = page.NextToken
+
+	var columns []Column
+	attributes := awsservices.InferDynamoColumns(l.table, l.items)
+	for _, name := range attributes {
+		columns = append(columns, Column{name, name, 40})
+	}
+	if !slices.Equal(l.Columns(), columns) {
+		l.SetColumns(columns)
+	}
+
+	rows := make([]TableRow, len(l.items))
+	for i, item := range l.items {
+		cells := make([]string, len(attributes))
+		for j, name := range attributes {
+			if value, ok := item[name]; ok {
+				cells[j] = dynamoCell(value)
+			}
+		}
+		rows[i] = TableRow{
+			ID:    strconv.Itoa(i),
+			Cells: cells,
+			Ref:   item,
+		}
+	}
+	l.SetRows(rows)
+
+	mode := "Scan"
+	if l.query != nil {
+		mode = "Query"
+	}
+	if l.nextToken != "" {
+		l.session.Layout.SetStatus(fmt.Sprintf("%s • showing the first %d items, press n for the next page", mode, len(l.items)))
+	} else {
+		l.session.Layout.SetStatus(fmt.Sprintf("%s • %d items", mode, len(l.items)))
+	}
+	l.session.Layout.Refresh()
+}
+
+func (l *ItemList) scan() {
+	l.query = nil
+	l.LoadData()
+}
+
+// showQueryForm asks for a key condition on the table or one of its indexes
+func (l *ItemList) showQueryForm() {
+	query := awsservices.DynamoQuery{}
+	if l.query != nil {
+		query = *l.query
+	}
+
+	// Offer the table and its indexes together with the keys each is queried by
+	indexNames := []string{""}
+	indexOptions := []string{"Table (" + formatDynamoKeys(l.table.PartitionKey, l.table.SortKey) + ")"}
+	for _, index := range l.table.Indexes {
+		indexNames = append(indexNames, index.Name)
+		indexOptions = append(indexOptions, index.Name+" ("+formatDynamoKeys(index.PartitionKey, index.SortKey)+")")
+	}
+	sortOps := append([]string{"(none)"}, awsservices.DynamoSortOps...)
+
+	form := tview.NewForm()
+	form.AddDropDown("Table or index", indexOptions, max(slices.Index(indexNames, query.Index), 0), func(option string, index int) {
+		query.Index = indexNames[index]
+	})
+	form.AddInputField("Partition key value", query.PartitionValue, 0, nil, func(text string) { query.PartitionValue = text })
+	form.AddDropDown("Sort key condition", sortOps, max(slices.Index(sortOps, query.SortOp), 0), func(option string, index int) {
+		query.SortOp = ""
+		if index > 0 {
+			query.SortOp = option
+		}
+	})
+	form.AddInputField("Sort key value", query.SortValue, 0, nil, func(text string) { query.SortValue = text })
+	form.AddInputField("Upper bound (between)", query.SortValue2, 0, nil, func(text string) { query.SortValue2 = text })
+	form.AddButton("Query", func() {
+		l.session.Layout.CloseModal("dynamodb-query")
+		l.query = &query
+		l.LoadData()
+	})
+	form.AddButton("Cancel", func() {
+		l.session.Layout.CloseModal("dynamodb-query")
+	})
+	form.SetCancelFunc(func() {
+		l.session.Layout.CloseModal("dynamodb-query")
+	})
+
+	form.SetBorder(true)
+	form.SetTitle(tview.Escape("Query " + l.table.Name))
+	form.SetTitleAlign(tview.AlignLeft)
+
+	l.session.Layout.ShowModal("dynamodb-query", centered(form, 80, form.GetFormItemCount()*2+5))
+}
+
+// showItem shows an item as plain JSON
+func (l *ItemList) showItem(row TableRow) {
+	title := "Item"
+	if key := l.table.PartitionKey.Name; key != "" {
+		title += ": " + dynamoCell(row.Ref.(map[string]interface{})[key])
+	}
+	showDetail(l.session, title, func() (string, error) {
+		data, err := json.MarshalIndent(row.Ref, "", "  ")
+		if err != nil {
+			return "", fmt.Errorf("failed to marshal item: %w", err)
+		}
+		return string(data), nil
+	})
+}
+
+// dynamoCell renders an attribute value for a table cell, nested values as compact JSON
+func dynamoCell(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case json.Number:
+		return string(v)
+	case []byte:
+		return base64.StdEncoding.EncodeToString(v)
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	return string(data)
+}
+
+// formatDynamoKeys renders a key schema as e.g. "pk S, sk N"
+func formatDynamoKeys(partitionKey, sortKey awsservices.DynamoKey) string {
+	keys := partitionKey.Name + " " + partitionKey.Type
+	if sortKey.Name != "" {
+		keys += ", " + sortKey.Name + " " + sortKey.Type
+	}
+	return keys
+}
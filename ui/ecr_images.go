@@ -0,0 +1,240 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	awsservices "github.com/Ninad-Bhangui/awstui/aws/services"
+	"github.com/gdamore/tcell/v2"
+)
+
+// severities lists scan finding severities from most to least severe
+var severities = []string{"CRITICAL", "HIGH", "MEDIUM", "LOW", "INFORMATIONAL", "UNDEFINED"}
+
+// ImageList represents the images of an ECR repository
+type ImageList struct {
+	*DataTable
+	session  *Session
+	repoName string
+	scanType string
+}
+
+// NewImageList creates a new image list for a repository
+func NewImageList(session *Session, repoName string) *ImageList {
+	list := &ImageList{
+		DataTable: NewDataTable(session.Layout, "Images: "+repoName, []Column{
+			{"Tags", "tags", 30},
+			{"Digest", "digest", 20},
+			{"Pushed", "pushed", 20},
+			{"Size (MB)", "size", 10},
+			{"Scan Status", "scan_status", 15},
+			{"Findings", "findings", 40},
+		}),
+		session:  session,
+		repoName: repoName,
+	}
+
+	// Set up selection handler
+	list.SetOpenFunc(func(row TableRow) {
+		image := row.Ref.(awsservices.ECRImage)
+		session.Layout.Push(NewFindingsList(session, repoName, image))
+	})
+
+	// Only basic scanning supports on-demand scans
+	scanType, err := awsservices.GetRegistryScanType(context.Background(), session.Config)
+	if err == nil {
+		list.scanType = scanType
+	}
+
+	list.LoadData()
+
+	return list
+}
+
+// Actions returns the key bindings for the image list
+func (l *ImageList) Actions() []KeyAction {
+	actions := l.DataTable.Actions()
+	if l.scanType == "BASIC" {
+		actions = append(actions, newWriteAction('s', "Start scan", l.startScan))
+	}
+	return actions
+}
+
+// LoadData loads the repository images from AWS
+func (l *ImageList) LoadData() {
+	images, err := awsservices.ListECRImages(context.Background(), l.session.Config, l.repoName)
+	if err != nil {
+		l.SetError(err)
+		return
+	}
+
+	var rows []TableRow
+	for _, image := range images {
+		tags := strings.Join(image.Tags, ", ")
+		if tags == "" {
+			tags = "<untagged>"
+		}
+		row := TableRow{
+			ID: image.Digest,
+			Cells: []string{
+				tags,
+				shortDigest(image.Digest),
+				formatTime(image.PushedAt),
+				fmt.Sprintf("%.1f", float64(image.SizeBytes)/1024/1024),
+				image.ScanStatus,
+				formatSeverityCounts(image.SeverityCounts),
+			},
+			SortKeys: map[int]string{5: severitySortKey(image.SeverityCounts)},
+			Ref:      image,
+		}
+		if color, ok := severityColor(worstSeverity(image.SeverityCounts)); ok {
+			row.Colors = map[int]tcell.Color{5: color}
+		}
+		rows = append(rows, row)
+	}
+
+	l.SetRows(rows)
+}
+
+func (l *ImageList) startScan() {
+	row, ok := l.SelectedRow()
+	if !ok {
+		return
+	}
+	image := row.Ref.(awsservices.ECRImage)
+
+	l.session.ConfirmChange(fmt.Sprintf("Start a scan of %s:%s?", l.repoName, row.Cells[0]), func() {
+		err := awsservices.StartImageScan(context.Background(), l.session.Config, l.repoName, image.Digest)
+		l.session.Audit("ecr:StartImageScan", []string{l.repoName + "@" + image.Digest}, nil, err)
+		if err != nil {
+			l.session.Layout.ShowError(err)
+			return
+		}
+		l.session.Layout.SetStatus(fmt.Sprintf("Scan started for %s", shortDigest(image.Digest)))
+		l.LoadData()
+	})
+}
+
+// FindingsList represents the vulnerability scan findings of an image
+type FindingsList struct {
+	*DataTable
+	session  *Session
+	repoName string
+	image    awsservices.ECRImage
+}
+
+// NewFindingsList creates a new findings list for an image
+func NewFindingsList(session *Session, repoName string, image awsservices.ECRImage) *FindingsList {
+	list := &FindingsList{
+		DataTable: NewDataTable(session.Layout, "Findings: "+shortDigest(image.Digest), []Column{
+			{"CVE", "cve", 25},
+			{"Severity", "severity", 15},
+			{"Package", "package", 30},
+			{"Version", "version", 25},
+			{"Fixed In", "fixed_in", 40},
+		}),
+		session:  session,
+		repoName: repoName,
+		image:    image,
+	}
+
+	list.LoadData()
+	list.SortBy(1, false)
+
+	return list
+}
+
+// LoadData loads the scan findings from AWS
+func (l *FindingsList) LoadData() {
+	result, err := awsservices.GetImageScanFindings(context.Background(), l.session.Config, l.repoName, l.image.Digest)
+	if err != nil {
+		l.SetError(err)
+		return
+	}
+
+	var rows []TableRow
+	for i, finding := range result.Findings {
+		row := TableRow{
+			ID:       fmt.Sprintf("%s/%s/%d", finding.Name, finding.Package, i),
+			Cells:    []string{finding.Name, finding.Severity, orDash(finding.Package), orDash(finding.Version), orDash(finding.FixedIn)},
+			SortKeys: map[int]string{1: fmt.Sprintf("%d", severityRank(finding.Severity))},
+			Ref:      finding,
+		}
+		if color, ok := severityColor(finding.Severity); ok {
+			row.Colors = map[int]tcell.Color{1: color}
+		}
+		rows = append(rows, row)
+	}
+
+	l.SetRows(rows)
+	l.session.Layout.SetStatus(fmt.Sprintf("Scan %s • %s", result.Status, formatSeverityCounts(result.SeverityCounts)))
+}
+
+// formatSeverityCounts renders severity counts as e.g. "C:1 H:4 M:2"
+func formatSeverityCounts(counts map[string]int32) string {
+	var parts []string
+	for _, severity := range severities {
+		if n := counts[severity]; n > 0 {
+			parts = append(parts, fmt.Sprintf("%c:%d", severity[0], n))
+		}
+	}
+	if len(parts) == 0 {
+		return "-"
+	}
+	return strings.Join(parts, " ")
+}
+
+// severitySortKey orders images by their most severe findings first
+func severitySortKey(counts map[string]int32) string {
+	var key strings.Builder
+	for _, severity := range severities {
+		fmt.Fprintf(&key, "%06d-", 999999-counts[severity])
+	}
+	return key.String()
+}
+
+func worstSeverity(counts map[string]int32) string {
+	for _, severity := range severities {
+		if counts[severity] > 0 {
+			return severity
+		}
+	}
+	return ""
+}
+
+func severityRank(severity string) int {
+	for i, s := range severities {
+		if s == severity {
+			return i
+		}
+	}
+	return len(severities)
+}
+
+func severityColor(severity string) (tcell.Color, bool) {
+	switch severity {
+	case "CRITICAL":
+		return tcell.ColorRed, true
+	case "HIGH":
+		return tcell.ColorOrangeRed, true
+	case "MEDIUM":
+		return tcell.ColorYellow, true
+	}
+	return tcell.ColorDefault, false
+}
+
+func shortDigest(digest string) string {
+	digest = strings.TrimPrefix(digest, "sha256:")
+	if len(digest) > 12 {
+		return digest[:12]
+	}
+	return digest
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
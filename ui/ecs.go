@@ -0,0 +1,263 @@
+package ui
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	awsservices "github.com/Ninad-Bhangui/awstui/aws/services"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// ServiceList represents the services of an ECS cluster
+type ServiceList struct {
+	*DataTable
+	session *Session
+	cluster string
+}
+
+// NewServiceList creates a new service list for a cluster
+func NewServiceList(session *Session, cluster string) *ServiceList {
+	list := &ServiceList{
+		DataTable: NewDataTable(session.Layout, "Services: "+cluster, []Column{
+			{"Name", "name", 40},
+			{"Status", "status", 10},
+			{"Desired", "desired", 8},
+			{"Running", "running", 8},
+			{"Pending", "pending", 8},
+			{"Deployment", "deployment", 14},
+			{"Task Definition", "task_definition", 40},
+			{"Launch Type", "launch_type", 12},
+		}),
+		session: session,
+		cluster: cluster,
+	}
+
+	// Set up selection handler
+	list.SetOpenFunc(func(row TableRow) {
+		session.Layout.Push(NewTaskList(session, cluster, row.ID))
+	})
+
+	list.LoadData()
+
+	return list
+}
+
+// Actions returns the key bindings for the service list
+func (l *ServiceList) Actions() []KeyAction {
+	return append(l.DataTable.Actions(),
+		newWriteAction('F', "Force new deployment", l.forceDeployment),
+		newAction('x', "Export", func() { exportTable(l.session, l.DataTable) }),
+	)
+}
+
+// LoadData loads the services from AWS
+func (l *ServiceList) LoadData() {
+	services, err := awsservices.ListECSServices(context.Background(), l.session.Config, l.cluster)
+	if err != nil {
+		l.SetError(err)
+		return
+	}
+
+	var rows []TableRow
+	for _, service := range services {
+		deployment := orDash(service.RolloutState)
+		if service.Deployments > 1 {
+			deployment += fmt.Sprintf(" (%d)", service.Deployments)
+		}
+		row := TableRow{
+			ID: service.Name,
+			Cells: []string{
+				service.Name,
+				service.Status,
+				fmt.Sprintf("%d", service.Desired),
+				fmt.Sprintf("%d", service.Running),
+				fmt.Sprintf("%d", service.Pending),
+				deployment,
+				service.TaskDefinition,
+				orDash(service.LaunchType),
+			},
+			Colors: map[int]tcell.Color{5: rolloutColor(service.RolloutState)},
+			Ref:    service,
+		}
+		if service.Running < service.Desired {
+			row.Colors[3] = tcell.ColorYellow
+		}
+		rows = append(rows, row)
+	}
+	l.SetRows(rows)
+}
+
+// forceDeployment replaces the tasks of the selected service after confirmation
+func (l *ServiceList) forceDeployment() {
+	row, ok := l.SelectedRow()
+	if !ok {
+		return
+	}
+	service := row.Ref.(awsservices.ECSService)
+
+	text := fmt.Sprintf("Force a new deployment of %s?\n\nAll %d tasks are replaced with new ones running %s.", service.Name, service.Running, service.TaskDefinition)
+	l.session.ConfirmChange(text, func() {
+		err := awsservices.ForceECSDeployment(context.Background(), l.session.Config, l.cluster, service.Name)
+		l.session.Audit("ecs:UpdateService", []string{service.ARN}, map[string]interface{}{"forceNewDeployment": true}, err)
+		if err != nil {
+			l.session.Layout.ShowError(err)
+			return
+		}
+		l.session.Layout.SetStatus("Started a new deployment of " + service.Name)
+		l.LoadData()
+	})
+}
+
+// TaskList represents the running tasks of an ECS service
+type TaskList struct {
+	*DataTable
+	session *Session
+	cluster string
+	service string
+}
+
+// NewTaskList creates a new task list for a service
+func NewTaskList(session *Session, cluster, service string) *TaskList {
+	list := &TaskList{
+		DataTable: NewDataTable(session.Layout, "Tasks: "+service, []Column{
+			{"Task", "task", 34},
+			{"Status", "status", 12},
+			{"Desired", "desired", 10},
+			{"Health", "health", 10},
+			{"Containers", "containers", 50},
+			{"Task Definition", "task_definition", 30},
+			{"Started", "started", 20},
+		}),
+		session: session,
+		cluster: cluster,
+		service: service,
+	}
+
+	// Set up selection handler
+	list.SetOpenFunc(list.showTask)
+
+	list.LoadData()
+
+	return list
+}
+
+// Actions returns the key bindings for the task list
+func (l *TaskList) Actions() []KeyAction {
+	return append(l.DataTable.Actions(),
+		newAction('L', "Logs", l.showLogs),
+	)
+}
+
+// LoadData loads the tasks from AWS
+func (l *TaskList) LoadData() {
+	tasks, err := awsservices.ListECSTasks(context.Background(), l.session.Config, l.cluster, l.service)
+	if err != nil {
+		l.SetError(err)
+		return
+	}
+
+	var rows []TableRow
+	for _, task := range tasks {
+		var containers []string
+		for _, container := range task.Containers {
+			text := container.Name
+			if len(container.IPs) > 0 {
+				text += " " + strings.Join(container.IPs, ",")
+			}
+			containers = append(containers, text)
+		}
+		row := TableRow{
+			ID: task.ID,
+			Cells: []string{
+				task.ID,
+				task.LastStatus,
+				task.DesiredStatus,
+				orDash(task.Health),
+				orDash(strings.Join(containers, "; ")),
+				awsservices.ShortTaskDefinition(task.TaskDefinitionARN),
+				formatOptionalTime(task.StartedAt),
+			},
+			Colors: map[int]tcell.Color{},
+			Ref:    task,
+		}
+		switch task.Health {
+		case "HEALTHY":
+			row.Colors[3] = tcell.ColorGreen
+		case "UNHEALTHY":
+			row.Colors[3] = tcell.ColorRed
+		}
+		if task.LastStatus != task.DesiredStatus {
+			row.Colors[1] = tcell.ColorYellow
+		}
+		rows = append(rows, row)
+	}
+	l.SetRows(rows)
+}
+
+// showTask shows the containers of a task as JSON
+func (l *TaskList) showTask(row TableRow) {
+	showDetail(l.session, "Task: "+row.ID, func() (string, error) {
+		data, err := json.MarshalIndent(row.Ref, "", "  ")
+		if err != nil {
+			return "", fmt.Errorf("failed to marshal task: %w", err)
+		}
+		return string(data), nil
+	})
+}
+
+// showLogs opens the log stream of a container of the selected task, asking which
+// container when the task has more than one
+func (l *TaskList) showLogs() {
+	row, ok := l.SelectedRow()
+	if !ok {
+		return
+	}
+	task := row.Ref.(awsservices.ECSTask)
+
+	switch len(task.Containers) {
+	case 0:
+		l.session.Layout.SetStatus("Task " + task.ID + " has no containers")
+	case 1:
+		l.openLogStream(task, task.Containers[0])
+	default:
+		list := tview.NewList().ShowSecondaryText(false)
+		for _, container := range task.Containers {
+			container := container
+			list.AddItem(tview.Escape(container.Name), "", 0, func() {
+				l.session.Layout.CloseModal("containers")
+				l.openLogStream(task, container)
+			})
+		}
+		list.SetDoneFunc(func() {
+			l.session.Layout.CloseModal("containers")
+		})
+		list.SetBorder(true)
+		list.SetTitle("Container")
+		list.SetTitleAlign(tview.AlignLeft)
+
+		l.session.Layout.ShowModal("containers", centered(list, 50, len(task.Containers)+2))
+	}
+}
+
+func (l *TaskList) openLogStream(task awsservices.ECSTask, container awsservices.ECSContainer) {
+	group, stream, err := awsservices.ECSContainerLogStream(context.Background(), l.session.Config, task, container)
+	if err != nil {
+		l.session.Layout.ShowError(err)
+		return
+	}
+	l.session.Layout.Push(NewLogViewer(l.session, group, stream))
+}
+
+// rolloutColor colors completed deployments green, failed ones red and the rest yellow
+func rolloutColor(state string) tcell.Color {
+	switch state {
+	case "COMPLETED":
+		return tcell.ColorGreen
+	case "FAILED":
+		return tcell.ColorRed
+	}
+	return tcell.ColorYellow
+}
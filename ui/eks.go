@@ -0,0 +1,194 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/Ninad-Bhangui/awstui/aws"
+	awsservices "github.com/Ninad-Bhangui/awstui/aws/services"
+	"github.com/gdamore/tcell/v2"
+)
+
+// NodeGroupList represents the managed node groups of an EKS cluster
+type NodeGroupList struct {
+	*DataTable
+	session *Session
+	cluster awsservices.EKSCluster
+}
+
+// NewNodeGroupList creates a new node group list for a cluster
+func NewNodeGroupList(session *Session, cluster awsservices.EKSCluster) *NodeGroupList {
+	list := &NodeGroupList{
+		DataTable: NewDataTable(session.Layout, "Node Groups: "+cluster.Name, []Column{
+			{"Name", "name", 40},
+			{"Status", "status", 16},
+			{"Version", "version", 8},
+			{"Instance Types", "instance_types", 30},
+			{"Capacity", "capacity", 10},
+			{"Desired", "desired", 8},
+			{"Min", "min", 6},
+			{"Max", "max", 6},
+			{"AMI Type", "ami_type", 20},
+			{"Created", "created", 20},
+		}),
+		session: session,
+		cluster: cluster,
+	}
+
+	list.LoadData()
+
+	return list
+}
+
+// Actions returns the key bindings for the node group list
+func (l *NodeGroupList) Actions() []KeyAction {
+	actions := append(l.DataTable.Actions(), clusterActions(l.session, func() (awsservices.EKSCluster, bool) { return l.cluster, true })...)
+	return append(actions,
+		newAction('x', "Export", func() { exportTable(l.session, l.DataTable) }),
+	)
+}
+
+// LoadData loads the node groups from AWS
+func (l *NodeGroupList) LoadData() {
+	nodeGroups, err := awsservices.ListEKSNodeGroups(context.Background(), l.session.Config, l.cluster.Name)
+	if err != nil {
+		l.SetError(err)
+		return
+	}
+
+	var rows []TableRow
+	for _, ng := range nodeGroups {
+		rows = append(rows, TableRow{
+			ID: ng.Name,
+			Cells: []string{
+				ng.Name,
+				orDash(ng.Status),
+				orDash(ng.Version),
+				orDash(strings.Join(ng.InstanceTypes, ", ")),
+				orDash(ng.CapacityType),
+				fmt.Sprintf("%d", ng.Desired),
+				fmt.Sprintf("%d", ng.Min),
+				fmt.Sprintf("%d", ng.Max),
+				orDash(ng.AMIType),
+				formatOptionalTime(ng.CreatedAt),
+			},
+			Colors: map[int]tcell.Color{1: eksStatusColor(ng.Status)},
+			Ref:    ng,
+		})
+	}
+	l.SetRows(rows)
+}
+
+// clusterActions returns the kubeconfig and k9s actions for the cluster returned by selected
+func clusterActions(session *Session, selected func() (awsservices.EKSCluster, bool)) []KeyAction {
+	return []KeyAction{
+		newAction('u', "Update kubeconfig", func() {
+			if cluster, ok := selected(); ok {
+				writeKubeconfig(session, cluster)
+			}
+		}),
+		newAction('K', "Launch k9s", func() {
+			if cluster, ok := selected(); ok {
+				launchK9s(session, cluster)
+			}
+		}),
+	}
+}
+
+// kubeconfigEntry returns the kubeconfig entry of a cluster, getting tokens with the session profile
+func kubeconfigEntry(session *Session, cluster awsservices.EKSCluster) aws.KubeconfigEntry {
+	entry := aws.KubeconfigEntry{
+		Name:                 cluster.ARN,
+		ClusterName:          cluster.Name,
+		Region:               session.AWSConfig().Region,
+		Server:               cluster.Endpoint,
+		CertificateAuthority: cluster.CertificateAuthority,
+	}
+	// Profiles from the environment have no name the AWS CLI could look up later
+	if !session.Profile.IsFromEnv {
+		entry.Profile = session.Profile.Name
+	}
+	return entry
+}
+
+// writeKubeconfig adds a context for the cluster to the kubeconfig after confirmation
+func writeKubeconfig(session *Session, cluster awsservices.EKSCluster) {
+	if cluster.Endpoint == "" {
+		session.Layout.SetStatus(cluster.Name + " has no API server endpoint yet")
+		return
+	}
+	path, err := aws.KubeconfigPath()
+	if err != nil {
+		session.Layout.ShowError(err)
+		return
+	}
+
+	text := fmt.Sprintf("Write a context for %s to %s and make it the current context?", cluster.Name, path)
+	session.Layout.Confirm(text, func() {
+		if err := aws.WriteKubeconfig(path, kubeconfigEntry(session, cluster)); err != nil {
+			session.Layout.ShowError(err)
+			return
+		}
+		session.Layout.SetStatus(fmt.Sprintf("Wrote context %s to %s", cluster.ARN, path))
+	})
+}
+
+// launchK9s writes the context of the cluster and suspends the TUI while k9s runs on it
+func launchK9s(session *Session, cluster awsservices.EKSCluster) {
+	if cluster.Endpoint == "" {
+		session.Layout.SetStatus(cluster.Name + " has no API server endpoint yet")
+		return
+	}
+	k9s, err := exec.LookPath("k9s")
+	if err != nil {
+		session.Layout.ShowError(fmt.Errorf("k9s was not found on the PATH: %w", err))
+		return
+	}
+	path, err := aws.KubeconfigPath()
+	if err != nil {
+		session.Layout.ShowError(err)
+		return
+	}
+
+	text := fmt.Sprintf("Launch k9s on %s?\n\nIts context is written to %s first.", cluster.Name, path)
+	session.Layout.Confirm(text, func() {
+		if err := aws.WriteKubeconfig(path, kubeconfigEntry(session, cluster)); err != nil {
+			session.Layout.ShowError(err)
+			return
+		}
+
+		// Hand k9s the session credentials so tokens work for assumed roles and MFA sessions too
+		credentialEnv(session, func(vars []aws.EnvVar) {
+			vars = append(vars, aws.EnvVar{Name: "AWSTUI_PROFILE", Value: session.Profile.Name})
+
+			var runErr error
+			session.Layout.Suspend(func() {
+				cmd := exec.Command(k9s, "--context", cluster.ARN)
+				cmd.Env = aws.ShellEnv(os.Environ(), vars)
+				cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+				runErr = cmd.Run()
+			})
+			if runErr != nil {
+				session.Layout.ShowError(fmt.Errorf("k9s failed: %w", runErr))
+				return
+			}
+			session.Layout.SetStatus("Returned from k9s")
+		})
+	})
+}
+
+// eksStatusColor returns the color of a cluster or node group status
+func eksStatusColor(status string) tcell.Color {
+	switch status {
+	case "ACTIVE":
+		return tcell.ColorGreen
+	case "CREATING", "UPDATING", "PENDING":
+		return tcell.ColorYellow
+	case "FAILED", "DEGRADED", "CREATE_FAILED", "DELETE_FAILED":
+		return tcell.ColorRed
+	}
+	return tcell.ColorWhite
+}
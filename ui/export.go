@@ -0,0 +1,112 @@
+package ui
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/Ninad-Bhangui/awstui/clipboard"
+	"github.com/Ninad-Bhangui/awstui/export"
+	"github.com/rivo/tview"
+)
+
+var fileNameUnsafe = regexp.MustCompile(`[^a-z0-9]+`)
+
+// exportTable asks for a format and destination and exports the visible rows of a table
+func exportTable(session *Session, table *DataTable) {
+	now := time.Now()
+	base := strings.Trim(fileNameUnsafe.ReplaceAllString(strings.ToLower(table.GetTitle()), "-"), "-")
+	base += "-" + now.Format("20060102-150405")
+
+	format := export.Formats[0]
+	toClipboard := false
+
+	var formats []string
+	for _, f := range export.Formats {
+		formats = append(formats, string(f))
+	}
+
+	form := tview.NewForm()
+	path := tview.NewInputField().
+		SetLabel("Path").
+		SetText(base + "." + string(format)).
+		SetFieldWidth(0)
+
+	form.AddDropDown("Format", formats, 0, func(option string, index int) {
+		if index < 0 {
+			return
+		}
+		format = export.Formats[index]
+
+		// Keep the file extension in step with the format
+		current := path.GetText()
+		if ext := filepath.Ext(current); ext != "" && ext != "."+option {
+			path.SetText(strings.TrimSuffix(current, ext) + "." + option)
+		}
+	})
+	form.AddDropDown("Destination", []string{"File", "Clipboard"}, 0, func(option string, index int) {
+		toClipboard = option == "Clipboard"
+		path.SetDisabled(toClipboard)
+	})
+	form.AddFormItem(path)
+	form.AddButton("Export", func() {
+		session.Layout.CloseModal("export")
+
+		var columns []string
+		for _, col := range table.Columns() {
+			columns = append(columns, col.Title)
+		}
+		var rows [][]string
+		for _, row := range table.VisibleRows() {
+			rows = append(rows, row.Cells)
+		}
+
+		header := export.Header{
+			Profile: session.Profile.Name,
+			Region:  session.AWSConfig().Region,
+			Time:    now,
+		}
+
+		var buf bytes.Buffer
+		if err := export.Write(&buf, format, header, export.Table{
+			Title:   table.GetTitle(),
+			Columns: columns,
+			Rows:    rows,
+		}); err != nil {
+			session.Layout.ShowError(err)
+			return
+		}
+
+		if toClipboard {
+			if err := clipboard.Copy(buf.String()); err != nil {
+				session.Layout.ShowError(fmt.Errorf("failed to copy to clipboard: %w", err))
+				return
+			}
+			session.Layout.SetStatus(fmt.Sprintf("Copied %d row(s) as %s to the clipboard", len(rows), format))
+			return
+		}
+
+		file := strings.TrimSpace(path.GetText())
+		if err := os.WriteFile(file, buf.Bytes(), 0o644); err != nil {
+			session.Layout.ShowError(fmt.Errorf("failed to write export: %w", err))
+			return
+		}
+		session.Layout.SetStatus(fmt.Sprintf("Exported %d row(s) to %s", len(rows), file))
+	})
+	form.AddButton("Cancel", func() {
+		session.Layout.CloseModal("export")
+	})
+	form.SetCancelFunc(func() {
+		session.Layout.CloseModal("export")
+	})
+
+	form.SetBorder(true)
+	form.SetTitle(tview.Escape(fmt.Sprintf("Export %s (%d rows)", table.GetTitle(), len(table.VisibleRows()))))
+	form.SetTitleAlign(tview.AlignLeft)
+
+	session.Layout.ShowModal("export", centered(form, 70, 11))
+}
@@ -46,6 +46,7 @@ func NewHomePage(profile aws.Profile, cfg config.Config) *HomePage {
 // HomeScreen represents the main services overview screen
 type HomeScreen struct {
 	*tview.Table
+	session         *Session
 	onServiceSelect func(service string)
 }
 
@@ -62,12 +63,24 @@ var services = []Service{
 	{"ECR Repositories", "Manage Docker container images", "ecr"},
 	{"Lambda Functions", "Run code without provisioning servers", "lambda"},
 	{"Secrets Manager", "Store and manage sensitive information", "secrets"},
+	{"S3 Buckets", "Store and retrieve objects", "s3"},
+	{"CloudWatch Logs", "Browse log streams and run Logs Insights queries", "logs"},
+	{"DynamoDB Tables", "Scan and query NoSQL tables", "dynamodb"},
+	{"RDS Databases", "Inspect DB instances and Aurora clusters", "rds"},
+	{"ECS Clusters", "Inspect services and tasks and read container logs", "ecs"},
+	{"CloudFormation Stacks", "Follow stack events and detect drift", "cfn"},
+	{"SQS Queues", "Peek, send, purge and redrive messages", "sqs"},
+	{"SNS Topics", "Inspect subscriptions and publish messages", "sns"},
+	{"SSM Parameters", "Browse the Parameter Store by path", "ssm"},
+	{"IAM", "Review users, access key age, roles and policies", "iam"},
+	{"EKS Clusters", "Inspect node groups, write kubeconfig and launch k9s", "eks"},
 }
 
 // NewHomeScreen creates a new home screen
-func NewHomeScreen(onServiceSelect func(service string)) *HomeScreen {
+func NewHomeScreen(session *Session, onServiceSelect func(service string)) *HomeScreen {
 	home := &HomeScreen{
 		Table:           tview.NewTable().SetSelectable(true, false),
+		session:         session,
 		onServiceSelect: onServiceSelect,
 	}
 
@@ -97,3 +110,11 @@ func NewHomeScreen(onServiceSelect func(service string)) *HomeScreen {
 
 	return home
 }
+
+// Actions returns the key bindings for the home screen
+func (h *HomeScreen) Actions() []KeyAction {
+	return []KeyAction{
+		newAction('c', "Copy credentials", func() { copyCredentials(h.session) }),
+		newAction('S', "Shell", func() { openShell(h.session) }),
+	}
+}
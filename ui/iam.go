@@ -0,0 +1,377 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	awsservices "github.com/Ninad-Bhangui/awstui/aws/services"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// IAMPage represents the users, roles and customer managed policies of the account
+type IAMPage struct {
+	*TabView
+	session *Session
+}
+
+// NewIAMPage creates a new IAM page
+func NewIAMPage(session *Session) *IAMPage {
+	page := &IAMPage{session: session}
+
+	page.TabView = NewTabView(session.Layout, "IAM", []Tab{
+		{"Users", page.usersTab()},
+		{"Roles", page.rolesTab()},
+		{"Policies", page.policiesTab()},
+	})
+
+	return page
+}
+
+func (p *IAMPage) usersTab() tview.Primitive {
+	table := NewDataTable(p.session.Layout, "Users", []Column{
+		{"Name", "name", 30},
+		{"Access Keys", "keys", 12},
+		{"Oldest Key", "oldest_key", 12},
+		{"Key Last Used", "key_last_used", 20},
+		{"Console Last Used", "console_last_used", 20},
+		{"MFA", "mfa", 5},
+		{"Created", "created", 20},
+	})
+	table.SetOpenFunc(func(row TableRow) {
+		p.session.Layout.Push(NewUserPage(p.session, row.Ref.(awsservices.IAMUser)))
+	})
+
+	users, err := awsservices.ListIAMUsers(context.Background(), p.session.Config)
+	if err != nil {
+		table.SetError(err)
+		return table
+	}
+
+	maxAge := p.session.Settings.AccessKeyMaxAgeDays
+	now := time.Now()
+	var rows []TableRow
+	for _, user := range users {
+		// Only active keys count towards the age and last use of a user
+		active := 0
+		oldest := -1
+		var lastUsed time.Time
+		for _, key := range user.AccessKeys {
+			if !key.Active {
+				continue
+			}
+			active++
+			oldest = max(oldest, awsservices.KeyAgeDays(key.Created, now))
+			if key.LastUsed.After(lastUsed) {
+				lastUsed = key.LastUsed
+			}
+		}
+
+		row := TableRow{
+			ID: user.Name,
+			Cells: []string{
+				user.Name,
+				fmt.Sprintf("%d active", active),
+				"-",
+				formatOptionalTime(lastUsed),
+				formatOptionalTime(user.PasswordLastUsed),
+				yesNo(user.MFA),
+				formatTime(user.Created),
+			},
+			Colors:   map[int]tcell.Color{5: tcell.ColorGreen},
+			SortKeys: map[int]string{2: fmt.Sprintf("%d", oldest)},
+			Ref:      user,
+		}
+		if oldest >= 0 {
+			row.Cells[2] = fmt.Sprintf("%d days", oldest)
+			if oldest >= maxAge {
+				row.Colors[2] = tcell.ColorRed
+			}
+		}
+		if !user.MFA {
+			row.Colors[5] = tcell.ColorRed
+		}
+		// Unreadable keys or MFA devices are unknown, not missing
+		if user.KeysErr != nil {
+			row.Cells[1], row.Cells[2], row.Cells[3] = "?", "?", "?"
+			row.Colors[1] = tcell.ColorYellow
+		}
+		if user.MFAErr != nil {
+			row.Cells[5] = "?"
+			row.Colors[5] = tcell.ColorYellow
+		}
+		rows = append(rows, row)
+	}
+	table.SetRows(rows)
+
+	return table
+}
+
+func (p *IAMPage) rolesTab() tview.Primitive {
+	table := NewDataTable(p.session.Layout, "Roles", []Column{
+		{"Name", "name", 40},
+		{"Trusted By", "trusted_by", 50},
+		{"Last Used", "last_used", 20},
+		{"Region", "region", 14},
+		{"Created", "created", 20},
+		{"Description", "description", 50},
+	})
+	table.SetOpenFunc(func(row TableRow) {
+		p.session.Layout.Push(NewRolePage(p.session, row.Ref.(awsservices.IAMRole)))
+	})
+
+	roles, err := awsservices.ListIAMRoles(context.Background(), p.session.Config)
+	if err != nil {
+		table.SetError(err)
+		return table
+	}
+
+	var rows []TableRow
+	for _, role := range roles {
+		rows = append(rows, TableRow{
+			ID: role.Name,
+			Cells: []string{
+				role.Name,
+				orDash(strings.Join(awsservices.TrustedPrincipals(role.TrustPolicy), ", ")),
+				formatOptionalTime(role.LastUsed),
+				orDash(role.LastUsedRegion),
+				formatTime(role.Created),
+				orDash(role.Description),
+			},
+			Ref: role,
+		})
+	}
+	table.SetRows(rows)
+
+	return table
+}
+
+func (p *IAMPage) policiesTab() tview.Primitive {
+	table := NewDataTable(p.session.Layout, "Customer Managed Policies", []Column{
+		{"Name", "name", 40},
+		{"Attachments", "attachments", 12},
+		{"Version", "version", 8},
+		{"Updated", "updated", 20},
+		{"Description", "description", 60},
+	})
+	table.SetOpenFunc(func(row TableRow) {
+		policy := row.Ref.(awsservices.IAMPolicy)
+		showPolicy(p.session, awsservices.PolicyRef{Name: policy.Name, ARN: policy.ARN})
+	})
+
+	policies, err := awsservices.ListIAMPolicies(context.Background(), p.session.Config)
+	if err != nil {
+		table.SetError(err)
+		return table
+	}
+
+	var rows []TableRow
+	for _, policy := range policies {
+		row := TableRow{
+			ID: policy.ARN,
+			Cells: []string{
+				policy.Name,
+				fmt.Sprintf("%d", policy.Attachments),
+				policy.DefaultVersion,
+				formatTime(policy.Updated),
+				orDash(policy.Description),
+			},
+			Ref: policy,
+		}
+		if policy.Attachments == 0 {
+			row.Colors = map[int]tcell.Color{1: tcell.ColorGray}
+		}
+		rows = append(rows, row)
+	}
+	table.SetRows(rows)
+
+	return table
+}
+
+// UserPage represents the drill-down of an IAM user
+type UserPage struct {
+	*TabView
+	session *Session
+	user    awsservices.IAMUser
+}
+
+// NewUserPage creates a new page with the access keys and policies of a user
+func NewUserPage(session *Session, user awsservices.IAMUser) *UserPage {
+	page := &UserPage{
+		session: session,
+		user:    user,
+	}
+
+	page.TabView = NewTabView(session.Layout, "User: "+user.Name, []Tab{
+		{"Access Keys", page.accessKeysTab()},
+		{"Policies", newPolicyTable(session, func() ([]awsservices.PolicyRef, error) {
+			return awsservices.ListUserPolicies(context.Background(), session.Config, user.Name)
+		})},
+	})
+
+	return page
+}
+
+func (p *UserPage) accessKeysTab() tview.Primitive {
+	maxAge := p.session.Settings.AccessKeyMaxAgeDays
+	table := NewDataTable(p.session.Layout, fmt.Sprintf("Access Keys (flagged from %d days)", maxAge), []Column{
+		{"Access Key ID", "id", 22},
+		{"Status", "status", 10},
+		{"Created", "created", 20},
+		{"Age", "age", 10},
+		{"Last Used", "last_used", 20},
+		{"Service", "service", 20},
+		{"Region", "region", 14},
+	})
+
+	if p.user.KeysErr != nil {
+		table.SetError(p.user.KeysErr)
+		return table
+	}
+
+	now := time.Now()
+	var rows []TableRow
+	for _, key := range p.user.AccessKeys {
+		age := awsservices.KeyAgeDays(key.Created, now)
+		row := TableRow{
+			ID: key.ID,
+			Cells: []string{
+				key.ID,
+				"Active",
+				formatTime(key.Created),
+				fmt.Sprintf("%d days", age),
+				formatOptionalTime(key.LastUsed),
+				orDash(key.LastService),
+				orDash(key.LastRegion),
+			},
+			Colors:   map[int]tcell.Color{1: tcell.ColorGreen},
+			SortKeys: map[int]string{3: fmt.Sprintf("%d", age)},
+			Ref:      key,
+		}
+		if !key.Active {
+			row.Cells[1] = "Inactive"
+			row.Colors[1] = tcell.ColorGray
+		} else if age >= maxAge {
+			row.Colors[3] = tcell.ColorRed
+		}
+		rows = append(rows, row)
+	}
+	table.SetRows(rows)
+
+	return table
+}
+
+// RolePage represents the drill-down of an IAM role
+type RolePage struct {
+	*TabView
+	session *Session
+	role    awsservices.IAMRole
+}
+
+// NewRolePage creates a new page with the trust policy and policies of a role
+func NewRolePage(session *Session, role awsservices.IAMRole) *RolePage {
+	page := &RolePage{
+		session: session,
+		role:    role,
+	}
+
+	trust := tview.NewTextView()
+	trust.SetScrollable(true)
+	trust.SetText(role.TrustPolicy)
+
+	page.TabView = NewTabView(session.Layout, "Role: "+role.Name, []Tab{
+		{"Overview", page.overviewTab()},
+		{"Trust Policy", trust},
+		{"Policies", newPolicyTable(session, func() ([]awsservices.PolicyRef, error) {
+			return awsservices.ListRolePolicies(context.Background(), session.Config, role.Name)
+		})},
+	})
+	page.SetActions([]KeyAction{newAction('y', "Copy ARN", func() { copyText(session, role.ARN) })})
+
+	return page
+}
+
+func (p *RolePage) overviewTab() tview.Primitive {
+	role := p.role
+
+	lastUsed := formatOptionalTime(role.LastUsed)
+	if role.LastUsedRegion != "" {
+		lastUsed += " in " + role.LastUsedRegion
+	}
+
+	fields := [][2]string{
+		{"Name", role.Name},
+		{"ARN", role.ARN},
+		{"Path", role.Path},
+		{"Description", orDash(role.Description)},
+		{"Trusted By", orDash(strings.Join(awsservices.TrustedPrincipals(role.TrustPolicy), ", "))},
+		{"Max Session", role.MaxSession.String()},
+		{"Last Used", lastUsed},
+		{"Created", formatTime(role.Created)},
+	}
+
+	var b strings.Builder
+	for _, f := range fields {
+		fmt.Fprintf(&b, "[yellow]%-12s[-] %s\n", f[0], tview.Escape(f[1]))
+	}
+
+	view := tview.NewTextView().SetDynamicColors(true)
+	view.SetText(b.String())
+	return view
+}
+
+// newPolicyTable creates a table of the attached and inline policies of a user or role
+func newPolicyTable(session *Session, load func() ([]awsservices.PolicyRef, error)) *DataTable {
+	table := NewDataTable(session.Layout, "Policies", []Column{
+		{"Name", "name", 40},
+		{"Kind", "kind", 10},
+		{"ARN", "arn", 70},
+	})
+	table.SetOpenFunc(func(row TableRow) {
+		showPolicy(session, row.Ref.(awsservices.PolicyRef))
+	})
+
+	policies, err := load()
+	if err != nil {
+		table.SetError(err)
+		return table
+	}
+
+	var rows []TableRow
+	for _, policy := range policies {
+		kind := "Managed"
+		if policy.Inline {
+			kind = "Inline"
+		}
+		rows = append(rows, TableRow{
+			ID:    policy.Name + "/" + kind,
+			Cells: []string{policy.Name, kind, orDash(policy.ARN)},
+			Ref:   policy,
+		})
+	}
+	table.SetRows(rows)
+
+	return table
+}
+
+// showPolicy shows a policy document as formatted JSON, reading managed policies from IAM
+func showPolicy(session *Session, policy awsservices.PolicyRef) {
+	showDetail(session, "Policy: "+policy.Name, func() (string, error) {
+		if policy.Inline {
+			return policy.Document, nil
+		}
+		return awsservices.GetPolicyDocument(context.Background(), session.Config, policy.ARN)
+	})
+}
+
+// openIAMRole pushes the page of a role, such as the execution role of a Lambda function
+func openIAMRole(session *Session, name string) {
+	role, err := awsservices.GetIAMRole(context.Background(), session.Config, name)
+	if err != nil {
+		session.Layout.ShowError(err)
+		return
+	}
+	session.Layout.Push(NewRolePage(session, role))
+}
@@ -0,0 +1,226 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	awsservices "github.com/Ninad-Bhangui/awstui/aws/services"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// InstancePage represents the structured drill-down of an EC2 instance
+type InstancePage struct {
+	*TabView
+	session  *Session
+	detail   *awsservices.InstanceDetail
+	roleName string
+}
+
+// NewInstancePage creates a new instance page, returning an error if the instance cannot be loaded
+func NewInstancePage(session *Session, instanceID string) (*InstancePage, error) {
+	ctx := context.Background()
+
+	detail, err := awsservices.GetInstanceInfo(ctx, session.Config, instanceID)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &InstancePage{
+		session: session,
+		detail:  detail,
+	}
+
+	// Resolve the role behind the instance profile
+	if detail.InstanceProfileARN != "" {
+		if roleName, err := awsservices.GetInstanceProfileRole(ctx, session.Config, detail.InstanceProfileARN); err == nil {
+			page.roleName = roleName
+		}
+	}
+
+	title := "Instance: " + detail.ID
+	if detail.Name != "" {
+		title += " (" + detail.Name + ")"
+	}
+
+	page.TabView = NewTabView(session.Layout, title, []Tab{
+		{"Overview", page.overviewTab()},
+		{"Security Groups", page.securityGroupsTab()},
+		{"Volumes", page.volumesTab()},
+		{"Network Interfaces", page.networkInterfacesTab()},
+		{"Tags", page.tagsTab()},
+	})
+
+	if page.roleName != "" {
+		page.SetActions([]KeyAction{newAction('r', "Open role", page.openRole)})
+	}
+
+	return page, nil
+}
+
+func (p *InstancePage) overviewTab() tview.Primitive {
+	d := p.detail
+
+	role := "-"
+	if p.roleName != "" {
+		role = p.roleName
+	} else if d.InstanceProfileARN != "" {
+		role = d.InstanceProfileARN
+	}
+
+	fields := [][2]string{
+		{"Instance ID", d.ID},
+		{"Name", orDash(d.Name)},
+		{"State", d.State},
+		{"Type", d.Type},
+		{"AMI", d.ImageID},
+		{"Platform", orDash(d.Platform)},
+		{"Architecture", orDash(d.Architecture)},
+		{"Availability Zone", d.AvailabilityZone},
+		{"VPC", orDash(d.VPCID)},
+		{"Subnet", orDash(d.SubnetID)},
+		{"Private IP", d.PrivateIP},
+		{"Public IP", d.PublicIP},
+		{"Key Pair", orDash(d.KeyName)},
+		{"IAM Role", role},
+		{"Launch Time", formatTime(d.LaunchTime)},
+	}
+
+	var b strings.Builder
+	for _, f := range fields {
+		fmt.Fprintf(&b, "[yellow]%-18s[-] %s\n", f[0], tview.Escape(f[1]))
+	}
+
+	view := tview.NewTextView().SetDynamicColors(true)
+	view.SetText(b.String())
+	return view
+}
+
+func (p *InstancePage) securityGroupsTab() tview.Primitive {
+	table := NewDataTable(p.session.Layout, "Security Group Rules", []Column{
+		{"Group", "group", 30},
+		{"Direction", "direction", 10},
+		{"Protocol", "protocol", 10},
+		{"Ports", "ports", 12},
+		{"Peer", "peer", 40},
+		{"Description", "description", 40},
+	})
+
+	rules, err := awsservices.ListSecurityGroupRules(context.Background(), p.session.Config, p.detail.SecurityGroupIDs)
+	if err != nil {
+		table.SetError(err)
+		return table
+	}
+
+	var rows []TableRow
+	for i, rule := range rules {
+		row := TableRow{
+			ID:    fmt.Sprintf("%s/%d", rule.GroupID, i),
+			Cells: []string{rule.GroupID + " (" + rule.GroupName + ")", rule.Direction, rule.Protocol, rule.Ports, rule.Peer, orDash(rule.Description)},
+			Ref:   rule,
+		}
+		if rule.Peer == "0.0.0.0/0" || rule.Peer == "::/0" {
+			row.Colors = map[int]tcell.Color{4: tcell.ColorOrange}
+		}
+		rows = append(rows, row)
+	}
+	table.SetRows(rows)
+
+	table.SetOpenFunc(func(row TableRow) {
+		groupID := row.Ref.(awsservices.SecurityGroupRule).GroupID
+		showDetail(p.session, "Security Group: "+groupID, func() (string, error) {
+			return awsservices.GetSecurityGroupDetail(p.session.AWSConfig(), groupID)
+		})
+	})
+
+	return table
+}
+
+func (p *InstancePage) volumesTab() tview.Primitive {
+	table := NewDataTable(p.session.Layout, "Volumes", []Column{
+		{"Volume ID", "id", 25},
+		{"Device", "device", 12},
+		{"Type", "type", 8},
+		{"Size (GiB)", "size", 10},
+		{"IOPS", "iops", 8},
+		{"State", "state", 10},
+		{"Encrypted", "encrypted", 10},
+		{"Delete On Termination", "delete_on_termination", 10},
+	})
+
+	volumes, err := awsservices.ListInstanceVolumes(context.Background(), p.session.Config, p.detail.ID)
+	if err != nil {
+		table.SetError(err)
+		return table
+	}
+
+	var rows []TableRow
+	for _, vol := range volumes {
+		rows = append(rows, TableRow{
+			ID: vol.ID,
+			Cells: []string{
+				vol.ID,
+				orDash(vol.Device),
+				vol.Type,
+				fmt.Sprintf("%d", vol.SizeGiB),
+				fmt.Sprintf("%d", vol.IOPS),
+				vol.State,
+				fmt.Sprintf("%t", vol.Encrypted),
+				fmt.Sprintf("%t", vol.DeleteOnTermination),
+			},
+			Ref: vol,
+		})
+	}
+	table.SetRows(rows)
+
+	table.SetOpenFunc(func(row TableRow) {
+		showDetail(p.session, "Volume: "+row.ID, func() (string, error) {
+			return awsservices.GetVolumeDetail(p.session.AWSConfig(), row.ID)
+		})
+	})
+
+	return table
+}
+
+func (p *InstancePage) networkInterfacesTab() tview.Primitive {
+	table := NewDataTable(p.session.Layout, "Network Interfaces", []Column{
+		{"Interface ID", "id", 25},
+		{"Status", "status", 10},
+		{"Private IP", "private_ip", 15},
+		{"Public IP", "public_ip", 15},
+		{"Subnet", "subnet", 25},
+		{"MAC", "mac", 18},
+		{"Security Groups", "security_groups", 40},
+	})
+
+	var rows []TableRow
+	for _, eni := range p.detail.NetworkInterfaces {
+		rows = append(rows, TableRow{
+			ID:    eni.ID,
+			Cells: []string{eni.ID, eni.Status, eni.PrivateIP, eni.PublicIP, eni.SubnetID, eni.MACAddress, strings.Join(eni.SecurityGroups, ", ")},
+			Ref:   eni,
+		})
+	}
+	table.SetRows(rows)
+
+	table.SetOpenFunc(func(row TableRow) {
+		showDetail(p.session, "Network Interface: "+row.ID, func() (string, error) {
+			return awsservices.GetNetworkInterfaceDetail(p.session.AWSConfig(), row.ID)
+		})
+	})
+
+	return table
+}
+
+func (p *InstancePage) tagsTab() tview.Primitive {
+	return NewTagView(p.session, awsservices.TagTarget{
+		Service: "ec2",
+		ID:      p.detail.ID,
+		Name:    p.detail.ID,
+	})
+}
+
+func (p *InstancePage) openRole() {
+	openIAMRole(p.session, p.roleName)
+}
@@ -1,10 +1,58 @@
 package ui
 
 import (
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
 	"github.com/gdamore/tcell/v2"
 	"github.com/rivo/tview"
 )
 
+// helpText lists the key bindings available on every screen
+const helpText = `
+[::b]Navigation Keys[::-]
+  ↑/k         : Move up
+  ↓/j         : Move down
+  ←/h         : Move left/back
+  →/l         : Move right/forward
+  Enter       : Select item
+  
+[::b]General Commands[::-]
+  ?           : Toggle help
+  :           : Quick navigation (ec2, ecr, lambda, secrets, s3, logs, dynamodb, rds, ecs, cfn, sqs, sns, ssm, iam, eks, audit)
+  q/Esc       : Quit/Back
+  
+[::b]Profile Selector[::-]
+  /           : Fuzzy filter profiles (Esc clears)
+  f           : Pin or unpin a favorite
+  g           : Group by environment or account
+  a           : Add a profile
+  e           : Edit region and output
+  d           : Delete a profile
+  
+[::b]AWS Resources[::-]
+  :ec2        : EC2 Instances
+  :s3         : S3 Buckets
+  :lambda     : Lambda Functions
+  :logs       : CloudWatch Logs
+  :dynamodb   : DynamoDB Tables
+  :rds        : RDS Databases
+  :ecs        : ECS Clusters
+  :cfn        : CloudFormation Stacks
+  :sqs        : SQS Queues
+  :sns        : SNS Topics
+  :ssm        : SSM Parameters
+  :iam        : IAM Users, Roles and Policies
+  :eks        : EKS Clusters
+
+[::b]Tips[::-]
+  • Use vim-style navigation (hjkl) for faster movement
+  • Press ? again to return to previous screen
+  • More features coming soon!`
+
 // Layout represents the main application layout
 type Layout struct {
 	*tview.Grid
@@ -16,11 +64,18 @@ type Layout struct {
 	statusBar       *tview.TextView
 	helpPanel       *tview.TextView
 	app             *tview.Application
+	pages           *tview.Pages
+	views           []View // Navigation stack, last entry is shown
+	profile         string
+	readOnly        bool // Mutating actions are disabled
+	protected       bool // Changes need the profile name typed to confirm
+	sessionExpiry   time.Time
+	expiryTicker    sync.Once
 	showHelp        bool
 }
 
 // NewLayout creates a new application layout
-func NewLayout(app *tview.Application) *Layout {
+func NewLayout(app *tview.Application, pages *tview.Pages) *Layout {
 	layout := &Layout{
 		Grid:        tview.NewGrid(),
 		header:      tview.NewFlex(),
@@ -29,19 +84,23 @@ func NewLayout(app *tview.Application) *Layout {
 		statusBar:   tview.NewTextView(),
 		helpPanel:   tview.NewTextView(),
 		app:         app,
+		pages:       pages,
 		showHelp:    false,
 	}
 
 	// Set up header
 	layout.context.
+		SetDynamicColors(true).
 		SetTextColor(tcell.ColorWhite)
 
 	layout.keybindings.
+		SetDynamicColors(true).
 		SetTextAlign(tview.AlignRight).
 		SetTextColor(tcell.ColorWhite)
 
+	// Key hints get more room since views list every action
 	layout.header.AddItem(layout.context, 0, 1, false)
-	layout.header.AddItem(layout.keybindings, 0, 1, false)
+	layout.header.AddItem(layout.keybindings, 0, 2, false)
 
 	// Set up status bar
 	layout.statusBar.
@@ -52,27 +111,8 @@ func NewLayout(app *tview.Application) *Layout {
 	layout.helpPanel.SetBorder(true)
 	layout.helpPanel.SetTitle("Help & Key Bindings")
 	layout.helpPanel.SetTitleAlign(tview.AlignLeft)
-	layout.helpPanel.SetText(`
-[::b]Navigation Keys[::-]
-  ↑/k         : Move up
-  ↓/j         : Move down
-  ←/h         : Move left/back
-  →/l         : Move right/forward
-  Enter       : Select item
-  
-[::b]General Commands[::-]
-  ?           : Toggle help
-  q/Esc       : Quit/Back
-  
-[::b]AWS Resources (coming soon)[::-]
-  1           : EC2 Instances
-  2           : S3 Buckets
-  3           : Lambda Functions
-
-[::b]Tips[::-]
-  • Use vim-style navigation (hjkl) for faster movement
-  • Press ? again to return to previous screen
-  • More features coming soon!`)
+	layout.helpPanel.SetDynamicColors(true)
+	layout.helpPanel.SetText(helpText)
 
 	// Set up grid
 	layout.Grid.SetRows(1, 0, 1) // Header, content, status bar
@@ -85,12 +125,31 @@ func NewLayout(app *tview.Application) *Layout {
 
 	// Set up input capture for help toggle and vim navigation
 	layout.Grid.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		// Leave keys alone while the user is typing
+		if layout.IsTyping() {
+			return event
+		}
+
 		// Handle help toggle
 		if event.Rune() == '?' {
 			layout.ToggleHelp()
 			return nil
 		}
 
+		// Dispatch actions of the current view
+		if view := layout.CurrentView(); view != nil && !layout.showHelp && event.Key() == tcell.KeyRune {
+			for _, action := range view.Actions() {
+				if action.Key == event.Rune() {
+					if action.Mutating && layout.readOnly {
+						layout.SetStatus(fmt.Sprintf("Read-only mode: %s is disabled", action.Description))
+						return nil
+					}
+					action.Handler()
+					return nil
+				}
+			}
+		}
+
 		// Handle vim-style navigation
 		switch event.Rune() {
 		case 'j':
@@ -146,16 +205,302 @@ func (l *Layout) ToggleHelp() {
 		l.SetContext("Help")
 		l.SetKeybindings("<?> Back")
 		l.SetStatus("Viewing help")
+		l.helpPanel.SetText(helpText + l.viewHelp())
 	} else {
 		// Return to previous content if it exists
 		if l.previousContent != nil {
 			l.SetContent(l.previousContent)
 			l.app.SetFocus(l.previousContent)
 		}
+		if view := l.CurrentView(); view != nil && view == l.previousContent {
+			l.showView(view)
+		}
 	}
 }
 
+// viewHelp lists the actions of the current view for the help panel
+func (l *Layout) viewHelp() string {
+	view := l.CurrentView()
+	if view == nil || len(view.Actions()) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "\n\n[::b]%s[::-]\n", tview.Escape(view.GetTitle()))
+	for _, action := range view.Actions() {
+		line := fmt.Sprintf("  %-11s : %s", tview.Escape(keyLabel(action.Key)), action.Description)
+		if action.Mutating && l.readOnly {
+			line = "[gray]" + line + " (read-only)[-]"
+		}
+		b.WriteString(line + "\n")
+	}
+	return b.String()
+}
+
 // GetContent returns the current content primitive
 func (l *Layout) GetContent() tview.Primitive {
 	return l.content
 }
+
+// SetProfile sets the profile name shown in front of the breadcrumbs
+func (l *Layout) SetProfile(name string) {
+	l.profile = name
+}
+
+// SetReadOnly enables or disables read-only mode
+func (l *Layout) SetReadOnly(readOnly bool) {
+	l.readOnly = readOnly
+}
+
+// SetProtected marks the current profile as protected and shows a red banner in the header
+func (l *Layout) SetProtected(protected bool) {
+	l.protected = protected
+
+	background := tview.Styles.PrimitiveBackgroundColor
+	if protected {
+		background = tcell.ColorDarkRed
+	}
+	l.context.SetBackgroundColor(background)
+	l.keybindings.SetBackgroundColor(background)
+}
+
+// SetSessionExpiry shows the remaining lifetime of temporary credentials in the header
+func (l *Layout) SetSessionExpiry(expires time.Time) {
+	l.sessionExpiry = expires
+	if expires.IsZero() {
+		return
+	}
+
+	// Keep the countdown current
+	l.expiryTicker.Do(func() {
+		go func() {
+			for range time.Tick(30 * time.Second) {
+				l.app.QueueUpdateDraw(func() {
+					if view := l.CurrentView(); view != nil && view == l.content {
+						l.updateHeader(view)
+					}
+				})
+			}
+		}()
+	})
+}
+
+// Push shows a view on top of the navigation stack
+func (l *Layout) Push(view View) {
+	l.views = append(l.views, view)
+	l.showView(view)
+}
+
+// Pop returns to the previous view, reporting whether there was one
+func (l *Layout) Pop() bool {
+	if len(l.views) < 2 {
+		return false
+	}
+	l.views = l.views[:len(l.views)-1]
+	l.showView(l.views[len(l.views)-1])
+	return true
+}
+
+// PopToRoot drops every view above the first one on the stack
+func (l *Layout) PopToRoot() {
+	if len(l.views) > 1 {
+		l.views = l.views[:1]
+		l.showView(l.views[0])
+	}
+}
+
+// ResetViews clears the navigation stack
+func (l *Layout) ResetViews() {
+	l.views = nil
+}
+
+// CurrentView returns the view on top of the navigation stack
+func (l *Layout) CurrentView() View {
+	if len(l.views) == 0 {
+		return nil
+	}
+	return l.views[len(l.views)-1]
+}
+
+// OnStack reports whether a view is anywhere on the navigation stack, shown or not
+func (l *Layout) OnStack(view View) bool {
+	return slices.Contains(l.views, view)
+}
+
+// Refresh redraws the header for the current view after its actions changed
+func (l *Layout) Refresh() {
+	if view := l.CurrentView(); view != nil && view == l.content {
+		l.showView(view)
+	}
+}
+
+func (l *Layout) showView(view View) {
+	l.showHelp = false
+	l.SetContent(view)
+	l.updateHeader(view)
+}
+
+// updateHeader sets the breadcrumbs, badges and key hints for a view
+func (l *Layout) updateHeader(view View) {
+	titles := make([]string, 0, len(l.views))
+	for _, v := range l.views {
+		titles = append(titles, v.GetTitle())
+	}
+	context := tview.Escape(strings.Join(titles, " › "))
+	if l.profile != "" {
+		context = fmt.Sprintf("Profile: %s • %s", tview.Escape(l.profile), context)
+	}
+	if l.readOnly {
+		context = "[black:gray] READ-ONLY [-:-] " + context
+	}
+	if l.protected {
+		context = "[white::b] PROTECTED [-::-] " + context
+	}
+	if !l.sessionExpiry.IsZero() {
+		if remaining := time.Until(l.sessionExpiry); remaining > 0 {
+			context += fmt.Sprintf(" • Session: %s left", remaining.Round(time.Minute))
+		} else {
+			context += " • [red]Session expired[-]"
+		}
+	}
+	l.SetContext(context)
+	l.SetKeybindings(formatKeybindings(view.Actions(), l.readOnly))
+}
+
+// IsTyping reports whether keyboard focus is in a text input
+func (l *Layout) IsTyping() bool {
+	switch l.app.GetFocus().(type) {
+	case *tview.InputField, *tview.TextArea:
+		return true
+	}
+	return false
+}
+
+// QueueUpdateDraw runs f on the UI goroutine and redraws the screen
+func (l *Layout) QueueUpdateDraw(f func()) {
+	l.app.QueueUpdateDraw(f)
+}
+
+// Suspend stops the TUI while f runs, such as an interactive subprocess, then redraws it
+func (l *Layout) Suspend(f func()) bool {
+	return l.app.Suspend(f)
+}
+
+// ShowModal displays a primitive on top of the layout
+func (l *Layout) ShowModal(name string, modal tview.Primitive) {
+	l.pages.AddPage(name, modal, true, true)
+	l.app.SetFocus(modal)
+}
+
+// CloseModal removes a modal and returns focus to the content
+func (l *Layout) CloseModal(name string) {
+	l.pages.RemovePage(name)
+	if l.HasModal() {
+		_, front := l.pages.GetFrontPage()
+		l.app.SetFocus(front)
+		return
+	}
+	if l.content != nil {
+		l.app.SetFocus(l.content)
+	}
+}
+
+// ShowError displays an error in a modal dialog
+func (l *Layout) ShowError(err error) {
+	modal := tview.NewModal().
+		SetText(fmt.Sprintf("Error: %v", err)).
+		AddButtons([]string{"OK"}).
+		SetDoneFunc(func(buttonIndex int, buttonLabel string) {
+			l.CloseModal("error")
+		})
+	l.ShowModal("error", modal)
+}
+
+// Confirm asks the user to confirm an action before running it
+func (l *Layout) Confirm(text string, onConfirm func()) {
+	modal := tview.NewModal().
+		SetText(text).
+		AddButtons([]string{"Cancel", "Confirm"}).
+		SetDoneFunc(func(buttonIndex int, buttonLabel string) {
+			l.CloseModal("confirm")
+			if buttonLabel == "Confirm" {
+				onConfirm()
+			}
+		})
+	l.ShowModal("confirm", modal)
+}
+
+// Prompt asks the user for a single line of text
+func (l *Layout) Prompt(title, initial string, onDone func(text string)) {
+	input := tview.NewInputField().
+		SetText(initial).
+		SetFieldWidth(0)
+	input.SetDoneFunc(func(key tcell.Key) {
+		l.CloseModal("prompt")
+		if key == tcell.KeyEnter {
+			onDone(input.GetText())
+		}
+	})
+
+	input.SetBorder(true)
+	input.SetTitle(title)
+	input.SetTitleAlign(tview.AlignLeft)
+
+	l.ShowModal("prompt", centered(input, 60, 3))
+}
+
+// ConfirmTyped asks the user to type an exact value, such as the profile name, before running an action
+func (l *Layout) ConfirmTyped(text, expected string, onConfirm func()) {
+	message := tview.NewTextView().
+		SetDynamicColors(true).
+		SetWrap(true).
+		SetText(fmt.Sprintf("%s\n\nType [::b]%s[::-] to confirm.", tview.Escape(text), tview.Escape(expected)))
+
+	input := tview.NewInputField().
+		SetLabel("> ").
+		SetFieldWidth(0)
+	input.SetDoneFunc(func(key tcell.Key) {
+		switch key {
+		case tcell.KeyEnter:
+			if input.GetText() != expected {
+				input.SetLabel("[red]> [-]")
+				return
+			}
+			l.CloseModal("confirm")
+			onConfirm()
+		case tcell.KeyEscape:
+			l.CloseModal("confirm")
+		}
+	})
+
+	dialog := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(message, 0, 1, false).
+		AddItem(input, 1, 0, true)
+	dialog.SetBorder(true)
+	dialog.SetBorderColor(tcell.ColorRed)
+	dialog.SetTitle("Protected profile")
+	dialog.SetTitleAlign(tview.AlignLeft)
+
+	l.ShowModal("confirm", centered(dialog, 70, 9))
+}
+
+// centered wraps a primitive so it is drawn in the middle of the screen
+func centered(p tview.Primitive, width, height int) tview.Primitive {
+	return tview.NewFlex().
+		AddItem(nil, 0, 1, false).
+		AddItem(tview.NewFlex().SetDirection(tview.FlexRow).
+			AddItem(nil, 0, 1, false).
+			AddItem(p, height, 1, true).
+			AddItem(nil, 0, 1, false), width, 1, true).
+		AddItem(nil, 0, 1, false)
+}
+
+// HasModal reports whether a modal is shown on top of the layout
+func (l *Layout) HasModal() bool {
+	return l.pages.GetPageCount() > 1
+}
+
+// SetFocus moves keyboard focus to a primitive
+func (l *Layout) SetFocus(p tview.Primitive) {
+	l.app.SetFocus(p)
+}
@@ -0,0 +1,553 @@
+package ui
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	awsservices "github.com/Ninad-Bhangui/awstui/aws/services"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+const (
+	// followInterval is how often a followed log stream is checked for new events
+	followInterval = 2 * time.Second
+	// insightsPollInterval is how often a running Logs Insights query is checked
+	insightsPollInterval = time.Second
+	// maxLogLines limits how many lines the stream viewer keeps while following
+	maxLogLines = 10000
+)
+
+// defaultInsightsQuery is shown in the query editor when a profile has no history
+const defaultInsightsQuery = `fields @timestamp, @message
+| sort @timestamp desc
+| limit 100`
+
+// LogStreamList represents the streams of a log group, most recently written first
+type LogStreamList struct {
+	*DataTable
+	session *Session
+	group   string
+}
+
+// NewLogStreamList creates a new stream list for a log group
+func NewLogStreamList(session *Session, group string) *LogStreamList {
+	list := &LogStreamList{
+		DataTable: NewDataTable(session.Layout, "Streams: "+group, []Column{
+			{"Name", "name", 80},
+			{"Last Event", "last_event", 20},
+			{"First Event", "first_event", 20},
+		}),
+		session: session,
+		group:   group,
+	}
+
+	// Set up selection handler
+	list.SetOpenFunc(func(row TableRow) {
+		session.Layout.Push(NewLogViewer(session, group, row.ID))
+	})
+
+	list.LoadData()
+
+	return list
+}
+
+// Actions returns the key bindings for the stream list
+func (l *LogStreamList) Actions() []KeyAction {
+	return append(l.DataTable.Actions(),
+		newAction('i', "Insights query", func() { l.session.Layout.Push(NewInsightsView(l.session, []string{l.group})) }),
+	)
+}
+
+// LoadData loads the log streams from AWS
+func (l *LogStreamList) LoadData() {
+	streams, err := awsservices.ListLogStreams(context.Background(), l.session.Config, l.group)
+	if err != nil {
+		l.SetError(err)
+		return
+	}
+
+	var rows []TableRow
+	for _, stream := range streams {
+		rows = append(rows, TableRow{
+			ID:    stream.Name,
+			Cells: []string{stream.Name, formatOptionalTime(stream.LastEventAt), formatOptionalTime(stream.FirstEventAt)},
+			Ref:   stream,
+		})
+	}
+	l.SetRows(rows)
+}
+
+// LogViewer represents the events of a log stream, optionally following new events
+type LogViewer struct {
+	*tview.TextView
+	session *Session
+	group   string
+	stream  string
+	title   string
+	token   string        // Reads the events after those shown
+	follow  chan struct{} // Closed to stop following, nil when not following
+	wrap    bool
+}
+
+// NewLogViewer creates a new viewer showing the latest events of a stream
+func NewLogViewer(session *Session, group, stream string) *LogViewer {
+	viewer := &LogViewer{
+		TextView: tview.NewTextView(),
+		session:  session,
+		group:    group,
+		stream:   stream,
+		title:    "Stream: " + stream,
+		wrap:     true,
+	}
+
+	// Basic setup
+	viewer.SetBorder(true)
+	viewer.SetTitleAlign(tview.AlignLeft)
+	viewer.SetScrollable(true)
+	viewer.SetMaxLines(maxLogLines)
+	viewer.updateTitle()
+
+	viewer.load()
+
+	return viewer
+}
+
+// GetTitle returns the plain title of the viewer
+func (v *LogViewer) GetTitle() string {
+	return v.title
+}
+
+// Actions returns the key bindings for the stream viewer
+func (v *LogViewer) Actions() []KeyAction {
+	follow := "Follow"
+	if v.follow != nil {
+		follow = "Stop following"
+	}
+	return []KeyAction{
+		newAction('f', follow, v.toggleFollow),
+		newAction('w', "Toggle wrap", v.toggleWrap),
+	}
+}
+
+// load shows the latest events of the stream
+func (v *LogViewer) load() {
+	page, err := awsservices.GetLogEvents(context.Background(), v.session.Config, v.group, v.stream, "")
+	if err != nil {
+		v.SetText(fmt.Sprintf("Error: %v", err))
+		return
+	}
+	v.token = page.NextToken
+	v.appendEvents(page.Events)
+	if len(page.Events) == 0 {
+		v.session.Layout.SetStatus("No events in " + v.stream)
+	}
+}
+
+func (v *LogViewer) appendEvents(events []awsservices.LogEvent) {
+	for _, event := range events {
+		fmt.Fprintf(v, "%s  %s\n", event.Timestamp.Format("2006-01-02 15:04:05.000"), strings.TrimRight(event.Message, "\n"))
+	}
+	v.ScrollToEnd()
+}
+
+func (v *LogViewer) toggleFollow() {
+	if v.follow != nil {
+		v.stopFollowing()
+		v.session.Layout.SetStatus("Stopped following " + v.stream)
+	} else {
+		v.follow = make(chan struct{})
+		go v.poll(v.follow, v.token)
+		v.session.Layout.SetStatus("Following " + v.stream)
+	}
+	v.updateTitle()
+	v.session.Layout.Refresh()
+}
+
+func (v *LogViewer) stopFollowing() {
+	if v.follow != nil {
+		close(v.follow)
+		v.follow = nil
+	}
+}
+
+// poll appends new events until done is closed, stopping once the viewer is left
+func (v *LogViewer) poll(done chan struct{}, token string) {
+	ticker := time.NewTicker(followInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+		}
+
+		page, err := awsservices.GetLogEvents(context.Background(), v.session.Config, v.group, v.stream, token)
+		v.session.Layout.QueueUpdateDraw(func() {
+			// Ignore results that arrive after following stopped
+			if v.follow != done {
+				return
+			}
+			if v.session.Layout.CurrentView() != v {
+				v.stopFollowing()
+				v.updateTitle()
+				return
+			}
+			if err != nil {
+				v.stopFollowing()
+				v.updateTitle()
+				v.session.Layout.Refresh()
+				v.session.Layout.ShowError(err)
+				return
+			}
+			v.token = page.NextToken
+			v.appendEvents(page.Events)
+		})
+		if err != nil {
+			return
+		}
+		token = page.NextToken
+	}
+}
+
+func (v *LogViewer) toggleWrap() {
+	v.wrap = !v.wrap
+	v.SetWrap(v.wrap)
+}
+
+func (v *LogViewer) updateTitle() {
+	title := v.title
+	if v.follow != nil {
+		title += " [following]"
+	}
+	v.SetTitle(tview.Escape(title))
+}
+
+// InsightsView represents a Logs Insights query editor with its results
+type InsightsView struct {
+	*tview.Flex
+	session    *Session
+	groups     []string
+	title      string
+	editor     *tview.TextArea
+	rangeInput *tview.InputField
+	results    *DataTable
+	focus      tview.Primitive // Restored when the view is shown again
+	queryID    string          // The running query, "starting" until it has an ID and empty when none is
+	polling    chan struct{}   // Closed to stop polling the running query
+}
+
+// NewInsightsView creates a new Logs Insights query editor for log groups
+func NewInsightsView(session *Session, groups []string) *InsightsView {
+	title := "Logs Insights: " + groups[0]
+	if len(groups) > 1 {
+		title = fmt.Sprintf("Logs Insights: %d log groups", len(groups))
+	}
+
+	view := &InsightsView{
+		Flex:       tview.NewFlex().SetDirection(tview.FlexRow),
+		session:    session,
+		groups:     groups,
+		title:      title,
+		editor:     tview.NewTextArea(),
+		rangeInput: tview.NewInputField(),
+		results:    NewDataTable(session.Layout, "Results", nil),
+	}
+
+	// Start from the last query run with this profile
+	query := defaultInsightsQuery
+	if history := session.State.QueryHistory(session.Profile.Name); len(history) > 0 {
+		query = history[0]
+	}
+	view.editor.SetText(query, true)
+	view.editor.SetBorder(true)
+	view.editor.SetTitle("Query (Ctrl-R run • Tab switch • Esc results)")
+	view.editor.SetTitleAlign(tview.AlignLeft)
+
+	view.rangeInput.SetText("1h")
+	view.rangeInput.SetFieldWidth(0)
+	view.rangeInput.SetBorder(true)
+	view.rangeInput.SetTitle("Last")
+	view.rangeInput.SetTitleAlign(tview.AlignLeft)
+
+	view.results.SetOpenFunc(view.showRecord)
+
+	editorRow := tview.NewFlex().
+		AddItem(view.editor, 0, 1, true).
+		AddItem(view.rangeInput, 12, 0, false)
+	view.AddItem(editorRow, 8, 0, true)
+	view.AddItem(view.results, 0, 1, false)
+	view.focus = view.editor
+
+	// Run from anywhere in the view and move between the editor, range and results
+	view.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Key() {
+		case tcell.KeyCtrlR:
+			view.run()
+			return nil
+		case tcell.KeyTab:
+			switch view.focus {
+			case view.editor:
+				view.setFocus(view.rangeInput)
+			case view.rangeInput:
+				view.setFocus(view.results)
+			default:
+				view.setFocus(view.editor)
+			}
+			return nil
+		case tcell.KeyEscape:
+			if view.focus != view.results {
+				view.setFocus(view.results)
+				return nil
+			}
+		}
+		return event
+	})
+
+	return view
+}
+
+// GetTitle returns the plain title of the view
+func (v *InsightsView) GetTitle() string {
+	return v.title
+}
+
+// Focus restores the focus to the editor or the results, whichever had it last
+func (v *InsightsView) Focus(delegate func(p tview.Primitive)) {
+	delegate(v.focus)
+}
+
+// Actions returns the key bindings for the results of the query
+func (v *InsightsView) Actions() []KeyAction {
+	actions := append(v.results.Actions(),
+		newAction('e', "Edit query", func() { v.setFocus(v.editor) }),
+		newAction('r', "Run", v.run),
+		newAction('H', "History", v.showHistory),
+		newAction('x', "Export", func() { exportTable(v.session, v.results) }),
+	)
+	if v.queryID != "" {
+		actions = append(actions, newAction('s', "Stop query", v.stop))
+	}
+	return actions
+}
+
+func (v *InsightsView) setFocus(p tview.Primitive) {
+	v.focus = p
+	v.session.Layout.SetFocus(p)
+}
+
+// run starts the query in the editor and polls its results in the background
+func (v *InsightsView) run() {
+	query := strings.TrimSpace(v.editor.GetText())
+	if query == "" {
+		v.session.Layout.SetStatus("Enter a query first")
+		return
+	}
+	lookback, err := parseLookback(v.rangeInput.GetText())
+	if err != nil {
+		v.session.Layout.ShowError(err)
+		return
+	}
+
+	if v.queryID == "starting" {
+		v.session.Layout.SetStatus("A query is already starting")
+		return
+	}
+	v.stop()
+	v.session.State.AddQuery(v.session.Profile.Name, query)
+	if err := v.session.State.Save(); err != nil {
+		v.session.Layout.ShowError(fmt.Errorf("failed to save query history: %w", err))
+	}
+
+	end := time.Now()
+	start := end.Add(-lookback)
+	v.setFocus(v.results)
+	v.session.Layout.SetStatus("Starting query")
+
+	polling := make(chan struct{})
+	v.queryID = "starting"
+	v.polling = polling
+	v.session.Layout.Refresh()
+
+	go func() {
+		id, err := awsservices.StartInsightsQuery(context.Background(), v.session.Config, v.groups, query, start, end)
+		v.session.Layout.QueueUpdateDraw(func() {
+			// Stopped while starting, so the query is not wanted anymore
+			if v.polling != polling {
+				if err == nil {
+					go func() { _ = awsservices.StopInsightsQuery(context.Background(), v.session.Config, id) }()
+				}
+				return
+			}
+			if err != nil {
+				v.queryID = ""
+				v.polling = nil
+				v.session.Layout.Refresh()
+				v.session.Layout.ShowError(err)
+				return
+			}
+			v.queryID = id
+		})
+		if err == nil {
+			v.poll(polling, id)
+		}
+	}()
+}
+
+// poll shows the results of a query as they arrive until it finishes, is stopped or the view is closed
+func (v *InsightsView) poll(polling chan struct{}, id string) {
+	ticker := time.NewTicker(insightsPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-polling:
+			return
+		default:
+		}
+
+		result, err := awsservices.GetInsightsResults(context.Background(), v.session.Config, id)
+		done := err != nil || awsservices.InsightsQueryDone(result.Status)
+		v.session.Layout.QueueUpdateDraw(func() {
+			// Ignore queries stopped or replaced by a newer run
+			if v.polling != polling {
+				return
+			}
+			if !v.session.Layout.OnStack(v) {
+				v.stop()
+				return
+			}
+			if done {
+				v.queryID = ""
+				v.polling = nil
+				v.session.Layout.Refresh()
+			}
+			if err != nil {
+				v.session.Layout.ShowError(err)
+				return
+			}
+			v.showResults(result)
+		})
+		if done {
+			return
+		}
+		<-ticker.C
+	}
+}
+
+// stop cancels the running query, keeping the results found so far
+func (v *InsightsView) stop() {
+	if v.queryID == "" {
+		return
+	}
+	id := v.queryID
+	v.queryID = ""
+	close(v.polling)
+	v.polling = nil
+	v.session.Layout.Refresh()
+	// A query that is still starting is stopped once its ID is known
+	if id != "starting" {
+		go func() {
+			// The query may have finished in the meantime
+			_ = awsservices.StopInsightsQuery(context.Background(), v.session.Config, id)
+		}()
+	}
+	v.session.Layout.SetStatus("Query stopped")
+}
+
+func (v *InsightsView) showResults(result *awsservices.InsightsResult) {
+	// Keep the sort order while partial results of the same query arrive
+	columns := make([]Column, len(result.Fields))
+	for i, field := range result.Fields {
+		width := 40
+		if field == "@message" {
+			width = 120
+		}
+		columns[i] = Column{field, field, width}
+	}
+	if !slices.Equal(v.results.Columns(), columns) {
+		v.results.SetColumns(columns)
+	}
+
+	rows := make([]TableRow, len(result.Records))
+	for i, record := range result.Records {
+		cells := make([]string, len(result.Fields))
+		for j, field := range result.Fields {
+			cells[j] = record[field]
+		}
+		rows[i] = TableRow{
+			ID:    strconv.Itoa(i),
+			Cells: cells,
+			Ref:   record,
+		}
+	}
+	v.results.SetRows(rows)
+
+	v.session.Layout.SetStatus(fmt.Sprintf("Query %s • %d records • %.0f matched • %s scanned",
+		strings.ToLower(result.Status), len(result.Records), result.RecordsMatched, formatBytes(int64(result.BytesScanned))))
+}
+
+func (v *InsightsView) showRecord(row TableRow) {
+	showDetail(v.session, "Record "+row.ID, func() (string, error) {
+		data, err := json.MarshalIndent(row.Ref, "", "  ")
+		if err != nil {
+			return "", fmt.Errorf("failed to marshal record: %w", err)
+		}
+		return string(data), nil
+	})
+}
+
+// showHistory lets the user pick a previous query of the profile to edit
+func (v *InsightsView) showHistory() {
+	history := v.session.State.QueryHistory(v.session.Profile.Name)
+	if len(history) == 0 {
+		v.session.Layout.SetStatus("No queries have been run with " + v.session.Profile.Name)
+		return
+	}
+
+	list := tview.NewList().ShowSecondaryText(false)
+	for _, query := range history {
+		query := query
+		list.AddItem(tview.Escape(strings.Join(strings.Fields(query), " ")), "", 0, func() {
+			v.session.Layout.CloseModal("history")
+			v.editor.SetText(query, true)
+			v.setFocus(v.editor)
+		})
+	}
+	list.SetDoneFunc(func() {
+		v.session.Layout.CloseModal("history")
+	})
+	list.SetBorder(true)
+	list.SetTitle("Query history: " + tview.Escape(v.session.Profile.Name))
+	list.SetTitleAlign(tview.AlignLeft)
+
+	v.session.Layout.ShowModal("history", centered(list, 100, 20))
+}
+
+// parseLookback parses a query range such as "15m", "1h" or "7d"
+func parseLookback(text string) (time.Duration, error) {
+	text = strings.TrimSpace(text)
+	if days, ok := strings.CutSuffix(text, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err == nil && n > 0 {
+			return time.Duration(n) * 24 * time.Hour, nil
+		}
+	} else if d, err := time.ParseDuration(text); err == nil && d > 0 {
+		return d, nil
+	}
+	return 0, fmt.Errorf("invalid range %q, use a duration such as 15m, 1h or 7d", text)
+}
+
+// formatOptionalTime formats a time, showing a dash when it is not set
+func formatOptionalTime(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	return formatTime(t)
+}
@@ -0,0 +1,120 @@
+package ui
+
+import (
+	"fmt"
+
+	"github.com/Ninad-Bhangui/awstui/clipboard"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// valueMask hides a value without giving away its length
+const valueMask = "••••••••"
+
+// MaskedView shows the metadata of a secret value, keeping the value itself hidden until revealed.
+// The value is only fetched when it is revealed or copied.
+type MaskedView struct {
+	*tview.TextView
+	session  *Session
+	title    string
+	metadata string
+	load     func() (string, error)
+	value    string
+	loaded   bool
+	revealed bool
+}
+
+// NewMaskedView creates a new view of a masked value below its metadata lines
+func NewMaskedView(session *Session, title, metadata string, load func() (string, error)) *MaskedView {
+	view := &MaskedView{
+		TextView: tview.NewTextView(),
+		session:  session,
+		title:    title,
+		metadata: metadata,
+		load:     load,
+	}
+
+	// Basic setup
+	view.SetBorder(true)
+	view.SetTitle(tview.Escape(title))
+	view.SetTitleAlign(tview.AlignLeft)
+	view.SetTextColor(tcell.ColorWhite)
+	view.SetScrollable(true)
+	view.render()
+
+	return view
+}
+
+// GetTitle returns the plain title of the view
+func (v *MaskedView) GetTitle() string {
+	return v.title
+}
+
+// Actions returns the key bindings for the masked view
+func (v *MaskedView) Actions() []KeyAction {
+	label := "Reveal"
+	if v.revealed {
+		label = "Hide"
+	}
+	return []KeyAction{
+		newAction('v', label, v.toggle),
+		newAction('y', "Copy value", v.copyValue),
+	}
+}
+
+// Reveal shows the value, fetching it first if needed
+func (v *MaskedView) Reveal() {
+	if !v.fetch() {
+		return
+	}
+	v.revealed = true
+	v.render()
+	v.session.Layout.Refresh()
+}
+
+func (v *MaskedView) toggle() {
+	if v.revealed {
+		v.revealed = false
+		v.render()
+		v.session.Layout.Refresh()
+		return
+	}
+	v.Reveal()
+}
+
+// copyValue copies the value without showing it, not even in the status bar
+func (v *MaskedView) copyValue() {
+	if !v.fetch() {
+		return
+	}
+	if err := clipboard.Copy(v.value); err != nil {
+		v.session.Layout.ShowError(fmt.Errorf("failed to copy to clipboard: %w", err))
+		return
+	}
+	v.session.Layout.SetStatus("Copied the value of " + v.title)
+}
+
+// fetch loads the value once, reporting whether it is available
+func (v *MaskedView) fetch() bool {
+	if v.loaded {
+		return true
+	}
+	value, err := v.load()
+	if err != nil {
+		v.session.Layout.ShowError(err)
+		return false
+	}
+	v.value = value
+	v.loaded = true
+	return true
+}
+
+func (v *MaskedView) render() {
+	value := "[gray]" + valueMask + "  (press v to reveal)[-]"
+	if v.revealed {
+		value = tview.Escape(v.value)
+	}
+	v.SetDynamicColors(true)
+	v.SetText(tview.Escape(v.metadata) + "\n" + value)
+	v.ScrollToBeginning()
+}
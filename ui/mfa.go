@@ -0,0 +1,74 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// mfaTimeout bounds how long a credential refresh waits for an MFA code
+const mfaTimeout = 5 * time.Minute
+
+// mfaPrompt asks for MFA codes in a modal and is used as the stscreds TokenProvider
+type mfaPrompt struct {
+	layout      *Layout
+	profile     string
+	serial      string
+	interactive atomic.Bool // Prompting is only safe while credentials are resolved off the UI goroutine
+}
+
+// Token shows the MFA modal and blocks until a code is entered
+func (p *mfaPrompt) Token() (string, error) {
+	// Refreshing from a UI handler would block the very goroutine that draws the modal
+	if !p.interactive.Load() {
+		return "", fmt.Errorf("MFA session for %s expired, select the profile again to renew it", p.profile)
+	}
+
+	result := make(chan string, 1)
+	p.layout.QueueUpdateDraw(func() {
+		input := tview.NewInputField().
+			SetLabel("Code: ").
+			SetFieldWidth(0).
+			SetAcceptanceFunc(func(text string, ch rune) bool {
+				return len(text) <= 6 && ch >= '0' && ch <= '9'
+			})
+		input.SetDoneFunc(func(key tcell.Key) {
+			switch key {
+			case tcell.KeyEnter:
+				if len(input.GetText()) != 6 {
+					return
+				}
+				p.layout.CloseModal("mfa")
+				result <- input.GetText()
+			case tcell.KeyEscape:
+				p.layout.CloseModal("mfa")
+				result <- ""
+			}
+		})
+
+		input.SetBorder(true)
+		input.SetTitle(tview.Escape(fmt.Sprintf("MFA code for %s (%s)", p.profile, p.serial)))
+		input.SetTitleAlign(tview.AlignLeft)
+
+		p.layout.ShowModal("mfa", centered(input, 70, 3))
+		p.layout.SetStatus("Enter the MFA code to assume the role")
+	})
+
+	select {
+	case code := <-result:
+		if strings.TrimSpace(code) == "" {
+			return "", errors.New("MFA code entry cancelled")
+		}
+		return code, nil
+	case <-time.After(mfaTimeout):
+		p.layout.QueueUpdateDraw(func() {
+			p.layout.CloseModal("mfa")
+		})
+		return "", errors.New("timed out waiting for the MFA code")
+	}
+}
@@ -0,0 +1,184 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Ninad-Bhangui/awstui/aws"
+	"github.com/rivo/tview"
+)
+
+// noOutput is the output dropdown option that leaves the key unset
+const noOutput = "(not set)"
+
+// profileTypes are the kinds of profile the editor can create
+var profileTypes = []struct {
+	label  string
+	source aws.CredentialSource
+}{
+	{"Static keys", aws.SourceStatic},
+	{"SSO", aws.SourceSSO},
+	{"Assume role", aws.SourceAssumeRole},
+}
+
+// editableProfile returns the selected profile unless it is the default chain entry
+func (s *ProfileSelector) editableProfile() (aws.Profile, bool) {
+	profile, ok := s.profileAt(s.GetCurrentItem())
+	if !ok {
+		return aws.Profile{}, false
+	}
+	if profile.Source == aws.SourceDefault {
+		s.layout.SetStatus("The default chain is configured with environment variables, not profile files")
+		return aws.Profile{}, false
+	}
+	return profile, true
+}
+
+// addProfile asks for the kind of profile, then shows its form
+func (s *ProfileSelector) addProfile() {
+	var buttons []string
+	for _, t := range profileTypes {
+		buttons = append(buttons, t.label)
+	}
+	modal := tview.NewModal().
+		SetText("What kind of profile do you want to add?").
+		AddButtons(append(buttons, "Cancel")).
+		SetDoneFunc(func(buttonIndex int, buttonLabel string) {
+			s.layout.CloseModal("profile-type")
+			if buttonIndex >= 0 && buttonIndex < len(profileTypes) {
+				s.showProfileForm(profileTypes[buttonIndex].source)
+			}
+		})
+	s.layout.ShowModal("profile-type", modal)
+}
+
+// showProfileForm shows the fields needed to create a profile with the given credential source
+func (s *ProfileSelector) showProfileForm(source aws.CredentialSource) {
+	spec := aws.ProfileSpec{Source: source}
+	outputs := append([]string{noOutput}, aws.Outputs...)
+
+	form := tview.NewForm()
+	form.AddInputField("Name", "", 0, nil, func(text string) { spec.Name = strings.TrimSpace(text) })
+	form.AddInputField("Region", "", 0, nil, func(text string) { spec.Region = strings.TrimSpace(text) })
+	form.AddDropDown("Output", outputs, 0, func(option string, index int) { spec.Output = outputValue(option) })
+
+	switch source {
+	case aws.SourceStatic:
+		form.AddInputField("Access key ID", "", 0, nil, func(text string) { spec.AccessKeyID = strings.TrimSpace(text) })
+		form.AddPasswordField("Secret access key", "", 0, '*', func(text string) { spec.SecretAccessKey = strings.TrimSpace(text) })
+	case aws.SourceSSO:
+		// Suggest the first existing session, which needs no start URL or region
+		var session string
+		if sessions := s.profileMgr.GetSSOSessions(); len(sessions) > 0 {
+			session = sessions[0].Name
+			spec.SSOSession = session
+		}
+		form.AddInputField("SSO session", session, 0, nil, func(text string) { spec.SSOSession = strings.TrimSpace(text) })
+		form.AddInputField("Start URL (new session)", "", 0, nil, func(text string) { spec.SSOStartURL = strings.TrimSpace(text) })
+		form.AddInputField("SSO region (new session)", "", 0, nil, func(text string) { spec.SSORegion = strings.TrimSpace(text) })
+		form.AddInputField("Account ID", "", 0, nil, func(text string) { spec.SSOAccountID = strings.TrimSpace(text) })
+		form.AddInputField("Role name", "", 0, nil, func(text string) { spec.SSORoleName = strings.TrimSpace(text) })
+	case aws.SourceAssumeRole:
+		var names []string
+		for _, p := range s.profileMgr.GetAllProfiles() {
+			names = append(names, p.Name)
+		}
+		form.AddInputField("Role ARN", "", 0, nil, func(text string) { spec.RoleARN = strings.TrimSpace(text) })
+		if len(names) > 0 {
+			spec.SourceProfile = names[0]
+			form.AddDropDown("Source profile", names, 0, func(option string, index int) { spec.SourceProfile = option })
+		}
+		form.AddInputField("MFA serial (optional)", "", 0, nil, func(text string) { spec.MFASerial = strings.TrimSpace(text) })
+	}
+
+	form.AddButton("Save", func() {
+		if err := s.profileMgr.CreateProfile(spec); err != nil {
+			s.layout.ShowError(err)
+			return
+		}
+		s.layout.CloseModal("profile-form")
+		s.reload(spec.Name)
+		s.layout.SetStatus(fmt.Sprintf("Added profile %s", spec.Name))
+	})
+	form.AddButton("Cancel", func() {
+		s.layout.CloseModal("profile-form")
+	})
+	form.SetCancelFunc(func() {
+		s.layout.CloseModal("profile-form")
+	})
+
+	form.SetBorder(true)
+	form.SetTitle(fmt.Sprintf("New %s profile", source))
+	form.SetTitleAlign(tview.AlignLeft)
+
+	s.layout.ShowModal("profile-form", centered(form, 80, form.GetFormItemCount()*2+5))
+}
+
+// editProfile shows a form to change the region and output of a profile
+func (s *ProfileSelector) editProfile(profile aws.Profile) {
+	region, output := profile.Region, profile.Output
+	outputs := append([]string{noOutput}, aws.Outputs...)
+	selected := 0
+	for i, o := range outputs {
+		if o == output {
+			selected = i
+		}
+	}
+
+	form := tview.NewForm()
+	form.AddInputField("Region", region, 0, nil, func(text string) { region = strings.TrimSpace(text) })
+	form.AddDropDown("Output", outputs, selected, func(option string, index int) { output = outputValue(option) })
+	form.AddButton("Save", func() {
+		if err := s.profileMgr.UpdateProfile(profile.Name, region, output); err != nil {
+			s.layout.ShowError(err)
+			return
+		}
+		s.layout.CloseModal("profile-form")
+		s.reload(profile.Name)
+		s.layout.SetStatus(fmt.Sprintf("Updated profile %s", profile.Name))
+	})
+	form.AddButton("Cancel", func() {
+		s.layout.CloseModal("profile-form")
+	})
+	form.SetCancelFunc(func() {
+		s.layout.CloseModal("profile-form")
+	})
+
+	form.SetBorder(true)
+	form.SetTitle(tview.Escape("Edit profile " + profile.Name))
+	form.SetTitleAlign(tview.AlignLeft)
+
+	s.layout.ShowModal("profile-form", centered(form, 60, 9))
+}
+
+// deleteProfile removes a profile from the shared files after confirmation
+func (s *ProfileSelector) deleteProfile(profile aws.Profile) {
+	text := fmt.Sprintf("Delete profile %s from the AWS config and credentials files?\n\nThe previous files are kept with a .bak suffix.", profile.Name)
+	s.layout.Confirm(text, func() {
+		if err := s.profileMgr.DeleteProfile(profile.Name); err != nil {
+			s.layout.ShowError(err)
+			return
+		}
+		s.reload(profile.Name)
+		s.layout.SetStatus(fmt.Sprintf("Deleted profile %s", profile.Name))
+	})
+}
+
+// reload redraws the list after the files changed, selecting the changed profile if it still exists
+func (s *ProfileSelector) reload(selectName string) {
+	// Other profiles may use the changed one as their source, so check them all again
+	s.resetIdentities()
+	delete(s.mfaConfigs, selectName)
+
+	s.render()
+	s.selectProfile(selectName)
+	s.resolveIdentities()
+}
+
+// outputValue maps an output dropdown option to the value written to the file
+func outputValue(option string) string {
+	if option == noOutput {
+		return ""
+	}
+	return option
+}
@@ -0,0 +1,184 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Ninad-Bhangui/awstui/aws"
+	awsservices "github.com/Ninad-Bhangui/awstui/aws/services"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/smithy-go"
+	"github.com/rivo/tview"
+)
+
+const (
+	// identityWorkers limits how many profiles are resolved at the same time
+	identityWorkers = 8
+	// identityTimeout bounds the lookup of a single profile
+	identityTimeout = 15 * time.Second
+)
+
+// identityStatus represents the outcome of resolving a profile's identity
+type identityStatus int
+
+const (
+	identityPending identityStatus = iota
+	identityValid
+	identityExpired
+	identityNeedsMFA
+	identityError
+)
+
+// profileIdentity represents the cached identity of a profile
+type profileIdentity struct {
+	status   identityStatus
+	identity *awsservices.Identity
+	err      error
+}
+
+// expiredErrorCodes are API error codes returned for expired or revoked credentials
+var expiredErrorCodes = map[string]bool{
+	"ExpiredToken":          true,
+	"ExpiredTokenException": true,
+	"RequestExpired":        true,
+	"InvalidGrantException": true,
+	"UnauthorizedException": true,
+}
+
+// resolveIdentities looks up every profile's identity in the background, skipping cached results.
+// Each batch is tagged with the identity generation, so results that land after a reload are dropped.
+func (s *ProfileSelector) resolveIdentities() {
+	ctx, cancel := context.WithCancel(context.Background())
+	s.stopIdentities = cancel
+	gen := s.identityGen
+
+	workers := make(chan struct{}, identityWorkers)
+	for _, profile := range s.profiles() {
+		if _, cached := s.identities[profile.Name]; cached {
+			continue
+		}
+		s.identities[profile.Name] = &profileIdentity{status: identityPending}
+
+		go func(profile aws.Profile) {
+			workers <- struct{}{}
+			defer func() { <-workers }()
+			if ctx.Err() != nil {
+				return
+			}
+			result := s.lookupIdentity(ctx, profile, nil)
+			s.storeIdentity(gen, profile.Name, result)
+		}(profile)
+	}
+}
+
+// resetIdentities cancels the running lookups and empties the cache, so every profile is checked again
+func (s *ProfileSelector) resetIdentities() {
+	if s.stopIdentities != nil {
+		s.stopIdentities()
+	}
+	s.identityGen++
+	s.identities = make(map[string]*profileIdentity)
+}
+
+// refreshIdentity looks up a profile again with a config that is known to work
+func (s *ProfileSelector) refreshIdentity(profile aws.Profile, cfg config.Config) {
+	gen := s.identityGen
+	go func() {
+		result := s.lookupIdentity(context.Background(), profile, cfg)
+		s.storeIdentity(gen, profile.Name, result)
+	}()
+}
+
+// storeIdentity caches the result of a lookup unless the cache was reset since it started
+func (s *ProfileSelector) storeIdentity(gen int, name string, result *profileIdentity) {
+	s.layout.QueueUpdateDraw(func() {
+		if gen != s.identityGen {
+			return
+		}
+		s.identities[name] = result
+		s.render()
+	})
+}
+
+// lookupIdentity resolves the identity of a profile without prompting for anything
+func (s *ProfileSelector) lookupIdentity(ctx context.Context, profile aws.Profile, cfg config.Config) *profileIdentity {
+	ctx, cancel := context.WithTimeout(ctx, identityTimeout)
+	defer cancel()
+
+	if cfg == nil {
+		// MFA profiles would need a code, so wait until the user opens them
+		if profile.MFASerial != "" {
+			return &profileIdentity{status: identityNeedsMFA}
+		}
+
+		// An SSO profile without a valid token is expired without asking AWS
+		if profile.Source == aws.SourceSSO {
+			sso, err := aws.LoadSSOSettings(profile.Name)
+			if err != nil {
+				return &profileIdentity{status: identityError, err: err}
+			}
+			if sso != nil && !sso.HasValidToken() {
+				return &profileIdentity{status: identityExpired, err: errors.New("SSO token missing or expired")}
+			}
+		}
+
+		loaded, err := s.profileMgr.LoadConfig(ctx, profile.Name)
+		if err != nil {
+			return &profileIdentity{status: identityError, err: err}
+		}
+		cfg = loaded
+	}
+
+	identity, err := awsservices.GetIdentity(ctx, cfg)
+	if err != nil {
+		if isExpiredError(err) {
+			return &profileIdentity{status: identityExpired, err: err}
+		}
+		return &profileIdentity{status: identityError, err: err}
+	}
+	return &profileIdentity{status: identityValid, identity: identity}
+}
+
+// isExpiredError reports whether an error means the credentials have expired
+func isExpiredError(err error) bool {
+	var apiErr smithy.APIError
+	if errors.As(err, &apiErr) && expiredErrorCodes[apiErr.ErrorCode()] {
+		return true
+	}
+	return strings.Contains(strings.ToLower(err.Error()), "expired")
+}
+
+// describe returns the secondary list text with the badge and identity of a profile
+func (id *profileIdentity) describe(profile aws.Profile) string {
+	source := string(profile.Source)
+	if source == "" {
+		source = "unknown source"
+	}
+
+	switch id.status {
+	case identityValid:
+		account := id.identity.Account
+		if id.identity.Alias != "" {
+			account += " (" + id.identity.Alias + ")"
+		}
+		return fmt.Sprintf("  [green]● valid[-]    %s • %s • %s", tview.Escape(account), tview.Escape(id.identity.ARN), source)
+	case identityExpired:
+		return fmt.Sprintf("  [red]● expired[-]  %s", source)
+	case identityNeedsMFA:
+		return fmt.Sprintf("  [yellow]● mfa[-]      %s • select to enter an MFA code", source)
+	case identityError:
+		return fmt.Sprintf("  [orange]● error[-]    %s • %s", source, tview.Escape(firstLine(id.err.Error())))
+	}
+	return fmt.Sprintf("  [gray]… checking[-] %s", source)
+}
+
+// firstLine shortens multi-line SDK errors for the list
+func firstLine(text string) string {
+	if i := strings.IndexByte(text, '\n'); i >= 0 {
+		return text[:i]
+	}
+	return text
+}
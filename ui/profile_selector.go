@@ -3,26 +3,69 @@ package ui
 import (
 	"context"
 	"fmt"
+	"slices"
+	"sort"
+	"time"
 
 	"github.com/Ninad-Bhangui/awstui/aws"
+	"github.com/Ninad-Bhangui/awstui/fuzzy"
+	"github.com/Ninad-Bhangui/awstui/settings"
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials/stscreds"
 	"github.com/gdamore/tcell/v2"
 	"github.com/rivo/tview"
 )
 
+// groupModes are the ways the selector can group profiles, cycled with 'g'
+var groupModes = []string{"", "environment", "account"}
+
 // ProfileSelector represents the profile selection screen
 type ProfileSelector struct {
 	*tview.List
-	profileMgr *aws.ProfileManager
-	onSelect   func(aws.Profile, config.Config)
+	layout         *Layout
+	profileMgr     *aws.ProfileManager
+	settings       *settings.Settings
+	state          *settings.State
+	onSelect       func(aws.Profile, config.Config)
+	mfaConfigs     map[string]*mfaConfig       // Reused so assumed-role credentials last their full lifetime
+	identities     map[string]*profileIdentity // Cached for the whole session
+	identityGen    int                         // Bumped when the cache is reset, so older lookups are dropped
+	stopIdentities context.CancelFunc          // Cancels the lookups of the current batch
+	filter         string
+	entries        []profileEntry // What each list item shows, in list order
+}
+
+// profileEntry represents a list item, either a profile or a group header
+type profileEntry struct {
+	profile aws.Profile
+	header  string
+}
+
+// rankedProfile represents a profile that matches the filter
+type rankedProfile struct {
+	profile aws.Profile
+	score   int
+	order   int // Position in the shared files
+}
+
+// mfaConfig represents the config of an MFA profile together with its token prompt
+type mfaConfig struct {
+	cfg    config.Config
+	prompt *mfaPrompt
 }
 
 // NewProfileSelector creates a new profile selection screen
-func NewProfileSelector(onSelect func(aws.Profile, config.Config)) *ProfileSelector {
+func NewProfileSelector(layout *Layout, appSettings *settings.Settings, state *settings.State, onSelect func(aws.Profile, config.Config)) *ProfileSelector {
 	selector := &ProfileSelector{
 		List:       tview.NewList(),
+		layout:     layout,
 		profileMgr: aws.NewProfileManager(),
+		settings:   appSettings,
+		state:      state,
 		onSelect:   onSelect,
+		mfaConfigs: make(map[string]*mfaConfig),
+		identities: make(map[string]*profileIdentity),
 	}
 
 	// Basic list setup
@@ -32,26 +75,92 @@ func NewProfileSelector(onSelect func(aws.Profile, config.Config)) *ProfileSelec
 	selector.SetHighlightFullLine(true)
 	selector.SetSelectedBackgroundColor(tcell.ColorBlue)
 
+	// Filter, pin, group and edit profiles
+	selector.SetInputCapture(selector.handleKeys)
+
 	// Set up selection handler
 	selector.SetSelectedFunc(func(index int, mainText, secondaryText string, shortcut rune) {
-		if index >= 0 && index < len(selector.profileMgr.GetAllProfiles()) {
-			profile := selector.profileMgr.GetAllProfiles()[index]
+		if profile, ok := selector.profileAt(index); ok {
 
-			// Try to load AWS config
-			ctx := context.Background()
-			cfg, err := selector.profileMgr.LoadConfig(ctx, profile.Name)
+			// Log in first if the profile uses SSO and has no valid token
+			sso, err := aws.LoadSSOSettings(profile.Name)
 			if err != nil {
-				// Just add error as new item for now
-				selector.AddItem(fmt.Sprintf("Error: %v", err), "", 0, nil)
+				layout.ShowError(err)
 				return
 			}
-			onSelect(profile, cfg)
+			if sso != nil && !sso.HasValidToken() {
+				ssoLogin(layout, profile.Name, sso, func() {
+					selector.open(profile)
+				})
+				return
+			}
+
+			selector.open(profile)
 		}
 	})
 
 	return selector
 }
 
+// open loads the AWS config of a profile and hands it to the select handler
+func (s *ProfileSelector) open(profile aws.Profile) {
+	if profile.MFASerial != "" {
+		s.openWithMFA(profile)
+		return
+	}
+
+	cfg, err := s.profileMgr.LoadConfig(context.Background(), profile.Name)
+	if err != nil {
+		s.layout.ShowError(fmt.Errorf("failed to load profile %s: %w", profile.Name, err))
+		return
+	}
+	s.layout.SetSessionExpiry(time.Time{})
+	if id := s.identities[profile.Name]; id == nil || id.status != identityValid {
+		s.refreshIdentity(profile, cfg)
+	}
+	s.selected(profile, cfg)
+}
+
+// openWithMFA assumes the profile's role, prompting for an MFA code when there are no valid credentials
+func (s *ProfileSelector) openWithMFA(profile aws.Profile) {
+	cached, ok := s.mfaConfigs[profile.Name]
+	if !ok {
+		prompt := &mfaPrompt{layout: s.layout, profile: profile.Name, serial: profile.MFASerial}
+		cfg, err := s.profileMgr.LoadConfig(context.Background(), profile.Name,
+			config.WithAssumeRoleCredentialOptions(func(o *stscreds.AssumeRoleOptions) {
+				o.TokenProvider = prompt.Token
+			}))
+		if err != nil {
+			s.layout.ShowError(fmt.Errorf("failed to load profile %s: %w", profile.Name, err))
+			return
+		}
+		cached = &mfaConfig{cfg: cfg, prompt: prompt}
+		s.mfaConfigs[profile.Name] = cached
+	}
+
+	// Resolve credentials off the UI goroutine so the MFA modal can be drawn
+	s.layout.SetStatus("Assuming role for " + profile.Name)
+	go func() {
+		cached.prompt.interactive.Store(true)
+		creds, err := cached.cfg.(awssdk.Config).Credentials.Retrieve(context.Background())
+		cached.prompt.interactive.Store(false)
+
+		s.layout.QueueUpdateDraw(func() {
+			if err != nil {
+				s.layout.ShowError(fmt.Errorf("failed to assume role for %s: %w", profile.Name, err))
+				return
+			}
+			var expires time.Time
+			if creds.CanExpire {
+				expires = creds.Expires
+			}
+			s.layout.SetSessionExpiry(expires)
+			s.refreshIdentity(profile, cached.cfg)
+			s.selected(profile, cached.cfg)
+		})
+	}()
+}
+
 // LoadProfiles loads and displays the AWS profiles
 func (s *ProfileSelector) LoadProfiles() error {
 	// Load profiles
@@ -60,13 +169,262 @@ func (s *ProfileSelector) LoadProfiles() error {
 		return err
 	}
 
-	// Clear existing items
+	s.render()
+
+	// Set initial selection
+	for _, p := range s.profiles() {
+		if p.IsFromEnv {
+			s.selectProfile(p.Name)
+			break
+		}
+	}
+
+	// Check every profile's credentials in the background
+	s.resolveIdentities()
+
+	return nil
+}
+
+// profiles returns the profiles from the shared files followed by the default chain entry
+func (s *ProfileSelector) profiles() []aws.Profile {
+	return append(s.profileMgr.GetAllProfiles(), s.profileMgr.EnvironmentProfile())
+}
+
+// AutoSelect opens the default chain profile when there are no profile files, reporting whether it did
+func (s *ProfileSelector) AutoSelect() bool {
+	if s.profileMgr.HasProfileFiles() {
+		return false
+	}
+	s.open(s.profileMgr.EnvironmentProfile())
+	return true
+}
+
+// selected remembers a profile as recently used and hands it to the select handler
+func (s *ProfileSelector) selected(profile aws.Profile, cfg config.Config) {
+	s.state.UseProfile(profile.Name)
+	s.render()
+	s.onSelect(profile, cfg)
+	if err := s.state.Save(); err != nil {
+		s.layout.ShowError(fmt.Errorf("failed to save recent profiles: %w", err))
+	}
+}
+
+// handleKeys filters, pins and groups profiles and opens the profile editor
+func (s *ProfileSelector) handleKeys(event *tcell.EventKey) *tcell.EventKey {
+	if event.Key() == tcell.KeyEscape && s.filter != "" {
+		s.setFilter("")
+		return nil
+	}
+	if event.Key() != tcell.KeyRune {
+		return event
+	}
+
+	switch event.Rune() {
+	case '/':
+		s.promptFilter()
+	case 'f':
+		if profile, ok := s.profileAt(s.GetCurrentItem()); ok {
+			s.toggleFavorite(profile)
+		}
+	case 'g':
+		s.cycleGroups()
+	case 'a':
+		s.addProfile()
+	case 'e':
+		if profile, ok := s.editableProfile(); ok {
+			s.editProfile(profile)
+		}
+	case 'd':
+		if profile, ok := s.editableProfile(); ok {
+			s.deleteProfile(profile)
+		}
+	default:
+		return event
+	}
+	return nil
+}
+
+// promptFilter shows an input that filters the list while typing
+func (s *ProfileSelector) promptFilter() {
+	input := tview.NewInputField().
+		SetText(s.filter).
+		SetFieldWidth(0).
+		SetChangedFunc(s.setFilter)
+	input.SetDoneFunc(func(key tcell.Key) {
+		s.layout.CloseModal("filter")
+		if key == tcell.KeyEscape {
+			s.setFilter("")
+		}
+	})
+
+	input.SetBorder(true)
+	input.SetTitle("Filter profiles (Enter to keep, Esc to clear)")
+	input.SetTitleAlign(tview.AlignLeft)
+
+	s.layout.ShowModal("filter", centered(input, 60, 3))
+}
+
+// setFilter narrows the list to fuzzy matches, selecting the best one
+func (s *ProfileSelector) setFilter(filter string) {
+	s.filter = filter
+	s.render()
+	for i, e := range s.entries {
+		if e.header == "" {
+			s.SetCurrentItem(i)
+			break
+		}
+	}
+}
+
+// toggleFavorite pins or unpins a profile at the top of the list
+func (s *ProfileSelector) toggleFavorite(profile aws.Profile) {
+	favorite := s.state.ToggleFavorite(profile.Name)
+	if err := s.state.Save(); err != nil {
+		s.layout.ShowError(fmt.Errorf("failed to save favorites: %w", err))
+	}
+	s.render()
+	if favorite {
+		s.layout.SetStatus(fmt.Sprintf("Pinned %s to the top", profile.Name))
+	} else {
+		s.layout.SetStatus(fmt.Sprintf("Unpinned %s", profile.Name))
+	}
+}
+
+// cycleGroups switches between no grouping, environment labels and account IDs
+func (s *ProfileSelector) cycleGroups() {
+	next := (slices.Index(groupModes, s.state.GroupBy) + 1) % len(groupModes)
+	s.state.GroupBy = groupModes[next]
+	if err := s.state.Save(); err != nil {
+		s.layout.ShowError(fmt.Errorf("failed to save grouping: %w", err))
+	}
+	s.render()
+	if s.state.GroupBy == "" {
+		s.layout.SetStatus("Profiles are not grouped")
+	} else {
+		s.layout.SetStatus("Grouping profiles by " + s.state.GroupBy)
+	}
+}
+
+// profileAt returns the profile shown at a list index, if it is not a group header
+func (s *ProfileSelector) profileAt(index int) (aws.Profile, bool) {
+	if index < 0 || index >= len(s.entries) || s.entries[index].header != "" {
+		return aws.Profile{}, false
+	}
+	return s.entries[index].profile, true
+}
+
+// selectProfile moves the selection to a profile, reporting whether it is shown
+func (s *ProfileSelector) selectProfile(name string) bool {
+	for i, e := range s.entries {
+		if e.header == "" && e.profile.Name == name {
+			s.SetCurrentItem(i)
+			return true
+		}
+	}
+	return false
+}
+
+// orderedEntries returns the profiles matching the filter with favorites first, then
+// recently used ones, then the rest in file order, split into groups if enabled
+func (s *ProfileSelector) orderedEntries() []profileEntry {
+	var ranked []rankedProfile
+	for i, p := range s.profiles() {
+		if score, ok := fuzzy.Match(s.filter, p.Name); ok {
+			ranked = append(ranked, rankedProfile{profile: p, score: score, order: i})
+		}
+	}
+
+	sort.SliceStable(ranked, func(i, j int) bool {
+		a, b := ranked[i], ranked[j]
+		if a.score != b.score {
+			return a.score > b.score
+		}
+		if fa, fb := s.state.IsFavorite(a.profile.Name), s.state.IsFavorite(b.profile.Name); fa != fb {
+			return fa
+		}
+		ra, rb := s.state.RecentRank(a.profile.Name), s.state.RecentRank(b.profile.Name)
+		if (ra >= 0) != (rb >= 0) {
+			return ra >= 0
+		}
+		if ra != rb {
+			return ra < rb
+		}
+		return a.order < b.order
+	})
+
+	if s.state.GroupBy == "" {
+		entries := make([]profileEntry, len(ranked))
+		for i, r := range ranked {
+			entries[i] = profileEntry{profile: r.profile}
+		}
+		return entries
+	}
+
+	// Groups keep the order of their best profile, with ungrouped profiles last
+	var labels []string
+	groups := make(map[string][]aws.Profile)
+	for _, r := range ranked {
+		label := s.groupLabel(r.profile)
+		if _, ok := groups[label]; !ok && label != "" {
+			labels = append(labels, label)
+		}
+		groups[label] = append(groups[label], r.profile)
+	}
+	if _, ok := groups[""]; ok {
+		labels = append(labels, "")
+	}
+
+	var entries []profileEntry
+	for _, label := range labels {
+		header := label
+		if header == "" {
+			header = "no " + s.state.GroupBy
+		}
+		entries = append(entries, profileEntry{header: fmt.Sprintf("%s (%d)", header, len(groups[label]))})
+		for _, p := range groups[label] {
+			entries = append(entries, profileEntry{profile: p})
+		}
+	}
+	return entries
+}
+
+// groupLabel returns the group of a profile for the current grouping, or an empty string
+func (s *ProfileSelector) groupLabel(profile aws.Profile) string {
+	switch s.state.GroupBy {
+	case "environment":
+		return s.settings.Environment(profile.Name)
+	case "account":
+		// Prefer the account the credentials resolved to over the one the settings name
+		account := profile.AccountID()
+		if id := s.identities[profile.Name]; id != nil && id.status == identityValid {
+			account = id.identity.Account
+		}
+		if account == "" {
+			return ""
+		}
+		return "account " + s.settings.AccountGroup(account)
+	}
+	return ""
+}
+
+// render rebuilds the list items, keeping the selected profile
+func (s *ProfileSelector) render() {
+	current := s.GetCurrentItem()
+	selected, hasSelection := s.profileAt(current)
 	s.Clear()
 
-	// Add profiles to list
-	var activeProfileIndex int
-	for i, p := range s.profileMgr.GetAllProfiles() {
+	s.entries = s.orderedEntries()
+	for _, e := range s.entries {
+		if e.header != "" {
+			s.AddItem("[yellow::b]── "+tview.Escape(e.header)+" ──[-::-]", "", 0, nil)
+			continue
+		}
+
+		p := e.profile
 		name := p.Name
+		if s.state.IsFavorite(p.Name) {
+			name = "★ " + name
+		}
 		if p.Region != "" {
 			name += fmt.Sprintf(" (region: %s)", p.Region)
 		}
@@ -75,13 +433,25 @@ func (s *ProfileSelector) LoadProfiles() error {
 		}
 		if p.IsFromEnv {
 			name += " [active]"
-			activeProfileIndex = i
 		}
-		s.AddItem(name, "", 0, nil)
+
+		id, ok := s.identities[p.Name]
+		if !ok {
+			id = &profileIdentity{status: identityPending}
+		}
+		s.AddItem(tview.Escape(name), id.describe(p), 0, nil)
 	}
 
-	// Set initial selection
-	s.SetCurrentItem(activeProfileIndex)
+	if !hasSelection || !s.selectProfile(selected.Name) {
+		s.SetCurrentItem(current)
+	}
 
-	return nil
+	title := "AWS Profiles"
+	if s.filter != "" {
+		title += fmt.Sprintf(" [/%s]", s.filter)
+	}
+	if s.state.GroupBy != "" {
+		title += " by " + s.state.GroupBy
+	}
+	s.SetTitle(tview.Escape(title))
 }
@@ -0,0 +1,277 @@
+package ui
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	awsservices "github.com/Ninad-Bhangui/awstui/aws/services"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// DatabasePage represents the drill-down of an RDS DB instance or DB cluster
+type DatabasePage struct {
+	*TabView
+	session *Session
+	db      awsservices.RDSDatabase
+}
+
+// NewDatabasePage creates a new page with the snapshots, parameters and pending maintenance of a database
+func NewDatabasePage(session *Session, db awsservices.RDSDatabase) *DatabasePage {
+	page := &DatabasePage{
+		session: session,
+		db:      db,
+	}
+
+	title := "DB Instance: " + db.ID
+	if db.Cluster {
+		title = "DB Cluster: " + db.ID
+	}
+
+	page.TabView = NewTabView(session.Layout, title, []Tab{
+		{"Overview", page.overviewTab()},
+		{"Snapshots", page.snapshotsTab()},
+		{"Parameters", page.parametersTab()},
+		{"Maintenance", page.maintenanceTab()},
+	})
+	page.SetActions(databaseActions(session, func() (awsservices.RDSDatabase, bool) { return db, true }, func() {}))
+
+	return page
+}
+
+func (p *DatabasePage) overviewTab() tview.Primitive {
+	db := p.db
+
+	kind := "DB instance"
+	if db.Cluster {
+		kind = "DB cluster"
+	}
+	endpoint := "-"
+	if db.Endpoint != "" {
+		endpoint = fmt.Sprintf("%s:%d", db.Endpoint, db.Port)
+	}
+
+	fields := [][2]string{
+		{"Identifier", db.ID},
+		{"Type", kind},
+		{"Cluster", orDash(db.ClusterID)},
+		{"Engine", db.Engine},
+		{"Engine Version", db.EngineVersion},
+		{"Class", orDash(db.Class)},
+		{"Status", db.Status},
+		{"Multi-AZ", yesNo(db.MultiAZ)},
+		{"Endpoint", endpoint},
+		{"Storage", formatStorage(db)},
+		{"Master Username", orDash(db.MasterUsername)},
+		{"Database Name", orDash(db.DatabaseName)},
+		{"Parameter Group", orDash(db.ParameterGroup)},
+		{"Created", formatOptionalTime(db.CreatedAt)},
+		{"ARN", db.ARN},
+	}
+
+	var b strings.Builder
+	for _, f := range fields {
+		fmt.Fprintf(&b, "[yellow]%-18s[-] %s\n", f[0], tview.Escape(f[1]))
+	}
+
+	view := tview.NewTextView().SetDynamicColors(true)
+	view.SetText(b.String())
+	return view
+}
+
+func (p *DatabasePage) snapshotsTab() tview.Primitive {
+	table := NewDataTable(p.session.Layout, "Snapshots", []Column{
+		{"Snapshot", "snapshot", 50},
+		{"Type", "type", 12},
+		{"Status", "status", 12},
+		{"Storage (GiB)", "storage", 14},
+		{"Encrypted", "encrypted", 10},
+		{"Created", "created", 20},
+	})
+
+	snapshots, err := awsservices.ListRDSSnapshots(context.Background(), p.session.Config, p.db)
+	if err != nil {
+		table.SetError(err)
+		return table
+	}
+
+	var rows []TableRow
+	for _, snapshot := range snapshots {
+		rows = append(rows, TableRow{
+			ID:    snapshot.ID,
+			Cells: []string{snapshot.ID, snapshot.Type, snapshot.Status, fmt.Sprintf("%d", snapshot.StorageGiB), yesNo(snapshot.Encrypted), formatOptionalTime(snapshot.CreatedAt)},
+			Ref:   snapshot,
+		})
+	}
+	table.SetRows(rows)
+	table.SortBy(5, true)
+
+	return table
+}
+
+func (p *DatabasePage) parametersTab() tview.Primitive {
+	table := NewDataTable(p.session.Layout, "Parameter Group: "+orDash(p.db.ParameterGroup), []Column{
+		{"Name", "name", 50},
+		{"Value", "value", 50},
+		{"Source", "source", 16},
+		{"Apply Type", "apply_type", 10},
+		{"Modifiable", "modifiable", 10},
+	})
+
+	params, err := awsservices.ListRDSParameters(context.Background(), p.session.Config, p.db)
+	if err != nil {
+		table.SetError(err)
+		return table
+	}
+
+	var rows []TableRow
+	for _, param := range params {
+		row := TableRow{
+			ID:    param.Name,
+			Cells: []string{param.Name, orDash(param.Value), param.Source, param.ApplyType, yesNo(param.Modifiable)},
+			Ref:   param,
+		}
+		// Values changed from the engine defaults stand out
+		if param.Source == "user" {
+			row.Colors = map[int]tcell.Color{1: tcell.ColorAqua, 2: tcell.ColorAqua}
+		}
+		rows = append(rows, row)
+	}
+	table.SetRows(rows)
+
+	table.SetOpenFunc(func(row TableRow) {
+		showDetail(p.session, "Parameter: "+row.ID, func() (string, error) {
+			data, err := json.MarshalIndent(row.Ref, "", "  ")
+			if err != nil {
+				return "", fmt.Errorf("failed to marshal parameter: %w", err)
+			}
+			return string(data), nil
+		})
+	})
+
+	return table
+}
+
+func (p *DatabasePage) maintenanceTab() tview.Primitive {
+	table := NewDataTable(p.session.Layout, "Pending Maintenance", []Column{
+		{"Action", "action", 25},
+		{"Description", "description", 60},
+		{"Opt-in", "opt_in", 15},
+		{"Auto Applied After", "auto_applied", 20},
+		{"Forced Apply", "forced", 20},
+	})
+
+	actions, err := awsservices.ListRDSMaintenance(context.Background(), p.session.Config, p.db)
+	if err != nil {
+		table.SetError(err)
+		return table
+	}
+
+	var rows []TableRow
+	for i, action := range actions {
+		rows = append(rows, TableRow{
+			ID:    fmt.Sprintf("%s/%d", action.Action, i),
+			Cells: []string{action.Action, orDash(action.Description), orDash(action.OptInStatus), formatTimePtr(action.AutoAppliedAfter), formatTimePtr(action.ForcedApplyDate)},
+			Ref:   action,
+		})
+	}
+	table.SetRows(rows)
+
+	return table
+}
+
+// databaseActions returns the key bindings shared by the database list and page. selected
+// returns the database to act on, and onChange runs after it was started or stopped.
+func databaseActions(session *Session, selected func() (awsservices.RDSDatabase, bool), onChange func()) []KeyAction {
+	return []KeyAction{
+		newAction('c', "Copy connection", func() {
+			if db, ok := selected(); ok {
+				copyConnection(session, db)
+			}
+		}),
+		newWriteAction('s', "Start", func() {
+			if db, ok := selected(); ok {
+				changeDatabase(session, db, "start", onChange)
+			}
+		}),
+		newWriteAction('S', "Stop", func() {
+			if db, ok := selected(); ok {
+				changeDatabase(session, db, "stop", onChange)
+			}
+		}),
+	}
+}
+
+// copyConnection copies a psql or mysql command for the database, leaving the password to be typed
+func copyConnection(session *Session, db awsservices.RDSDatabase) {
+	command, err := awsservices.RDSConnectionCommand(db)
+	if err != nil {
+		session.Layout.ShowError(err)
+		return
+	}
+	copyText(session, command)
+}
+
+// changeDatabase starts or stops a database after confirmation and records it in the audit log
+func changeDatabase(session *Session, db awsservices.RDSDatabase, verb string, onChange func()) {
+	change, action := awsservices.StartRDSDatabase, awsservices.RDSStartAction(db)
+	if verb == "stop" {
+		change, action = awsservices.StopRDSDatabase, awsservices.RDSStopAction(db)
+	}
+
+	text := fmt.Sprintf("%s %s (%s, currently %s)?", strings.ToUpper(verb[:1])+verb[1:], db.ID, db.Engine, db.Status)
+	if verb == "stop" && !db.Cluster {
+		text += "\n\nStopped instances start again automatically after 7 days."
+	}
+
+	session.ConfirmChange(text, func() {
+		err := change(context.Background(), session.Config, db)
+		session.Audit(action, []string{db.ARN}, nil, err)
+		if err != nil {
+			session.Layout.ShowError(err)
+			return
+		}
+		session.Layout.SetStatus(fmt.Sprintf("Requested %s of %s", verb, db.ID))
+		onChange()
+	})
+}
+
+// rdsStatusColor colors available databases green, stopped ones red and the rest yellow
+func rdsStatusColor(status string) tcell.Color {
+	switch status {
+	case "available":
+		return tcell.ColorGreen
+	case "stopped", "failed", "inaccessible-encryption-credentials":
+		return tcell.ColorRed
+	}
+	return tcell.ColorYellow
+}
+
+// formatStorage renders the allocated storage of a database, which Aurora does not report
+func formatStorage(db awsservices.RDSDatabase) string {
+	if db.StorageGiB == 0 || strings.HasPrefix(db.Engine, "aurora") {
+		return orDash(db.StorageType)
+	}
+	storage := fmt.Sprintf("%d GiB", db.StorageGiB)
+	if db.StorageType != "" {
+		storage += " " + db.StorageType
+	}
+	return storage
+}
+
+func formatTimePtr(t *time.Time) string {
+	if t == nil {
+		return "-"
+	}
+	return formatTime(*t)
+}
+
+func yesNo(b bool) string {
+	if b {
+		return "Yes"
+	}
+	return "No"
+}
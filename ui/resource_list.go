@@ -3,19 +3,18 @@ package ui
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	awsservices "github.com/Ninad-Bhangui/awstui/aws/services"
-	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/gdamore/tcell/v2"
-	"github.com/rivo/tview"
 )
 
 // ResourceList represents a list of AWS resources
 type ResourceList struct {
-	*tview.Table
+	*DataTable
 	resourceType string
-	cfg          config.Config
+	session      *Session
 }
 
 // Column represents a table column configuration
@@ -52,33 +51,89 @@ var resourceColumns = map[string][]Column{
 		{"Last Modified", "modified", 20},
 		{"Days Until Rotation", "rotation", 15},
 	},
+	"s3": {
+		{"Name", "name", 50},
+		{"Region", "region", 15},
+		{"Created", "created", 20},
+		{"Versioning", "versioning", 12},
+	},
+	"logs": {
+		{"Name", "name", 70},
+		{"Retention", "retention", 12},
+		{"Stored", "stored", 12},
+		{"Created", "created", 20},
+	},
+	"dynamodb": {
+		{"Name", "name", 40},
+		{"Status", "status", 10},
+		{"Items", "items", 12},
+		{"Size", "size", 12},
+		{"Billing", "billing", 16},
+		{"Keys", "keys", 40},
+	},
+	"rds": {
+		{"Identifier", "id", 35},
+		{"Kind", "kind", 10},
+		{"Engine", "engine", 18},
+		{"Version", "version", 12},
+		{"Class", "class", 16},
+		{"Status", "status", 14},
+		{"Multi-AZ", "multi_az", 8},
+		{"Endpoint", "endpoint", 60},
+		{"Storage", "storage", 14},
+	},
+	"ecs": {
+		{"Name", "name", 40},
+		{"Status", "status", 10},
+		{"Services", "services", 10},
+		{"Running", "running", 10},
+		{"Pending", "pending", 10},
+	},
+	"cfn": {
+		{"Name", "name", 40},
+		{"Status", "status", 30},
+		{"Drift", "drift", 12},
+		{"Created", "created", 20},
+		{"Updated", "updated", 20},
+		{"Description", "description", 60},
+	},
+	"sqs": {
+		{"Name", "name", 40},
+		{"Type", "type", 8},
+		{"Visible", "visible", 9},
+		{"In Flight", "in_flight", 9},
+		{"Delayed", "delayed", 9},
+		{"Dead-letter", "dead_letter", 50},
+		{"Created", "created", 20},
+	},
+	"sns": {
+		{"Name", "name", 50},
+		{"Type", "type", 8},
+		{"Display Name", "display_name", 30},
+		{"Confirmed", "confirmed", 10},
+		{"Pending", "pending", 10},
+	},
+	"eks": {
+		{"Name", "name", 40},
+		{"Version", "version", 8},
+		{"Status", "status", 10},
+		{"Endpoint Access", "endpoint_access", 30},
+		{"Node Groups", "node_groups", 12},
+		{"Platform", "platform", 10},
+		{"Created", "created", 20},
+	},
 }
 
 // NewResourceList creates a new resource list
-func NewResourceList(resourceType string, cfg config.Config) *ResourceList {
+func NewResourceList(resourceType string, session *Session) *ResourceList {
 	list := &ResourceList{
-		Table:        tview.NewTable().SetSelectable(true, false),
+		DataTable:    NewDataTable(session.Layout, getResourceTitle(resourceType), resourceColumns[resourceType]),
 		resourceType: resourceType,
-		cfg:          cfg,
+		session:      session,
 	}
 
-	// Set up table
-	list.SetBorder(true)
-	list.SetTitle(getResourceTitle(resourceType))
-	list.SetTitleAlign(tview.AlignLeft)
-
-	// Set up headers
-	columns := resourceColumns[resourceType]
-	for i, col := range columns {
-		cell := tview.NewTableCell(col.Title).
-			SetTextColor(tcell.ColorYellow).
-			SetSelectable(false).
-			SetExpansion(1)
-		if col.Width > 0 {
-			cell.SetMaxWidth(col.Width)
-		}
-		list.SetCell(0, i, cell)
-	}
+	// Set up selection handler
+	list.SetOpenFunc(list.open)
 
 	// Load data
 	list.LoadData()
@@ -86,87 +141,543 @@ func NewResourceList(resourceType string, cfg config.Config) *ResourceList {
 	return list
 }
 
+// Actions returns the key bindings for the resource list
+func (l *ResourceList) Actions() []KeyAction {
+	switch l.resourceType {
+	case "s3":
+		return append(l.DataTable.Actions(),
+			newAction('y', "Copy URI", l.copyURI),
+			newAction('x', "Export", func() { exportTable(l.session, l.DataTable) }),
+		)
+	case "logs":
+		return append(l.DataTable.Actions(),
+			newAction('i', "Insights query", l.queryMarked),
+			newAction('x', "Export", func() { exportTable(l.session, l.DataTable) }),
+		)
+	case "dynamodb":
+		return append(l.DataTable.Actions(),
+			newAction('x', "Export", func() { exportTable(l.session, l.DataTable) }),
+		)
+	case "ecs", "cfn":
+		return append(l.DataTable.Actions(),
+			newAction('x', "Export", func() { exportTable(l.session, l.DataTable) }),
+		)
+	case "sqs":
+		actions := append(l.DataTable.Actions(), queueActions(l.session, l.selectedQueue, l.LoadData)...)
+		return append(actions,
+			newAction('x', "Export", func() { exportTable(l.session, l.DataTable) }),
+		)
+	case "sns":
+		return append(l.DataTable.Actions(),
+			newWriteAction('p', "Publish", func() {
+				if topic, ok := l.selectedTopic(); ok {
+					publishMessage(l.session, topic)
+				}
+			}),
+			newAction('d', "Access policy", func() {
+				if topic, ok := l.selectedTopic(); ok {
+					showTopicPolicy(l.session, topic)
+				}
+			}),
+			newAction('x', "Export", func() { exportTable(l.session, l.DataTable) }),
+		)
+	case "eks":
+		actions := append(l.DataTable.Actions(), clusterActions(l.session, l.selectedCluster)...)
+		return append(actions,
+			newAction('x', "Export", func() { exportTable(l.session, l.DataTable) }),
+		)
+	case "lambda":
+		return append(l.DataTable.Actions(),
+			newAction('t', "Tags", l.showTags),
+			newWriteAction('T', "Tag marked", l.tagMarked),
+			newAction('r', "Open role", l.openRole),
+			newAction('x', "Export", func() { exportTable(l.session, l.DataTable) }),
+		)
+	case "rds":
+		actions := append(l.DataTable.Actions(), databaseActions(l.session, l.selectedDatabase, l.LoadData)...)
+		return append(actions,
+			newAction('x', "Export", func() { exportTable(l.session, l.DataTable) }),
+		)
+	}
+	return append(l.DataTable.Actions(),
+		newAction('t', "Tags", l.showTags),
+		newWriteAction('T', "Tag marked", l.tagMarked),
+		newAction('x', "Export", func() { exportTable(l.session, l.DataTable) }),
+	)
+}
+
 // LoadData loads resource data from AWS
 func (l *ResourceList) LoadData() {
 	ctx := context.Background()
+	var rows []TableRow
 
 	switch l.resourceType {
 	case "ec2":
-		instances, err := awsservices.ListEC2Instances(ctx, l.cfg)
+		instances, err := awsservices.ListEC2Instances(ctx, l.session.Config)
 		if err != nil {
 			l.showError(err)
 			return
 		}
 
-		for i, inst := range instances {
-			row := i + 1
-			l.SetCell(row, 0, tview.NewTableCell(inst.ID))
-			l.SetCell(row, 1, tview.NewTableCell(inst.Name))
-			l.SetCell(row, 2, tview.NewTableCell(inst.Type))
-			stateCell := tview.NewTableCell(inst.State)
+		for _, inst := range instances {
+			row := TableRow{
+				ID:    inst.ID,
+				Cells: []string{inst.ID, inst.Name, inst.Type, inst.State, inst.PrivateIP, inst.PublicIP},
+				Ref:   inst,
+			}
 			if inst.State == "running" {
-				stateCell.SetTextColor(tcell.ColorGreen)
+				row.Colors = map[int]tcell.Color{3: tcell.ColorGreen}
 			} else if inst.State == "stopped" {
-				stateCell.SetTextColor(tcell.ColorRed)
+				row.Colors = map[int]tcell.Color{3: tcell.ColorRed}
 			}
-			l.SetCell(row, 3, stateCell)
-			l.SetCell(row, 4, tview.NewTableCell(inst.PrivateIP))
-			l.SetCell(row, 5, tview.NewTableCell(inst.PublicIP))
+			rows = append(rows, row)
 		}
 
 	case "ecr":
-		repos, err := awsservices.ListECRRepositories(ctx, l.cfg)
+		repos, err := awsservices.ListECRRepositories(ctx, l.session.Config)
 		if err != nil {
 			l.showError(err)
 			return
 		}
 
-		for i, repo := range repos {
-			row := i + 1
-			l.SetCell(row, 0, tview.NewTableCell(repo.Name))
-			l.SetCell(row, 1, tview.NewTableCell(repo.URI))
-			l.SetCell(row, 2, tview.NewTableCell(fmt.Sprintf("%d", repo.ImageCount)))
-			l.SetCell(row, 3, tview.NewTableCell(formatTime(repo.CreatedAt)))
+		for _, repo := range repos {
+			rows = append(rows, TableRow{
+				ID:    repo.Name,
+				Cells: []string{repo.Name, repo.URI, fmt.Sprintf("%d", repo.ImageCount), formatTime(repo.CreatedAt)},
+				Ref:   repo,
+			})
 		}
 
 	case "lambda":
-		functions, err := awsservices.ListLambdaFunctions(ctx, l.cfg)
+		functions, err := awsservices.ListLambdaFunctions(ctx, l.session.Config)
 		if err != nil {
 			l.showError(err)
 			return
 		}
 
-		for i, fn := range functions {
-			row := i + 1
-			l.SetCell(row, 0, tview.NewTableCell(fn.Name))
-			l.SetCell(row, 1, tview.NewTableCell(fn.Runtime))
-			l.SetCell(row, 2, tview.NewTableCell(fmt.Sprintf("%d", fn.MemorySize)))
-			l.SetCell(row, 3, tview.NewTableCell(formatTime(fn.LastModified)))
+		for _, fn := range functions {
+			rows = append(rows, TableRow{
+				ID:    fn.Name,
+				Cells: []string{fn.Name, fn.Runtime, fmt.Sprintf("%d", fn.MemorySize), formatTime(fn.LastModified)},
+				Ref:   fn,
+			})
 		}
 
 	case "secrets":
-		secrets, err := awsservices.ListSecrets(ctx, l.cfg)
+		secrets, err := awsservices.ListSecrets(ctx, l.session.Config)
 		if err != nil {
 			l.showError(err)
 			return
 		}
 
-		for i, secret := range secrets {
-			row := i + 1
-			l.SetCell(row, 0, tview.NewTableCell(secret.Name))
-			l.SetCell(row, 1, tview.NewTableCell(formatTime(secret.LastModified)))
-			rotationCell := tview.NewTableCell("-")
+		for _, secret := range secrets {
+			rotation := "-"
 			if secret.DaysUntilRotation >= 0 {
-				rotationCell.SetText(fmt.Sprintf("%d", secret.DaysUntilRotation))
+				rotation = fmt.Sprintf("%d", secret.DaysUntilRotation)
 			}
-			l.SetCell(row, 2, rotationCell)
+			rows = append(rows, TableRow{
+				ID:    secret.Name,
+				Cells: []string{secret.Name, formatTime(secret.LastModified), rotation},
+				Ref:   secret,
+			})
+		}
+
+	case "s3":
+		buckets, err := awsservices.ListS3Buckets(ctx, l.session.Config)
+		if err != nil {
+			l.showError(err)
+			return
+		}
+
+		for _, bucket := range buckets {
+			row := TableRow{
+				ID:    bucket.Name,
+				Cells: []string{bucket.Name, orDash(bucket.Region), formatTime(bucket.CreatedAt), orDash(bucket.Versioning)},
+				Ref:   bucket,
+			}
+			if bucket.Versioning == "Enabled" {
+				row.Colors = map[int]tcell.Color{3: tcell.ColorGreen}
+			}
+			rows = append(rows, row)
+		}
+
+	case "logs":
+		groups, err := awsservices.ListLogGroups(ctx, l.session.Config)
+		if err != nil {
+			l.showError(err)
+			return
+		}
+
+		for _, group := range groups {
+			retention := "Never expire"
+			if group.RetentionDays > 0 {
+				retention = fmt.Sprintf("%d days", group.RetentionDays)
+			}
+			rows = append(rows, TableRow{
+				ID:    group.Name,
+				Cells: []string{group.Name, retention, formatBytes(group.StoredBytes), formatTime(group.CreatedAt)},
+				SortKeys: map[int]string{
+					1: fmt.Sprintf("%d", group.RetentionDays),
+					2: fmt.Sprintf("%d", group.StoredBytes),
+				},
+				Ref: group,
+			})
+		}
+
+	case "dynamodb":
+		tables, err := awsservices.ListDynamoTables(ctx, l.session.Config)
+		if err != nil {
+			l.showError(err)
+			return
+		}
+
+		for _, table := range tables {
+			row := TableRow{
+				ID: table.Name,
+				Cells: []string{
+					table.Name,
+					orDash(table.Status),
+					fmt.Sprintf("%d", table.ItemCount),
+					formatBytes(table.SizeBytes),
+					orDash(table.BillingMode),
+					"-",
+				},
+				SortKeys: map[int]string{3: fmt.Sprintf("%d", table.SizeBytes)},
+				Ref:      table,
+			}
+			if table.PartitionKey.Name != "" {
+				row.Cells[5] = formatDynamoKeys(table.PartitionKey, table.SortKey)
+			}
+			if table.Status == "ACTIVE" {
+				row.Colors = map[int]tcell.Color{1: tcell.ColorGreen}
+			}
+			rows = append(rows, row)
+		}
+
+	case "rds":
+		databases, err := awsservices.ListRDSDatabases(ctx, l.session.Config)
+		if err != nil {
+			l.showError(err)
+			return
+		}
+
+		for _, db := range databases {
+			kind := "instance"
+			if db.Cluster {
+				kind = "cluster"
+			}
+			endpoint := "-"
+			if db.Endpoint != "" {
+				endpoint = fmt.Sprintf("%s:%d", db.Endpoint, db.Port)
+			}
+			rows = append(rows, TableRow{
+				ID: db.ID,
+				Cells: []string{
+					db.ID,
+					kind,
+					db.Engine,
+					db.EngineVersion,
+					orDash(db.Class),
+					db.Status,
+					yesNo(db.MultiAZ),
+					endpoint,
+					formatStorage(db),
+				},
+				Colors:   map[int]tcell.Color{5: rdsStatusColor(db.Status)},
+				SortKeys: map[int]string{8: fmt.Sprintf("%d", db.StorageGiB)},
+				Ref:      db,
+			})
+		}
+
+	case "ecs":
+		clusters, err := awsservices.ListECSClusters(ctx, l.session.Config)
+		if err != nil {
+			l.showError(err)
+			return
+		}
+
+		for _, cluster := range clusters {
+			row := TableRow{
+				ID: cluster.Name,
+				Cells: []string{
+					cluster.Name,
+					cluster.Status,
+					fmt.Sprintf("%d", cluster.ActiveServices),
+					fmt.Sprintf("%d", cluster.RunningTasks),
+					fmt.Sprintf("%d", cluster.PendingTasks),
+				},
+				Ref: cluster,
+			}
+			if cluster.Status == "ACTIVE" {
+				row.Colors = map[int]tcell.Color{1: tcell.ColorGreen}
+			}
+			rows = append(rows, row)
+		}
+
+	case "cfn":
+		stacks, err := awsservices.ListStacks(ctx, l.session.Config)
+		if err != nil {
+			l.showError(err)
+			return
+		}
+
+		for _, stack := range stacks {
+			rows = append(rows, TableRow{
+				ID: stack.Name,
+				Cells: []string{
+					stack.Name,
+					stack.Status,
+					orDash(stack.DriftStatus),
+					formatTime(stack.CreatedAt),
+					formatOptionalTime(stack.UpdatedAt),
+					orDash(stack.Description),
+				},
+				Colors: map[int]tcell.Color{1: stackStatusColor(stack.Status), 2: driftColor(stack.DriftStatus)},
+				Ref:    stack,
+			})
+		}
+
+	case "sqs":
+		queues, err := awsservices.ListQueues(ctx, l.session.Config)
+		if err != nil {
+			l.showError(err)
+			return
+		}
+
+		for _, queue := range queues {
+			kind := "Standard"
+			if queue.FIFO {
+				kind = "FIFO"
+			}
+			row := TableRow{
+				ID: queue.Name,
+				Cells: []string{
+					queue.Name,
+					kind,
+					fmt.Sprintf("%d", queue.Visible),
+					fmt.Sprintf("%d", queue.InFlight),
+					fmt.Sprintf("%d", queue.Delayed),
+					formatDeadLetter(queue),
+					formatOptionalTime(queue.CreatedAt),
+				},
+				Ref: queue,
+			}
+			// Messages waiting in a dead-letter queue need attention
+			if len(queue.Sources) > 0 && queue.Visible > 0 {
+				row.Colors = map[int]tcell.Color{2: tcell.ColorRed}
+			}
+			rows = append(rows, row)
+		}
+
+	case "sns":
+		topics, err := awsservices.ListTopics(ctx, l.session.Config)
+		if err != nil {
+			l.showError(err)
+			return
+		}
+
+		for _, topic := range topics {
+			kind := "Standard"
+			if topic.FIFO {
+				kind = "FIFO"
+			}
+			row := TableRow{
+				ID: topic.Name,
+				Cells: []string{
+					topic.Name,
+					kind,
+					orDash(topic.DisplayName),
+					fmt.Sprintf("%d", topic.Confirmed),
+					fmt.Sprintf("%d", topic.Pending),
+				},
+				Ref: topic,
+			}
+			if topic.Pending > 0 {
+				row.Colors = map[int]tcell.Color{4: tcell.ColorYellow}
+			}
+			rows = append(rows, row)
+		}
+
+	case "eks":
+		clusters, err := awsservices.ListEKSClusters(ctx, l.session.Config)
+		if err != nil {
+			l.showError(err)
+			return
+		}
+
+		for _, cluster := range clusters {
+			rows = append(rows, TableRow{
+				ID: cluster.Name,
+				Cells: []string{
+					cluster.Name,
+					orDash(cluster.Version),
+					orDash(cluster.Status),
+					awsservices.EndpointAccess(cluster),
+					fmt.Sprintf("%d", len(cluster.NodeGroups)),
+					orDash(cluster.PlatformVersion),
+					formatOptionalTime(cluster.CreatedAt),
+				},
+				Colors: map[int]tcell.Color{2: eksStatusColor(cluster.Status)},
+				Ref:    cluster,
+			})
+		}
+	}
+
+	l.SetRows(rows)
+}
+
+// open drills down into the selected resource
+func (l *ResourceList) open(row TableRow) {
+	switch ref := row.Ref.(type) {
+	case awsservices.EC2Instance:
+		page, err := NewInstancePage(l.session, ref.ID)
+		if err != nil {
+			l.session.Layout.ShowError(err)
+			return
+		}
+		l.session.Layout.Push(page)
+	case awsservices.ECRRepository:
+		l.session.Layout.Push(NewImageList(l.session, ref.Name))
+	case awsservices.S3Bucket:
+		l.session.Layout.Push(NewObjectList(l.session, ref, ""))
+	case awsservices.LogGroup:
+		l.session.Layout.Push(NewLogStreamList(l.session, ref.Name))
+	case awsservices.DynamoTable:
+		l.session.Layout.Push(NewItemList(l.session, ref))
+	case awsservices.RDSDatabase:
+		l.session.Layout.Push(NewDatabasePage(l.session, ref))
+	case awsservices.ECSCluster:
+		l.session.Layout.Push(NewServiceList(l.session, ref.Name))
+	case awsservices.Stack:
+		l.session.Layout.Push(NewStackPage(l.session, ref))
+	case awsservices.Queue:
+		l.session.Layout.Push(NewMessageList(l.session, ref))
+	case awsservices.Topic:
+		l.session.Layout.Push(NewSubscriptionList(l.session, ref))
+	case awsservices.EKSCluster:
+		l.session.Layout.Push(NewNodeGroupList(l.session, ref))
+	case awsservices.Secret:
+		l.session.Layout.Push(NewMaskedView(l.session, "Secret: "+ref.Name, strings.Join([]string{
+			"Name:          " + ref.Name,
+			"ARN:           " + ref.ARN,
+			"Last modified: " + formatTime(ref.LastModified),
+			"",
+		}, "\n"), func() (string, error) {
+			return awsservices.GetSecretValue(l.session.AWSConfig(), ref.ARN)
+		}))
+	}
+}
+
+// openRole opens the execution role of the selected Lambda function
+func (l *ResourceList) openRole() {
+	row, ok := l.SelectedRow()
+	if !ok {
+		return
+	}
+	if fn, ok := row.Ref.(awsservices.LambdaFunction); ok && fn.Role != "" {
+		openIAMRole(l.session, awsservices.RoleNameFromARN(fn.Role))
+	}
+}
+
+// selectedCluster returns the EKS cluster under the cursor
+func (l *ResourceList) selectedCluster() (awsservices.EKSCluster, bool) {
+	row, ok := l.SelectedRow()
+	if !ok {
+		return awsservices.EKSCluster{}, false
+	}
+	cluster, ok := row.Ref.(awsservices.EKSCluster)
+	return cluster, ok
+}
+
+// selectedDatabase returns the database under the cursor
+func (l *ResourceList) selectedDatabase() (awsservices.RDSDatabase, bool) {
+	row, ok := l.SelectedRow()
+	if !ok {
+		return awsservices.RDSDatabase{}, false
+	}
+	db, ok := row.Ref.(awsservices.RDSDatabase)
+	return db, ok
+}
+
+// selectedQueue returns the queue under the cursor
+func (l *ResourceList) selectedQueue() (awsservices.Queue, bool) {
+	row, ok := l.SelectedRow()
+	if !ok {
+		return awsservices.Queue{}, false
+	}
+	queue, ok := row.Ref.(awsservices.Queue)
+	return queue, ok
+}
+
+// selectedTopic returns the topic under the cursor
+func (l *ResourceList) selectedTopic() (awsservices.Topic, bool) {
+	row, ok := l.SelectedRow()
+	if !ok {
+		return awsservices.Topic{}, false
+	}
+	topic, ok := row.Ref.(awsservices.Topic)
+	return topic, ok
+}
+
+// queryMarked opens a Logs Insights query over the marked log groups
+func (l *ResourceList) queryMarked() {
+	var groups []string
+	for _, row := range l.MarkedRows() {
+		groups = append(groups, row.ID)
+	}
+	if len(groups) > 0 {
+		l.session.Layout.Push(NewInsightsView(l.session, groups))
+	}
+}
+
+// copyURI copies the s3:// URI of the selected bucket
+func (l *ResourceList) copyURI() {
+	if row, ok := l.SelectedRow(); ok {
+		copyText(l.session, awsservices.S3URI(row.ID, ""))
+	}
+}
+
+func (l *ResourceList) showTags() {
+	row, ok := l.SelectedRow()
+	if !ok {
+		return
+	}
+	if target, ok := tagTarget(row); ok {
+		l.session.Layout.Push(NewTagView(l.session, target))
+	}
+}
+
+func (l *ResourceList) tagMarked() {
+	var targets []awsservices.TagTarget
+	for _, row := range l.MarkedRows() {
+		if target, ok := tagTarget(row); ok {
+			targets = append(targets, target)
+		}
+	}
+	editTags(l.session, targets, func() {})
+}
+
+// tagTarget returns the tagging target for a resource row
+func tagTarget(row TableRow) (awsservices.TagTarget, bool) {
+	switch ref := row.Ref.(type) {
+	case awsservices.EC2Instance:
+		name := ref.ID
+		if ref.Name != "" {
+			name += " (" + ref.Name + ")"
 		}
+		return awsservices.TagTarget{Service: "ec2", ID: ref.ID, Name: name}, true
+	case awsservices.ECRRepository:
+		return awsservices.TagTarget{Service: "ecr", ID: ref.ARN, Name: ref.Name}, true
+	case awsservices.LambdaFunction:
+		return awsservices.TagTarget{Service: "lambda", ID: ref.ARN, Name: ref.Name}, true
+	case awsservices.Secret:
+		return awsservices.TagTarget{Service: "secrets", ID: ref.ARN, Name: ref.Name}, true
 	}
+	return awsservices.TagTarget{}, false
 }
 
 func (l *ResourceList) showError(err error) {
-	l.Clear()
-	l.SetCell(0, 0, tview.NewTableCell(fmt.Sprintf("Error: %v", err)).SetTextColor(tcell.ColorRed))
+	l.SetError(err)
 }
 
 func formatTime(t time.Time) string {
@@ -183,6 +694,24 @@ func getResourceTitle(resourceType string) string {
 		return "Lambda Functions"
 	case "secrets":
 		return "Secrets Manager"
+	case "s3":
+		return "S3 Buckets"
+	case "logs":
+		return "CloudWatch Log Groups"
+	case "dynamodb":
+		return "DynamoDB Tables"
+	case "rds":
+		return "RDS Databases"
+	case "ecs":
+		return "ECS Clusters"
+	case "cfn":
+		return "CloudFormation Stacks"
+	case "sqs":
+		return "SQS Queues"
+	case "sns":
+		return "SNS Topics"
+	case "eks":
+		return "EKS Clusters"
 	default:
 		return "Resources"
 	}
@@ -0,0 +1,198 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	awsservices "github.com/Ninad-Bhangui/awstui/aws/services"
+	"github.com/Ninad-Bhangui/awstui/clipboard"
+	"github.com/gdamore/tcell/v2"
+)
+
+// s3Prefix is the row reference of a "folder" in the object browser
+type s3Prefix string
+
+// ObjectList represents the objects and sub-prefixes under a prefix of an S3 bucket
+type ObjectList struct {
+	*DataTable
+	session   *Session
+	bucket    awsservices.S3Bucket
+	prefix    string
+	rows      []TableRow
+	nextToken string
+}
+
+// NewObjectList creates a new object browser for a prefix of a bucket
+func NewObjectList(session *Session, bucket awsservices.S3Bucket, prefix string) *ObjectList {
+	list := &ObjectList{
+		DataTable: NewDataTable(session.Layout, awsservices.S3URI(bucket.Name, prefix), []Column{
+			{"Name", "name", 60},
+			{"Size", "size", 12},
+			{"Storage Class", "storage_class", 20},
+			{"Last Modified", "modified", 20},
+		}),
+		session: session,
+		bucket:  bucket,
+		prefix:  prefix,
+	}
+
+	// Set up selection handler
+	list.SetOpenFunc(list.open)
+
+	list.LoadData()
+
+	return list
+}
+
+// Actions returns the key bindings for the object browser
+func (l *ObjectList) Actions() []KeyAction {
+	actions := append(l.DataTable.Actions(),
+		newAction('d', "Download", l.download),
+		newAction('y', "Copy URI", l.copyURI),
+	)
+	if l.nextToken != "" {
+		actions = append(actions, newAction('n', "Next page", l.loadNextPage))
+	}
+	return actions
+}
+
+// LoadData loads the first page of objects from AWS
+func (l *ObjectList) LoadData() {
+	l.rows = nil
+	l.nextToken = ""
+	l.loadPage("")
+}
+
+// loadNextPage appends the page after the ones already listed
+func (l *ObjectList) loadNextPage() {
+	l.loadPage(l.nextToken)
+}
+
+// loadPage appends a page of prefixes and objects to the list
+func (l *ObjectList) loadPage(token string) {
+	page, err := awsservices.ListS3Objects(context.Background(), l.session.Config, l.bucket.Name, l.bucket.Region, l.prefix, token)
+	if err != nil {
+		l.SetError(err)
+		return
+	}
+
+	for _, prefix := range page.Prefixes {
+		l.rows = append(l.rows, TableRow{
+			ID:       prefix,
+			Cells:    []string{strings.TrimPrefix(prefix, l.prefix), "-", "-", "-"},
+			Colors:   map[int]tcell.Color{0: tcell.ColorAqua},
+			SortKeys: map[int]string{1: "-1"},
+			Ref:      s3Prefix(prefix),
+		})
+	}
+	for _, obj := range page.Objects {
+		l.rows = append(l.rows, TableRow{
+			ID:       obj.Key,
+			Cells:    []string{strings.TrimPrefix(obj.Key, l.prefix), formatBytes(obj.Size), orDash(obj.StorageClass), formatTime(obj.LastModified)},
+			SortKeys: map[int]string{1: fmt.Sprintf("%d", obj.Size)},
+			Ref:      obj,
+		})
+	}
+	l.nextToken = page.NextToken
+	l.SetRows(l.rows)
+
+	if l.nextToken != "" {
+		l.session.Layout.SetStatus(fmt.Sprintf("Showing the first %d entries, press n for the next page", len(l.rows)))
+	} else {
+		l.session.Layout.SetStatus(fmt.Sprintf("%d entries", len(l.rows)))
+	}
+	l.session.Layout.Refresh()
+}
+
+// open drills into a prefix or shows the metadata of an object
+func (l *ObjectList) open(row TableRow) {
+	switch ref := row.Ref.(type) {
+	case s3Prefix:
+		l.session.Layout.Push(NewObjectList(l.session, l.bucket, string(ref)))
+	case awsservices.S3Object:
+		showDetail(l.session, "Object: "+awsservices.S3URI(l.bucket.Name, ref.Key), func() (string, error) {
+			return awsservices.GetS3ObjectDetail(context.Background(), l.session.Config, l.bucket.Name, l.bucket.Region, ref.Key)
+		})
+	}
+}
+
+// copyURI copies the s3:// URI of the selected prefix or object
+func (l *ObjectList) copyURI() {
+	row, ok := l.SelectedRow()
+	if !ok {
+		return
+	}
+	copyText(l.session, awsservices.S3URI(l.bucket.Name, row.ID))
+}
+
+// download asks for a local path and saves the selected object there
+func (l *ObjectList) download() {
+	row, ok := l.SelectedRow()
+	if !ok {
+		return
+	}
+	obj, ok := row.Ref.(awsservices.S3Object)
+	if !ok {
+		l.session.Layout.SetStatus("Only objects can be downloaded")
+		return
+	}
+
+	l.session.Layout.Prompt("Download to", filepath.Base(obj.Key), func(path string) {
+		path = strings.TrimSpace(path)
+		if path == "" {
+			return
+		}
+		if info, err := os.Stat(path); err == nil && info.IsDir() {
+			path = filepath.Join(path, filepath.Base(obj.Key))
+		}
+
+		start := func() { l.startDownload(obj, path) }
+		if _, err := os.Stat(path); err == nil {
+			l.session.Layout.Confirm(fmt.Sprintf("%s already exists. Overwrite it?", path), start)
+			return
+		}
+		start()
+	})
+}
+
+// startDownload downloads an object in the background, reporting progress in the status bar
+func (l *ObjectList) startDownload(obj awsservices.S3Object, path string) {
+	uri := awsservices.S3URI(l.bucket.Name, obj.Key)
+	l.session.Layout.SetStatus(fmt.Sprintf("Downloading %s (%s)", uri, formatBytes(obj.Size)))
+	go func() {
+		written, err := awsservices.DownloadS3Object(context.Background(), l.session.Config, l.bucket.Name, l.bucket.Region, obj.Key, path)
+		l.session.Layout.QueueUpdateDraw(func() {
+			if err != nil {
+				l.session.Layout.ShowError(err)
+				return
+			}
+			l.session.Layout.SetStatus(fmt.Sprintf("Downloaded %s to %s (%s)", uri, path, formatBytes(written)))
+		})
+	}()
+}
+
+// copyText copies text to the clipboard and reports it in the status bar
+func copyText(session *Session, text string) {
+	if err := clipboard.Copy(text); err != nil {
+		session.Layout.ShowError(fmt.Errorf("failed to copy to clipboard: %w", err))
+		return
+	}
+	session.Layout.SetStatus("Copied " + text)
+}
+
+// formatBytes renders a byte count with a binary unit, such as "1.5 MiB"
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
@@ -0,0 +1,109 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Ninad-Bhangui/awstui/audit"
+	"github.com/Ninad-Bhangui/awstui/aws"
+	awsservices "github.com/Ninad-Bhangui/awstui/aws/services"
+	"github.com/Ninad-Bhangui/awstui/settings"
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+)
+
+// errReadOnly is shown when a change is attempted in read-only mode
+var errReadOnly = errors.New("awstui is running in read-only mode")
+
+// Session holds the state shared by all views once a profile is selected
+type Session struct {
+	Layout   *Layout
+	Profile  aws.Profile
+	Config   config.Config
+	Settings *settings.Settings
+	State    *settings.State
+	AuditLog *audit.Logger
+	account  string // Resolved on the first audited change
+}
+
+// NewSession creates a new session for the selected profile
+func NewSession(layout *Layout, profile aws.Profile, cfg config.Config, settings *settings.Settings, state *settings.State, auditLog *audit.Logger) *Session {
+	return &Session{
+		Layout:   layout,
+		Profile:  profile,
+		Config:   cfg,
+		Settings: settings,
+		State:    state,
+		AuditLog: auditLog,
+	}
+}
+
+// AWSConfig returns the session config as aws.Config
+func (s *Session) AWSConfig() awssdk.Config {
+	return awsservices.GetAWSConfig(s.Config).(awssdk.Config)
+}
+
+// ReadOnly reports whether changes to AWS resources are disabled
+func (s *Session) ReadOnly() bool {
+	return s.Settings.ReadOnly
+}
+
+// Protected reports whether the session profile is protected
+func (s *Session) Protected() bool {
+	return s.Settings.IsProtected(s.Profile.Name)
+}
+
+// ConfirmChange asks for confirmation before a change is applied. Protected
+// profiles require the profile name to be typed, and read-only mode refuses.
+func (s *Session) ConfirmChange(text string, apply func()) {
+	switch {
+	case s.ReadOnly():
+		s.Layout.ShowError(errReadOnly)
+	case s.Protected():
+		s.Layout.ConfirmTyped(text, s.Profile.Name, apply)
+	default:
+		s.Layout.Confirm(text, apply)
+	}
+}
+
+// AllowChange runs a change the user has already reviewed, still enforcing
+// read-only mode and the typed confirmation of protected profiles
+func (s *Session) AllowChange(text string, apply func()) {
+	switch {
+	case s.ReadOnly():
+		s.Layout.ShowError(errReadOnly)
+	case s.Protected():
+		s.Layout.ConfirmTyped(text, s.Profile.Name, apply)
+	default:
+		apply()
+	}
+}
+
+// Audit records the result of a change in the audit log
+func (s *Session) Audit(action string, resources []string, params map[string]interface{}, err error) {
+	if s.account == "" {
+		if account, err := awsservices.GetAccountID(context.Background(), s.Config); err == nil {
+			s.account = account
+		}
+	}
+
+	entry := audit.Entry{
+		Time:      time.Now().UTC(),
+		Profile:   s.Profile.Name,
+		Account:   s.account,
+		Region:    s.AWSConfig().Region,
+		Action:    action,
+		Resources: resources,
+		Params:    params,
+		Result:    "success",
+	}
+	if err != nil {
+		entry.Result = "error"
+		entry.Error = err.Error()
+	}
+
+	if err := s.AuditLog.Record(entry); err != nil {
+		s.Layout.ShowError(err)
+	}
+}
@@ -0,0 +1,138 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	awsservices "github.com/Ninad-Bhangui/awstui/aws/services"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// SubscriptionList represents the subscriptions of an SNS topic
+type SubscriptionList struct {
+	*DataTable
+	session *Session
+	topic   awsservices.Topic
+}
+
+// NewSubscriptionList creates a new subscription list for a topic
+func NewSubscriptionList(session *Session, topic awsservices.Topic) *SubscriptionList {
+	list := &SubscriptionList{
+		DataTable: NewDataTable(session.Layout, "Subscriptions: "+topic.Name, []Column{
+			{"Protocol", "protocol", 10},
+			{"Endpoint", "endpoint", 60},
+			{"Status", "status", 22},
+			{"Subscription ARN", "arn", 80},
+		}),
+		session: session,
+		topic:   topic,
+	}
+
+	list.LoadData()
+
+	return list
+}
+
+// Actions returns the key bindings for the subscription list
+func (l *SubscriptionList) Actions() []KeyAction {
+	return append(l.DataTable.Actions(),
+		newWriteAction('p', "Publish", func() { publishMessage(l.session, l.topic) }),
+		newAction('d', "Access policy", func() { showTopicPolicy(l.session, l.topic) }),
+	)
+}
+
+// LoadData loads the subscriptions from AWS
+func (l *SubscriptionList) LoadData() {
+	subscriptions, err := awsservices.ListSubscriptions(context.Background(), l.session.Config, l.topic.ARN)
+	if err != nil {
+		l.SetError(err)
+		return
+	}
+
+	var rows []TableRow
+	for i, subscription := range subscriptions {
+		row := TableRow{
+			ID:     fmt.Sprintf("%s/%d", subscription.Endpoint, i),
+			Cells:  []string{subscription.Protocol, subscription.Endpoint, "Confirmed", orDash(subscription.ARN)},
+			Colors: map[int]tcell.Color{2: tcell.ColorGreen},
+			Ref:    subscription,
+		}
+		if subscription.Pending {
+			row.Cells[2] = "Pending confirmation"
+			row.Colors[2] = tcell.ColorYellow
+		}
+		rows = append(rows, row)
+	}
+	l.SetRows(rows)
+}
+
+// publishMessage lets the user write a message with attributes and publishes it to the topic
+func publishMessage(session *Session, topic awsservices.Topic) {
+	form := tview.NewForm()
+	form.AddInputField("Subject", "", 0, nil, nil)
+	form.AddTextArea("Message", "", 0, 10, 0, nil)
+	form.AddTextArea("Attributes (key=value)", "", 0, 4, 0, nil)
+	if topic.FIFO {
+		form.AddInputField("Group ID", "", 0, nil, nil)
+		form.AddInputField("Deduplication ID", "", 0, nil, nil)
+	}
+	form.AddButton("Publish", func() {
+		subject := form.GetFormItem(0).(*tview.InputField).GetText()
+		message := form.GetFormItem(1).(*tview.TextArea).GetText()
+		if strings.TrimSpace(message) == "" {
+			session.Layout.SetStatus("Enter a message first")
+			return
+		}
+		attributes, err := parseTagLines(form.GetFormItem(2).(*tview.TextArea).GetText())
+		if err != nil {
+			session.Layout.ShowError(fmt.Errorf("invalid attributes: %w", err))
+			return
+		}
+		var groupID, dedupID string
+		if topic.FIFO {
+			groupID = form.GetFormItem(3).(*tview.InputField).GetText()
+			dedupID = form.GetFormItem(4).(*tview.InputField).GetText()
+		}
+
+		params := map[string]interface{}{"attributes": sortedKeys(attributes)}
+		if groupID != "" {
+			params["messageGroupId"] = groupID
+		}
+
+		text := fmt.Sprintf("Publish a message to %s?\n\nIt is delivered to %d confirmed subscriptions.", topic.Name, topic.Confirmed)
+		session.AllowChange(text, func() {
+			id, err := awsservices.PublishMessage(context.Background(), session.Config, topic.ARN, subject, message, attributes, groupID, dedupID)
+			session.Audit("sns:Publish", []string{topic.ARN}, params, err)
+			if err != nil {
+				session.Layout.ShowError(err)
+				return
+			}
+			session.Layout.CloseModal("sns-publish")
+			session.Layout.SetStatus("Published message " + id)
+		})
+	})
+	form.AddButton("Cancel", func() {
+		session.Layout.CloseModal("sns-publish")
+	})
+	form.SetCancelFunc(func() {
+		session.Layout.CloseModal("sns-publish")
+	})
+
+	form.SetBorder(true)
+	form.SetTitle(tview.Escape("Publish: " + topic.Name))
+	form.SetTitleAlign(tview.AlignLeft)
+
+	session.Layout.ShowModal("sns-publish", centered(form, 90, form.GetFormItemCount()*2+19))
+}
+
+// showTopicPolicy shows the access policy of a topic as formatted JSON
+func showTopicPolicy(session *Session, topic awsservices.Topic) {
+	showDetail(session, "Access policy: "+topic.Name, func() (string, error) {
+		if topic.Policy == "" {
+			return "", fmt.Errorf("the access policy of %s could not be read", topic.Name)
+		}
+		return awsservices.FormatPolicy(topic.Policy), nil
+	})
+}
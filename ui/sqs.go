@@ -0,0 +1,253 @@
+package ui
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	awsservices "github.com/Ninad-Bhangui/awstui/aws/services"
+	"github.com/rivo/tview"
+)
+
+// MessageList represents messages peeked from an SQS queue without deleting them
+type MessageList struct {
+	*DataTable
+	session  *Session
+	queue    awsservices.Queue
+	messages []awsservices.QueueMessage
+}
+
+// NewMessageList creates a new message list for a queue. It starts empty, since peeking receives
+// the messages and counts towards the maxReceiveCount of the redrive policy.
+func NewMessageList(session *Session, queue awsservices.Queue) *MessageList {
+	list := &MessageList{
+		DataTable: NewDataTable(session.Layout, "Messages: "+queue.Name, []Column{
+			{"Message ID", "id", 38},
+			{"Sent", "sent", 20},
+			{"Receives", "receives", 9},
+			{"Group", "group", 20},
+			{"Body", "body", 80},
+		}),
+		session: session,
+		queue:   queue,
+	}
+
+	// Set up selection handler
+	list.SetOpenFunc(list.showMessage)
+
+	list.render()
+	session.Layout.SetStatus("Press p to peek at messages, each peek counts as a receive")
+
+	return list
+}
+
+// Actions returns the key bindings for the message list
+func (l *MessageList) Actions() []KeyAction {
+	return append(l.DataTable.Actions(),
+		newWriteAction('p', "Peek", l.confirmPeek),
+		newWriteAction('s', "Send", func() { sendMessage(l.session, l.queue, l.LoadData) }),
+	)
+}
+
+// LoadData clears the peeked messages without receiving any, so the next peek starts from scratch
+func (l *MessageList) LoadData() {
+	l.messages = nil
+	l.render()
+}
+
+// confirmPeek peeks after confirmation when receiving may move messages to the dead-letter queue
+func (l *MessageList) confirmPeek() {
+	if l.queue.DeadLetterARN == "" {
+		l.session.AllowChange(fmt.Sprintf("Peek at %s?\n\nEach peek counts as a receive.", l.queue.Name), l.peek)
+		return
+	}
+	text := fmt.Sprintf("Peek at %s?\n\nEach peek counts as a receive, and messages received %d times move to its dead-letter queue.",
+		l.queue.Name, l.queue.MaxReceiveCount)
+	l.session.ConfirmChange(text, l.peek)
+}
+
+// peek adds up to 10 more messages. SQS picks which messages a receive returns, so peeking
+// again may return some of those already shown.
+func (l *MessageList) peek() {
+	messages, err := awsservices.PeekQueue(context.Background(), l.session.Config, l.queue.URL)
+	if err != nil {
+		l.SetError(err)
+		return
+	}
+
+	added := 0
+	for _, message := range messages {
+		if !slices.ContainsFunc(l.messages, func(m awsservices.QueueMessage) bool { return m.ID == message.ID }) {
+			l.messages = append(l.messages, message)
+			added++
+		}
+	}
+
+	l.render()
+	l.session.Layout.SetStatus(fmt.Sprintf("Peeked %d new messages, each peek counts as a receive", added))
+}
+
+// render shows the messages peeked so far
+func (l *MessageList) render() {
+	rows := make([]TableRow, len(l.messages))
+	for i, message := range l.messages {
+		rows[i] = TableRow{
+			ID: message.ID,
+			Cells: []string{
+				message.ID,
+				formatOptionalTime(message.SentAt),
+				fmt.Sprintf("%d", message.ReceiveCount),
+				orDash(message.GroupID),
+				strings.Join(strings.Fields(message.Body), " "),
+			},
+			Ref: message,
+		}
+	}
+	l.SetRows(rows)
+}
+
+// showMessage shows a message with its attributes, indenting JSON bodies
+func (l *MessageList) showMessage(row TableRow) {
+	message := row.Ref.(awsservices.QueueMessage)
+	showDetail(l.session, "Message: "+message.ID, func() (string, error) {
+		var b strings.Builder
+		for _, name := range sortedKeys(message.Attributes) {
+			fmt.Fprintf(&b, "%s: %s\n", name, message.Attributes[name])
+		}
+		if b.Len() > 0 {
+			b.WriteString("\n")
+		}
+
+		var body bytes.Buffer
+		if json.Indent(&body, []byte(message.Body), "", "  ") == nil {
+			b.WriteString(body.String())
+		} else {
+			b.WriteString(message.Body)
+		}
+		return b.String(), nil
+	})
+}
+
+// queueActions returns the write actions of the queue list. selected returns the queue to
+// act on, and onChange runs after its messages changed.
+func queueActions(session *Session, selected func() (awsservices.Queue, bool), onChange func()) []KeyAction {
+	return []KeyAction{
+		newWriteAction('s', "Send", func() {
+			if queue, ok := selected(); ok {
+				sendMessage(session, queue, onChange)
+			}
+		}),
+		newWriteAction('P', "Purge", func() {
+			if queue, ok := selected(); ok {
+				purgeQueue(session, queue, onChange)
+			}
+		}),
+		newWriteAction('R', "Redrive DLQ", func() {
+			if queue, ok := selected(); ok {
+				redriveQueue(session, queue, onChange)
+			}
+		}),
+	}
+}
+
+// sendMessage lets the user write a message and sends it to the queue
+func sendMessage(session *Session, queue awsservices.Queue, onSent func()) {
+	form := tview.NewForm()
+	form.AddTextArea("Body", "", 0, 12, 0, nil)
+	if queue.FIFO {
+		form.AddInputField("Group ID", "", 0, nil, nil)
+		form.AddInputField("Deduplication ID", "", 0, nil, nil)
+	}
+	form.AddButton("Send", func() {
+		body := form.GetFormItem(0).(*tview.TextArea).GetText()
+		if strings.TrimSpace(body) == "" {
+			session.Layout.SetStatus("Enter a message body first")
+			return
+		}
+		var groupID, dedupID string
+		if queue.FIFO {
+			groupID = form.GetFormItem(1).(*tview.InputField).GetText()
+			dedupID = form.GetFormItem(2).(*tview.InputField).GetText()
+		}
+
+		var params map[string]interface{}
+		if groupID != "" {
+			params = map[string]interface{}{"messageGroupId": groupID}
+		}
+
+		session.AllowChange(fmt.Sprintf("Send a message to %s?", queue.Name), func() {
+			id, err := awsservices.SendQueueMessage(context.Background(), session.Config, queue.URL, body, groupID, dedupID)
+			session.Audit("sqs:SendMessage", []string{queue.ARN}, params, err)
+			if err != nil {
+				session.Layout.ShowError(err)
+				return
+			}
+			session.Layout.CloseModal("sqs-send")
+			session.Layout.SetStatus("Sent message " + id)
+			onSent()
+		})
+	})
+	form.AddButton("Cancel", func() {
+		session.Layout.CloseModal("sqs-send")
+	})
+	form.SetCancelFunc(func() {
+		session.Layout.CloseModal("sqs-send")
+	})
+
+	form.SetBorder(true)
+	form.SetTitle(tview.Escape("Send message: " + queue.Name))
+	form.SetTitleAlign(tview.AlignLeft)
+
+	session.Layout.ShowModal("sqs-send", centered(form, 80, form.GetFormItemCount()*2+15))
+}
+
+// purgeQueue deletes all messages of a queue after confirmation
+func purgeQueue(session *Session, queue awsservices.Queue, onPurged func()) {
+	text := fmt.Sprintf("Purge %s?\n\nAbout %d visible, %d in-flight and %d delayed messages are deleted for good.", queue.Name, queue.Visible, queue.InFlight, queue.Delayed)
+	session.ConfirmChange(text, func() {
+		err := awsservices.PurgeQueue(context.Background(), session.Config, queue.URL)
+		session.Audit("sqs:PurgeQueue", []string{queue.ARN}, nil, err)
+		if err != nil {
+			session.Layout.ShowError(err)
+			return
+		}
+		session.Layout.SetStatus("Purging " + queue.Name + ", which can take up to a minute")
+		onPurged()
+	})
+}
+
+// redriveQueue moves the messages of a dead-letter queue back to their source queues after confirmation
+func redriveQueue(session *Session, queue awsservices.Queue, onStarted func()) {
+	if len(queue.Sources) == 0 {
+		session.Layout.SetStatus(queue.Name + " is not the dead-letter queue of any queue")
+		return
+	}
+
+	text := fmt.Sprintf("Move about %d messages from %s back to %s?", queue.Visible, queue.Name, strings.Join(queue.Sources, ", "))
+	session.ConfirmChange(text, func() {
+		err := awsservices.RedriveQueue(context.Background(), session.Config, queue.ARN)
+		session.Audit("sqs:StartMessageMoveTask", []string{queue.ARN}, nil, err)
+		if err != nil {
+			session.Layout.ShowError(err)
+			return
+		}
+		session.Layout.SetStatus("Started redrive of " + queue.Name)
+		onStarted()
+	})
+}
+
+// formatDeadLetter describes the dead-letter relationship of a queue
+func formatDeadLetter(queue awsservices.Queue) string {
+	var parts []string
+	if queue.DeadLetterARN != "" {
+		name := queue.DeadLetterARN[strings.LastIndex(queue.DeadLetterARN, ":")+1:]
+		parts = append(parts, fmt.Sprintf("→ %s after %d receives", name, queue.MaxReceiveCount))
+	}
+	if len(queue.Sources) > 0 {
+		parts = append(parts, "DLQ of "+strings.Join(queue.Sources, ", "))
+	}
+	return orDash(strings.Join(parts, "; "))
+}
@@ -0,0 +1,289 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	awsservices "github.com/Ninad-Bhangui/awstui/aws/services"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// parameterTypes are the types a parameter can be put with
+var parameterTypes = []string{"String", "StringList", awsservices.SecureStringType}
+
+// ParameterList represents the folders and parameters at a path of the Parameter Store
+type ParameterList struct {
+	*DataTable
+	session *Session
+	path    string
+	params  []awsservices.Parameter
+}
+
+// NewParameterList creates a new parameter browser for a path, "/" for the root
+func NewParameterList(session *Session, path string) *ParameterList {
+	list := &ParameterList{
+		DataTable: NewDataTable(session.Layout, "Parameters: "+path, []Column{
+			{"Name", "name", 50},
+			{"Type", "type", 14},
+			{"Version", "version", 8},
+			{"Tier", "tier", 12},
+			{"Last Modified", "modified", 20},
+			{"Modified By", "modified_by", 40},
+		}),
+		session: session,
+		path:    path,
+	}
+
+	// Set up selection handler
+	list.SetOpenFunc(list.open)
+
+	list.LoadData()
+
+	return list
+}
+
+// Actions returns the key bindings for the parameter browser
+func (l *ParameterList) Actions() []KeyAction {
+	return append(l.DataTable.Actions(),
+		newWriteAction('P', "Put parameter", l.put),
+		newAction('H', "History", l.showHistory),
+		newAction('x', "Export", func() { exportTable(l.session, l.DataTable) }),
+	)
+}
+
+// LoadData loads the parameters below the path from AWS
+func (l *ParameterList) LoadData() {
+	params, err := awsservices.ListParameters(context.Background(), l.session.Config, l.path)
+	if err != nil {
+		l.SetError(err)
+		return
+	}
+	l.params = params
+
+	folders, leaves := awsservices.ParameterTree(l.path, params)
+	var rows []TableRow
+	for _, folder := range folders {
+		rows = append(rows, TableRow{
+			ID:       folder.Path,
+			Cells:    []string{strings.TrimPrefix(folder.Path, l.path), fmt.Sprintf("%d parameters", folder.Count), "-", "-", "-", "-"},
+			Colors:   map[int]tcell.Color{0: tcell.ColorAqua},
+			SortKeys: map[int]string{2: "-1"},
+			Ref:      folder,
+		})
+	}
+	for _, param := range leaves {
+		row := TableRow{
+			ID: param.Name,
+			Cells: []string{
+				strings.TrimPrefix(param.Name, l.path),
+				param.Type,
+				fmt.Sprintf("%d", param.Version),
+				orDash(param.Tier),
+				formatTime(param.LastModified),
+				orDash(param.LastModifiedUser),
+			},
+			Ref: param,
+		}
+		if param.Type == awsservices.SecureStringType {
+			row.Colors = map[int]tcell.Color{1: tcell.ColorYellow}
+		}
+		rows = append(rows, row)
+	}
+	l.SetRows(rows)
+}
+
+// open drills into a folder or shows a parameter, masking SecureString values
+func (l *ParameterList) open(row TableRow) {
+	switch ref := row.Ref.(type) {
+	case awsservices.ParameterFolder:
+		l.session.Layout.Push(NewParameterList(l.session, ref.Path))
+	case awsservices.Parameter:
+		lines := []string{
+			"Name:          " + ref.Name,
+			"Type:          " + ref.Type,
+			"Version:       " + fmt.Sprintf("%d", ref.Version),
+			"Tier:          " + orDash(ref.Tier),
+			"Data type:     " + orDash(ref.DataType),
+			"Last modified: " + formatTime(ref.LastModified),
+			"Modified by:   " + orDash(ref.LastModifiedUser),
+			"Description:   " + orDash(ref.Description),
+		}
+		showParameterValue(l.session, "Parameter: "+ref.Name, lines, ref.Name, ref.Type, ref.Value)
+	}
+}
+
+// selectedParameter returns the parameter under the cursor
+func (l *ParameterList) selectedParameter() (awsservices.Parameter, bool) {
+	row, ok := l.SelectedRow()
+	if !ok {
+		return awsservices.Parameter{}, false
+	}
+	param, ok := row.Ref.(awsservices.Parameter)
+	return param, ok
+}
+
+func (l *ParameterList) showHistory() {
+	param, ok := l.selectedParameter()
+	if !ok {
+		l.session.Layout.SetStatus("Select a parameter to show its history")
+		return
+	}
+	l.session.Layout.Push(NewParameterHistoryList(l.session, param.Name))
+}
+
+// put opens the put form, filled in with the selected parameter to overwrite it
+func (l *ParameterList) put() {
+	param, ok := l.selectedParameter()
+	if !ok {
+		param = awsservices.Parameter{Name: l.path, Type: "String"}
+	}
+	putParameter(l.session, param, l.params, l.LoadData)
+}
+
+// putParameter lets the user create a parameter or overwrite one. Overwriting an existing
+// parameter needs confirmation, since the previous value is only kept in its history.
+func putParameter(session *Session, param awsservices.Parameter, existing []awsservices.Parameter, onPut func()) {
+	form := tview.NewForm()
+	form.AddInputField("Name", param.Name, 0, nil, nil)
+	form.AddDropDown("Type", parameterTypes, max(slices.Index(parameterTypes, param.Type), 0), nil)
+	form.AddTextArea("Value", param.Value, 0, 8, 0, nil)
+	form.AddButton("Put", func() {
+		name := strings.TrimSpace(form.GetFormItem(0).(*tview.InputField).GetText())
+		_, paramType := form.GetFormItem(1).(*tview.DropDown).GetCurrentOption()
+		value := form.GetFormItem(2).(*tview.TextArea).GetText()
+		if name == "" || strings.HasSuffix(name, "/") || value == "" {
+			session.Layout.SetStatus("Enter a parameter name and a value first")
+			return
+		}
+
+		i := slices.IndexFunc(existing, func(p awsservices.Parameter) bool { return p.Name == name })
+		overwrite := i >= 0
+		apply := func() {
+			version, err := awsservices.PutParameter(context.Background(), session.Config, name, value, paramType, overwrite)
+			session.Audit("ssm:PutParameter", []string{name}, map[string]interface{}{"type": paramType, "overwrite": overwrite}, err)
+			if err != nil {
+				session.Layout.ShowError(err)
+				return
+			}
+			session.Layout.CloseModal("ssm-put")
+			session.Layout.SetStatus(fmt.Sprintf("Put %s, now at version %d", name, version))
+			onPut()
+		}
+
+		if overwrite {
+			text := fmt.Sprintf("Overwrite %s?\n\nVersion %d is replaced as the current value and only kept in the history.", name, existing[i].Version)
+			session.ConfirmChange(text, apply)
+		} else {
+			session.AllowChange(fmt.Sprintf("Create %s as a %s parameter?", name, paramType), apply)
+		}
+	})
+	form.AddButton("Cancel", func() {
+		session.Layout.CloseModal("ssm-put")
+	})
+	form.SetCancelFunc(func() {
+		session.Layout.CloseModal("ssm-put")
+	})
+
+	form.SetBorder(true)
+	form.SetTitle("Put parameter")
+	form.SetTitleAlign(tview.AlignLeft)
+
+	session.Layout.ShowModal("ssm-put", centered(form, 90, 20))
+}
+
+// ParameterHistoryList represents the versions of a parameter
+type ParameterHistoryList struct {
+	*DataTable
+	session *Session
+	name    string
+}
+
+// NewParameterHistoryList creates a new version history of a parameter
+func NewParameterHistoryList(session *Session, name string) *ParameterHistoryList {
+	list := &ParameterHistoryList{
+		DataTable: NewDataTable(session.Layout, "History: "+name, []Column{
+			{"Version", "version", 8},
+			{"Type", "type", 14},
+			{"Last Modified", "modified", 20},
+			{"Modified By", "modified_by", 40},
+			{"Labels", "labels", 20},
+			{"Value", "value", 60},
+		}),
+		session: session,
+		name:    name,
+	}
+
+	// Set up selection handler
+	list.SetOpenFunc(list.open)
+
+	list.LoadData()
+	list.SortBy(0, true)
+
+	return list
+}
+
+// LoadData loads the versions of the parameter from AWS
+func (l *ParameterHistoryList) LoadData() {
+	versions, err := awsservices.GetParameterHistory(context.Background(), l.session.Config, l.name)
+	if err != nil {
+		l.SetError(err)
+		return
+	}
+
+	rows := make([]TableRow, len(versions))
+	for i, version := range versions {
+		value := strings.Join(strings.Fields(version.Value), " ")
+		if version.Type == awsservices.SecureStringType {
+			value = valueMask
+		}
+		rows[i] = TableRow{
+			ID: fmt.Sprintf("%d", version.Version),
+			Cells: []string{
+				fmt.Sprintf("%d", version.Version),
+				version.Type,
+				formatTime(version.LastModified),
+				orDash(version.User),
+				orDash(strings.Join(version.Labels, ", ")),
+				value,
+			},
+			Ref: version,
+		}
+	}
+	l.SetRows(rows)
+}
+
+// open shows a version, masking SecureString values
+func (l *ParameterHistoryList) open(row TableRow) {
+	version := row.Ref.(awsservices.ParameterVersion)
+	lines := []string{
+		"Name:          " + l.name,
+		"Type:          " + version.Type,
+		"Version:       " + fmt.Sprintf("%d", version.Version),
+		"Labels:        " + orDash(strings.Join(version.Labels, ", ")),
+		"Last modified: " + formatTime(version.LastModified),
+		"Modified by:   " + orDash(version.User),
+		"Description:   " + orDash(version.Description),
+	}
+	selector := fmt.Sprintf("%s:%d", l.name, version.Version)
+	showParameterValue(l.session, "Parameter: "+selector, lines, selector, version.Type, version.Value)
+}
+
+// showParameterValue pushes a view of a parameter value. SecureString values stay masked and are
+// only decrypted when revealed, other values are shown right away.
+func showParameterValue(session *Session, title string, lines []string, name, paramType, value string) {
+	load := func() (string, error) {
+		return awsservices.GetParameterValue(context.Background(), session.Config, name)
+	}
+	if paramType != awsservices.SecureStringType {
+		load = func() (string, error) { return value, nil }
+	}
+
+	view := NewMaskedView(session, title, strings.Join(lines, "\n")+"\n", load)
+	session.Layout.Push(view)
+	if paramType != awsservices.SecureStringType {
+		view.Reveal()
+	}
+}
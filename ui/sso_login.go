@@ -0,0 +1,83 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Ninad-Bhangui/awstui/aws"
+	"github.com/Ninad-Bhangui/awstui/clipboard"
+	"github.com/rivo/tview"
+)
+
+// ssoLogin runs the SSO device authorization flow in a modal and calls onDone once the token is cached
+func ssoLogin(layout *Layout, profileName string, settings *aws.SSOSettings, onDone func()) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	layout.SetStatus("Starting SSO login for " + profileName)
+	auth, err := settings.StartDeviceAuthorization(ctx)
+	if err != nil {
+		cancel()
+		layout.ShowError(err)
+		return
+	}
+
+	url := auth.VerificationURIComplete
+	if url == "" {
+		url = auth.VerificationURI
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "The SSO token for [::b]%s[::-] is missing or expired.\n\n", tview.Escape(profileName))
+	fmt.Fprintf(&b, "Open this URL in a browser:\n  [blue]%s[-]\n\n", tview.Escape(url))
+	fmt.Fprintf(&b, "and confirm the code:\n  [yellow::b]%s[-::-]\n\n", tview.Escape(auth.UserCode))
+	fmt.Fprintf(&b, "Waiting for approval (expires at %s)...", auth.ExpiresAt.Format("15:04:05"))
+
+	message := tview.NewTextView().
+		SetDynamicColors(true).
+		SetWrap(true).
+		SetText(b.String())
+
+	buttons := tview.NewForm()
+	buttons.AddButton("Copy URL", func() {
+		if err := clipboard.Copy(url); err != nil {
+			layout.SetStatus(fmt.Sprintf("Failed to copy URL: %v", err))
+			return
+		}
+		layout.SetStatus("Copied SSO URL to the clipboard")
+	})
+	buttons.AddButton("Cancel", func() {
+		cancel()
+	})
+	buttons.SetCancelFunc(cancel)
+	buttons.SetButtonsAlign(tview.AlignRight)
+
+	dialog := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(message, 0, 1, false).
+		AddItem(buttons, 3, 0, true)
+	dialog.SetBorder(true)
+	dialog.SetTitle("SSO Login: " + tview.Escape(settings.StartURL))
+	dialog.SetTitleAlign(tview.AlignLeft)
+
+	layout.ShowModal("sso-login", centered(dialog, 80, 16))
+	layout.SetStatus("Waiting for SSO approval")
+
+	// Poll for the token without blocking the UI
+	go func() {
+		err := auth.Wait(ctx)
+		cancel()
+		layout.QueueUpdateDraw(func() {
+			layout.CloseModal("sso-login")
+			switch {
+			case errors.Is(err, context.Canceled):
+				layout.SetStatus("SSO login cancelled")
+			case err != nil:
+				layout.ShowError(err)
+			default:
+				layout.SetStatus("SSO login succeeded")
+				onDone()
+			}
+		})
+	}()
+}
@@ -0,0 +1,301 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// TableRow represents a single row of a DataTable
+type TableRow struct {
+	ID       string
+	Cells    []string
+	Colors   map[int]tcell.Color // Optional text color per column
+	SortKeys map[int]string      // Optional sort value per column
+	Ref      interface{}         // The resource the row was built from
+}
+
+// DataTable represents a table whose rows can be sorted, filtered and marked
+type DataTable struct {
+	*tview.Table
+	layout   *Layout
+	title    string
+	columns  []Column
+	rows     []TableRow
+	visible  []TableRow
+	sortCol  int
+	sortDesc bool
+	filter   string
+	marked   map[string]bool
+	onOpen   func(row TableRow)
+}
+
+// NewDataTable creates a new data table with the given columns
+func NewDataTable(layout *Layout, title string, columns []Column) *DataTable {
+	table := &DataTable{
+		Table:   tview.NewTable().SetSelectable(true, false).SetFixed(1, 0),
+		layout:  layout,
+		title:   title,
+		columns: columns,
+		sortCol: -1,
+		marked:  make(map[string]bool),
+	}
+
+	// Set up table
+	table.SetBorder(true)
+	table.SetTitleAlign(tview.AlignLeft)
+
+	// Set up selection handler
+	table.Table.SetSelectedFunc(func(row, col int) {
+		if r, ok := table.SelectedRow(); ok && table.onOpen != nil {
+			table.onOpen(r)
+		}
+	})
+
+	table.render()
+
+	return table
+}
+
+// SetOpenFunc sets the handler called when a row is opened with Enter
+func (t *DataTable) SetOpenFunc(handler func(row TableRow)) {
+	t.onOpen = handler
+}
+
+// SetRows replaces the rows of the table
+func (t *DataTable) SetRows(rows []TableRow) {
+	t.rows = rows
+	t.render()
+}
+
+// SetColumns replaces the columns of the table, such as for query results, and clears the sort order
+func (t *DataTable) SetColumns(columns []Column) {
+	t.columns = columns
+	t.sortCol = -1
+	t.render()
+}
+
+// SetTitle changes the title shown before the row count, such as to reflect a state change
+func (t *DataTable) SetTitle(title string) {
+	t.title = title
+	t.render()
+}
+
+// SortBy sorts the table by the given column
+func (t *DataTable) SortBy(col int, desc bool) {
+	t.sortCol = col
+	t.sortDesc = desc
+	t.render()
+}
+
+// SetError replaces the table contents with an error message
+func (t *DataTable) SetError(err error) {
+	t.rows = nil
+	t.visible = nil
+	t.Clear()
+	t.SetCell(0, 0, tview.NewTableCell(fmt.Sprintf("Error: %v", err)).SetTextColor(tcell.ColorRed))
+}
+
+// SelectedRow returns the row under the cursor
+func (t *DataTable) SelectedRow() (TableRow, bool) {
+	row, _ := t.GetSelection()
+	if row < 1 || row > len(t.visible) {
+		return TableRow{}, false
+	}
+	return t.visible[row-1], true
+}
+
+// MarkedRows returns the marked rows, or the selected row when none are marked
+func (t *DataTable) MarkedRows() []TableRow {
+	var rows []TableRow
+	for _, row := range t.rows {
+		if t.marked[row.ID] {
+			rows = append(rows, row)
+		}
+	}
+	if len(rows) == 0 {
+		if row, ok := t.SelectedRow(); ok {
+			rows = append(rows, row)
+		}
+	}
+	return rows
+}
+
+// VisibleRows returns the filtered and sorted rows as displayed
+func (t *DataTable) VisibleRows() []TableRow {
+	return t.visible
+}
+
+// Columns returns the table columns
+func (t *DataTable) Columns() []Column {
+	return t.columns
+}
+
+// GetTitle returns the plain title of the table
+func (t *DataTable) GetTitle() string {
+	return t.title
+}
+
+// Actions returns the key bindings for sorting, filtering and marking
+func (t *DataTable) Actions() []KeyAction {
+	return []KeyAction{
+		newAction('/', "Filter", t.promptFilter),
+		newAction('o', "Sort column", t.cycleSort),
+		newAction('O', "Reverse sort", t.reverseSort),
+		newAction(' ', "Mark", t.toggleMark),
+	}
+}
+
+func (t *DataTable) promptFilter() {
+	t.layout.Prompt("Filter", t.filter, func(text string) {
+		t.filter = strings.TrimSpace(text)
+		t.render()
+		t.Select(1, 0)
+	})
+}
+
+func (t *DataTable) cycleSort() {
+	if len(t.columns) == 0 {
+		return
+	}
+	t.sortCol = (t.sortCol + 1) % len(t.columns)
+	t.render()
+}
+
+func (t *DataTable) reverseSort() {
+	t.sortDesc = !t.sortDesc
+	t.render()
+}
+
+func (t *DataTable) toggleMark() {
+	row, ok := t.SelectedRow()
+	if !ok {
+		return
+	}
+	if t.marked[row.ID] {
+		delete(t.marked, row.ID)
+	} else {
+		t.marked[row.ID] = true
+	}
+	t.render()
+
+	// Move down so several rows can be marked in a row
+	selected, _ := t.GetSelection()
+	if selected < len(t.visible) {
+		t.Select(selected+1, 0)
+	}
+}
+
+// render rebuilds the table cells from the current rows, filter and sort order
+func (t *DataTable) render() {
+	selected, _ := t.GetSelection()
+
+	// Apply filter
+	t.visible = t.visible[:0]
+	needle := strings.ToLower(t.filter)
+	for _, row := range t.rows {
+		if needle == "" || rowMatches(row, needle) {
+			t.visible = append(t.visible, row)
+		}
+	}
+
+	// Apply sort
+	if t.sortCol >= 0 {
+		col := t.sortCol
+		sort.SliceStable(t.visible, func(i, j int) bool {
+			a, b := sortValue(t.visible[i], col), sortValue(t.visible[j], col)
+			if t.sortDesc {
+				return lessCell(b, a)
+			}
+			return lessCell(a, b)
+		})
+	}
+
+	t.Clear()
+
+	// Set up headers
+	for i, col := range t.columns {
+		title := col.Title
+		if i == t.sortCol {
+			if t.sortDesc {
+				title += " ▼"
+			} else {
+				title += " ▲"
+			}
+		}
+		cell := tview.NewTableCell(title).
+			SetTextColor(tcell.ColorYellow).
+			SetSelectable(false).
+			SetExpansion(1)
+		if col.Width > 0 {
+			cell.SetMaxWidth(col.Width)
+		}
+		t.SetCell(0, i, cell)
+	}
+
+	// Add rows
+	for i, row := range t.visible {
+		for j, text := range row.Cells {
+			cell := tview.NewTableCell(text)
+			if color, ok := row.Colors[j]; ok {
+				cell.SetTextColor(color)
+			}
+			if t.marked[row.ID] {
+				cell.SetBackgroundColor(tcell.ColorDarkCyan)
+			}
+			t.SetCell(i+1, j, cell)
+		}
+	}
+
+	// Update title with count and filter
+	title := fmt.Sprintf("%s (%d)", t.title, len(t.visible))
+	if t.filter != "" {
+		title += fmt.Sprintf(" [/%s]", t.filter)
+	}
+	if len(t.marked) > 0 {
+		title += fmt.Sprintf(" [%d marked]", len(t.marked))
+	}
+	t.Table.SetTitle(tview.Escape(title))
+
+	// Keep the cursor within the rows
+	if selected > len(t.visible) {
+		selected = len(t.visible)
+	}
+	if selected < 1 {
+		selected = 1
+	}
+	t.Select(selected, 0)
+}
+
+func rowMatches(row TableRow, needle string) bool {
+	for _, text := range row.Cells {
+		if strings.Contains(strings.ToLower(text), needle) {
+			return true
+		}
+	}
+	return false
+}
+
+func sortValue(row TableRow, col int) string {
+	if key, ok := row.SortKeys[col]; ok {
+		return key
+	}
+	if col < len(row.Cells) {
+		return row.Cells[col]
+	}
+	return ""
+}
+
+// lessCell compares two cells numerically when both are numbers
+func lessCell(a, b string) bool {
+	af, aErr := strconv.ParseFloat(a, 64)
+	bf, bErr := strconv.ParseFloat(b, 64)
+	if aErr == nil && bErr == nil {
+		return af < bf
+	}
+	return strings.ToLower(a) < strings.ToLower(b)
+}
@@ -0,0 +1,124 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// Tab represents a single page of a TabView
+type Tab struct {
+	Name string
+	View tview.Primitive
+}
+
+// TabView represents a view with several tabs, switched with digits or Tab
+type TabView struct {
+	*tview.Flex
+	layout  *Layout
+	title   string
+	tabBar  *tview.TextView
+	pages   *tview.Pages
+	tabs    []Tab
+	current int
+	actions []KeyAction
+}
+
+// NewTabView creates a new tab view
+func NewTabView(layout *Layout, title string, tabs []Tab) *TabView {
+	view := &TabView{
+		Flex:   tview.NewFlex().SetDirection(tview.FlexRow),
+		layout: layout,
+		title:  title,
+		tabBar: tview.NewTextView().SetDynamicColors(true),
+		pages:  tview.NewPages(),
+		tabs:   tabs,
+	}
+
+	// Basic setup
+	view.SetBorder(true)
+	view.SetTitle(tview.Escape(title))
+	view.SetTitleAlign(tview.AlignLeft)
+
+	for i, tab := range tabs {
+		view.pages.AddPage(tab.Name, tab.View, true, i == 0)
+	}
+
+	view.AddItem(view.tabBar, 1, 0, false)
+	view.AddItem(view.pages, 0, 1, true)
+
+	// Cycle tabs with Tab and Shift+Tab
+	view.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Key() {
+		case tcell.KeyTab:
+			view.SelectTab((view.current + 1) % len(view.tabs))
+			return nil
+		case tcell.KeyBacktab:
+			view.SelectTab((view.current + len(view.tabs) - 1) % len(view.tabs))
+			return nil
+		}
+		return event
+	})
+
+	view.renderTabBar()
+
+	return view
+}
+
+// GetTitle returns the plain title of the view
+func (t *TabView) GetTitle() string {
+	return t.title
+}
+
+// SetActions sets extra key bindings offered on every tab
+func (t *TabView) SetActions(actions []KeyAction) {
+	t.actions = actions
+}
+
+// Actions returns the tab switching keys, the extra actions and those of the current tab
+func (t *TabView) Actions() []KeyAction {
+	var actions []KeyAction
+	for i := range t.tabs {
+		if i > 8 {
+			break
+		}
+		index := i
+		actions = append(actions, newAction(rune('1'+i), t.tabs[i].Name, func() { t.SelectTab(index) }))
+	}
+	actions = append(actions, t.actions...)
+	if view, ok := t.tabs[t.current].View.(interface{ Actions() []KeyAction }); ok {
+		actions = append(actions, view.Actions()...)
+	}
+	return actions
+}
+
+// SelectTab switches to the tab with the given index
+func (t *TabView) SelectTab(index int) {
+	if index < 0 || index >= len(t.tabs) {
+		return
+	}
+	t.current = index
+	t.pages.SwitchToPage(t.tabs[index].Name)
+	t.renderTabBar()
+	t.layout.SetFocus(t.pages)
+	t.layout.Refresh()
+}
+
+// CurrentTab returns the index of the selected tab
+func (t *TabView) CurrentTab() int {
+	return t.current
+}
+
+func (t *TabView) renderTabBar() {
+	var labels []string
+	for i, tab := range t.tabs {
+		label := fmt.Sprintf(" %d:%s ", i+1, tview.Escape(tab.Name))
+		if i == t.current {
+			label = "[black:yellow]" + label + "[-:-]"
+		}
+		labels = append(labels, label)
+	}
+	t.tabBar.SetText(strings.Join(labels, " "))
+}
@@ -0,0 +1,242 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	awsservices "github.com/Ninad-Bhangui/awstui/aws/services"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// TagView represents the tags of a single resource
+type TagView struct {
+	*DataTable
+	session *Session
+	target  awsservices.TagTarget
+}
+
+// NewTagView creates a new tag view for a resource
+func NewTagView(session *Session, target awsservices.TagTarget) *TagView {
+	view := &TagView{
+		DataTable: NewDataTable(session.Layout, "Tags: "+target.Name, []Column{
+			{"Key", "key", 40},
+			{"Value", "value", 80},
+		}),
+		session: session,
+		target:  target,
+	}
+
+	view.LoadData()
+
+	return view
+}
+
+// Actions returns the key bindings for the tag view
+func (v *TagView) Actions() []KeyAction {
+	return append(v.DataTable.Actions(), newWriteAction('e', "Edit tags", func() {
+		editTags(v.session, []awsservices.TagTarget{v.target}, v.LoadData)
+	}))
+}
+
+// LoadData loads the resource tags from AWS
+func (v *TagView) LoadData() {
+	tags, err := awsservices.GetTags(context.Background(), v.session.Config, v.target)
+	if err != nil {
+		v.SetError(err)
+		return
+	}
+
+	var rows []TableRow
+	for _, key := range sortedKeys(tags) {
+		rows = append(rows, TableRow{
+			ID:    key,
+			Cells: []string{key, tags[key]},
+		})
+	}
+	v.SetRows(rows)
+}
+
+// editTags opens the tag editor for one or more resources and applies the reviewed changes
+func editTags(session *Session, targets []awsservices.TagTarget, onDone func()) {
+	if len(targets) == 0 {
+		return
+	}
+	ctx := context.Background()
+
+	// Load the current tags of every resource
+	current := make([]map[string]string, len(targets))
+	for i, target := range targets {
+		tags, err := awsservices.GetTags(ctx, session.Config, target)
+		if err != nil {
+			session.Layout.ShowError(fmt.Errorf("%s: %w", target.Name, err))
+			return
+		}
+		current[i] = tags
+	}
+	shared := awsservices.SharedTags(current)
+
+	// Show the shared tags as editable key=value lines
+	var lines []string
+	for _, key := range sortedKeys(shared) {
+		if !strings.HasPrefix(key, "aws:") {
+			lines = append(lines, key+"="+shared[key])
+		}
+	}
+
+	title := "Edit tags: " + targets[0].Name
+	if len(targets) > 1 {
+		title = fmt.Sprintf("Edit tags: %d resources (shared tags only)", len(targets))
+	}
+
+	form := tview.NewForm()
+	form.AddTextArea("key=value", strings.Join(lines, "\n"), 0, 15, 0, nil)
+	form.AddButton("Review", func() {
+		text := form.GetFormItem(0).(*tview.TextArea).GetText()
+		edited, err := parseTagLines(text)
+		if err != nil {
+			session.Layout.ShowError(err)
+			return
+		}
+
+		// Work out the changes for every resource
+		changes := make([][]awsservices.TagChange, len(targets))
+		total := 0
+		for i := range targets {
+			desired := awsservices.MergeTagEdit(current[i], shared, edited)
+			changes[i] = awsservices.DiffTags(current[i], desired)
+			total += len(changes[i])
+		}
+		if total == 0 {
+			session.Layout.CloseModal("tags")
+			session.Layout.SetStatus("No tag changes")
+			return
+		}
+
+		reviewTagChanges(session, targets, changes, func() {
+			session.Layout.CloseModal("tags")
+			onDone()
+		})
+	})
+	form.AddButton("Cancel", func() {
+		session.Layout.CloseModal("tags")
+	})
+	form.SetCancelFunc(func() {
+		session.Layout.CloseModal("tags")
+	})
+
+	form.SetBorder(true)
+	form.SetTitle(tview.Escape(title))
+	form.SetTitleAlign(tview.AlignLeft)
+
+	session.Layout.ShowModal("tags", centered(form, 80, 21))
+}
+
+// reviewTagChanges shows a diff of the tag changes and applies them once confirmed
+func reviewTagChanges(session *Session, targets []awsservices.TagTarget, changes [][]awsservices.TagChange, onApplied func()) {
+	var b strings.Builder
+	for i, target := range targets {
+		if len(changes[i]) == 0 {
+			continue
+		}
+		fmt.Fprintf(&b, "[::b]%s[::-]\n", tview.Escape(target.Name))
+		for _, change := range changes[i] {
+			key := tview.Escape(change.Key)
+			switch change.Action {
+			case "add":
+				fmt.Fprintf(&b, "  [green]+ %s = %s[-]\n", key, tview.Escape(change.NewValue))
+			case "update":
+				fmt.Fprintf(&b, "  [yellow]~ %s: %s → %s[-]\n", key, tview.Escape(change.OldValue), tview.Escape(change.NewValue))
+			case "remove":
+				fmt.Fprintf(&b, "  [red]- %s (%s)[-]\n", key, tview.Escape(change.OldValue))
+			}
+		}
+	}
+
+	diff := tview.NewTextView().SetDynamicColors(true).SetScrollable(true)
+	diff.SetText(b.String())
+
+	buttons := tview.NewForm()
+	buttons.AddButton("Apply", func() {
+		session.Layout.CloseModal("tag-review")
+
+		session.AllowChange(fmt.Sprintf("Apply tag changes to %d resource(s)?", len(targets)), func() {
+			ctx := context.Background()
+			updated := 0
+			for i, target := range targets {
+				if len(changes[i]) == 0 {
+					continue
+				}
+				err := awsservices.ApplyTagChanges(ctx, session.Config, target, changes[i])
+				session.Audit(target.Service+":UpdateTags", []string{target.ID}, tagChangeParams(changes[i]), err)
+				if err != nil {
+					session.Layout.ShowError(fmt.Errorf("%s: %w", target.Name, err))
+					break
+				}
+				updated++
+			}
+			session.Layout.SetStatus(fmt.Sprintf("Updated tags on %d resource(s)", updated))
+			onApplied()
+		})
+	})
+	buttons.AddButton("Back", func() {
+		session.Layout.CloseModal("tag-review")
+	})
+	buttons.SetCancelFunc(func() {
+		session.Layout.CloseModal("tag-review")
+	})
+	buttons.SetButtonsAlign(tview.AlignRight)
+
+	dialog := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(diff, 0, 1, false).
+		AddItem(buttons, 3, 0, true)
+	dialog.SetBorder(true)
+	dialog.SetTitle("Review tag changes")
+	dialog.SetTitleAlign(tview.AlignLeft)
+	dialog.SetBorderColor(tcell.ColorYellow)
+
+	session.Layout.ShowModal("tag-review", centered(dialog, 80, 20))
+}
+
+// tagChangeParams describes tag changes for the audit log
+func tagChangeParams(changes []awsservices.TagChange) map[string]interface{} {
+	set := make(map[string]interface{})
+	var remove []interface{}
+	for _, change := range changes {
+		if change.Action == "remove" {
+			remove = append(remove, change.Key)
+		} else {
+			set[change.Key] = change.NewValue
+		}
+	}
+	return map[string]interface{}{"set": set, "remove": remove}
+}
+
+// parseTagLines parses key=value lines, ignoring blank lines and # comments
+func parseTagLines(text string) (map[string]string, error) {
+	tags := make(map[string]string)
+	for i, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		key, value, found := strings.Cut(line, "=")
+		key = strings.TrimSpace(key)
+		if !found || key == "" {
+			return nil, fmt.Errorf("line %d: expected key=value", i+1)
+		}
+		tags[key] = strings.TrimSpace(value)
+	}
+	return tags, nil
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
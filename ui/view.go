@@ -0,0 +1,54 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rivo/tview"
+)
+
+// View represents a screen that can be shown on the layout's navigation stack
+type View interface {
+	tview.Primitive
+	GetTitle() string
+	Actions() []KeyAction
+}
+
+// KeyAction represents a key binding offered by a view
+type KeyAction struct {
+	Key         rune
+	Description string
+	Handler     func()
+	Mutating    bool // Changes AWS resources, disabled in read-only mode
+}
+
+// newAction creates a key binding that only reads data
+func newAction(key rune, description string, handler func()) KeyAction {
+	return KeyAction{Key: key, Description: description, Handler: handler}
+}
+
+// newWriteAction creates a key binding that changes AWS resources
+func newWriteAction(key rune, description string, handler func()) KeyAction {
+	return KeyAction{Key: key, Description: description, Handler: handler, Mutating: true}
+}
+
+// keyLabel returns the display label for an action key
+func keyLabel(key rune) string {
+	if key == ' ' {
+		return "space"
+	}
+	return string(key)
+}
+
+// formatKeybindings renders actions as header hints, greying out write actions in read-only mode
+func formatKeybindings(actions []KeyAction, readOnly bool) string {
+	hints := []string{"<?> Help", "<:> Quick Nav", "<q> Back"}
+	for _, action := range actions {
+		hint := tview.Escape(fmt.Sprintf("<%s> %s", keyLabel(action.Key), action.Description))
+		if action.Mutating && readOnly {
+			hint = "[gray]" + hint + "[-]"
+		}
+		hints = append(hints, hint)
+	}
+	return strings.Join(hints, " • ")
+}